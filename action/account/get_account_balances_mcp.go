@@ -0,0 +1,95 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+)
+
+var GetAccountBalancesMCPDefinition = mcp.Tool{
+	Name: "get_account_balances",
+	Description: "Running balance of every registered account in its own currency: opening balance plus incoming minus outgoing transactions up to 'at' (default now). " +
+		"Transactions in other currencies are not converted but netted per currency in other_currencies. " +
+		"Also returns the latest bank-reported balance from imports and account names used by transactions but not registered via save_account.",
+}
+
+// GetAccountBalancesInput is the MCP tool input.
+type GetAccountBalancesInput struct {
+	At *time.Time `json:"at,omitempty" jsonschema:"Balance moment (default now)"`
+}
+
+// AccountBalanceOutput is one account with its computed balance.
+type AccountBalanceOutput struct {
+	AccountOutput
+	Balance                float64                 `json:"balance"`
+	OtherCurrencies        []CurrencyBalanceOutput `json:"other_currencies,omitempty"`
+	TransactionCount       int                     `json:"transaction_count"`
+	LastTransactionAt      *time.Time              `json:"last_transaction_at,omitempty"`
+	LastStatementBalance   *float64                `json:"last_statement_balance,omitempty"`
+	LastStatementBalanceAt *time.Time              `json:"last_statement_balance_at,omitempty"`
+}
+
+// CurrencyBalanceOutput is the net of an account's transactions in another
+// currency.
+type CurrencyBalanceOutput struct {
+	Currency string  `json:"currency"`
+	Balance  float64 `json:"balance"`
+}
+
+// GetAccountBalancesOutput is the MCP tool output.
+type GetAccountBalancesOutput struct {
+	At                   time.Time              `json:"at"`
+	Accounts             []AccountBalanceOutput `json:"accounts"`
+	UnregisteredAccounts []string               `json:"unregistered_accounts,omitempty"`
+}
+
+func GetAccountBalances(ctx context.Context, _ *mcp.CallToolRequest, input GetAccountBalancesInput) (*mcp.CallToolResult, GetAccountBalancesOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, GetAccountBalancesOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, GetAccountBalancesOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	at := time.Now().UTC()
+	if input.At != nil {
+		at = *input.At
+	}
+
+	balances, err := db.GetAccountBalances(ctx, userID, at)
+	if err != nil {
+		return nil, GetAccountBalancesOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	unregistered, err := db.ListUnregisteredAccounts(ctx, userID)
+	if err != nil {
+		return nil, GetAccountBalancesOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	out := GetAccountBalancesOutput{
+		At:                   at,
+		Accounts:             make([]AccountBalanceOutput, len(balances)),
+		UnregisteredAccounts: unregistered,
+	}
+	for i, b := range balances {
+		var other []CurrencyBalanceOutput
+		for _, cb := range b.OtherCurrencies {
+			other = append(other, CurrencyBalanceOutput{Currency: cb.Currency, Balance: cb.Balance})
+		}
+		out.Accounts[i] = AccountBalanceOutput{
+			AccountOutput:          toOutput(b.Account),
+			Balance:                b.Balance,
+			OtherCurrencies:        other,
+			TransactionCount:       b.TransactionCount,
+			LastTransactionAt:      b.LastTransactionAt,
+			LastStatementBalance:   b.LastStatementBalance,
+			LastStatementBalanceAt: b.LastStatementBalanceAt,
+		}
+	}
+	return nil, out, nil
+}
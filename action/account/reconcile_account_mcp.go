@@ -0,0 +1,118 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+// defaultTolerance absorbs rounding in bank exports.
+const defaultTolerance = 0.01
+
+var ReconcileAccountMCPDefinition = mcp.Tool{
+	Name: "reconcile_account",
+	Description: "Compare an account's computed running balance with the Balance column imported from Revolut and Bank of Cyprus exports. " +
+		"Returns every day where the difference changed: the first one points at a wrong opening balance, later ones at missing or duplicated transactions since the previous checkpoint.",
+}
+
+// ReconcileAccountInput is the MCP tool input.
+type ReconcileAccountInput struct {
+	Account   string     `json:"account" jsonschema:"Registered account name"`
+	To        *time.Time `json:"to,omitempty" jsonschema:"Reconcile up to this moment (default now)"`
+	Tolerance float64    `json:"tolerance,omitempty" jsonschema:"Allowed difference (default 0.01)"`
+}
+
+// ReconciliationGapOutput is one checkpoint where balances drifted apart.
+type ReconciliationGapOutput struct {
+	Date             time.Time `json:"date"`
+	TransactionID    int64     `json:"transaction_id"`
+	ComputedBalance  float64   `json:"computed_balance"`
+	StatementBalance float64   `json:"statement_balance"`
+	Difference       float64   `json:"difference"`
+	DriftSincePrev   float64   `json:"drift_since_previous"`
+}
+
+// ReconcileAccountOutput is the MCP tool output.
+type ReconcileAccountOutput struct {
+	Account            AccountOutput             `json:"account"`
+	Status             string                    `json:"status"` // ok, gaps_found, no_statement_balances
+	ComputedBalance    float64                   `json:"computed_balance"`
+	StatementBalance   *float64                  `json:"statement_balance,omitempty"`
+	Difference         float64                   `json:"difference"`
+	CheckpointsChecked int                       `json:"checkpoints_checked"`
+	Gaps               []ReconciliationGapOutput `json:"gaps"`
+	Error              string                    `json:"error,omitempty"`
+}
+
+func ReconcileAccount(ctx context.Context, _ *mcp.CallToolRequest, input ReconcileAccountInput) (*mcp.CallToolResult, ReconcileAccountOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, ReconcileAccountOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, ReconcileAccountOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	if input.Account == "" {
+		return nil, ReconcileAccountOutput{Error: "account is required"}, nil
+	}
+	if input.Tolerance < 0 {
+		return nil, ReconcileAccountOutput{Error: "tolerance must not be negative"}, nil
+	}
+	tolerance := input.Tolerance
+	if tolerance == 0 {
+		tolerance = defaultTolerance
+	}
+	to := time.Now().UTC()
+	if input.To != nil {
+		to = *input.To
+	}
+
+	account, err := db.GetAccount(ctx, userID, input.Account)
+	if err != nil {
+		return nil, ReconcileAccountOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	if account == nil {
+		return nil, ReconcileAccountOutput{Error: fmt.Sprintf("account %q is not registered — use save_account first", input.Account)}, nil
+	}
+
+	txs, err := db.ListAccountTransactions(ctx, userID, account.Name, account.OpenedAt, to)
+	if err != nil {
+		return nil, ReconcileAccountOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	rec := domain.Reconcile(*account, txs, tolerance)
+
+	out := ReconcileAccountOutput{
+		Account:            toOutput(*account),
+		Status:             "ok",
+		ComputedBalance:    rec.ComputedBalance,
+		StatementBalance:   rec.StatementBalance,
+		Difference:         rec.Difference,
+		CheckpointsChecked: rec.CheckpointsChecked,
+		Gaps:               make([]ReconciliationGapOutput, len(rec.Gaps)),
+	}
+	switch {
+	case rec.CheckpointsChecked == 0:
+		out.Status = "no_statement_balances"
+	case len(rec.Gaps) > 0:
+		out.Status = "gaps_found"
+	}
+	for i, g := range rec.Gaps {
+		out.Gaps[i] = ReconciliationGapOutput{
+			Date:             g.Date,
+			TransactionID:    g.TransactionID,
+			ComputedBalance:  g.ComputedBalance,
+			StatementBalance: g.StatementBalance,
+			Difference:       g.Difference,
+			DriftSincePrev:   g.DriftSincePrev,
+		}
+	}
+	return nil, out, nil
+}
@@ -0,0 +1,105 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+var SaveAccountMCPDefinition = mcp.Tool{
+	Name: "save_account",
+	Description: "Register or update an account (checking, savings, credit, cash) with currency and opening balance. " +
+		"Upserts by name, case-insensitively; transactions spelled differently (\"revolut\" vs \"Revolut\") are renamed to the account name.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Save account",
+	},
+}
+
+// SaveAccountInput is the MCP tool input.
+type SaveAccountInput struct {
+	Name           string     `json:"name" jsonschema:"Account name as used in transactions, e.g. Revolut"`
+	Currency       string     `json:"currency,omitempty" jsonschema:"ISO 4217 code (default EUR)"`
+	Type           string     `json:"type,omitempty" jsonschema:"checking, savings, credit or cash (default checking)"`
+	OpeningBalance float64    `json:"opening_balance,omitempty" jsonschema:"Balance at opened_at, before any recorded transaction"`
+	OpenedAt       *time.Time `json:"opened_at,omitempty" jsonschema:"Transactions before this moment are not counted (default: all history)"`
+}
+
+// AccountOutput mirrors domain.Account for JSON serialization.
+type AccountOutput struct {
+	ID             int64     `json:"id"`
+	Name           string    `json:"name"`
+	Currency       string    `json:"currency"`
+	Type           string    `json:"type"`
+	OpeningBalance float64   `json:"opening_balance"`
+	OpenedAt       time.Time `json:"opened_at"`
+}
+
+// SaveAccountOutput is the MCP tool output.
+type SaveAccountOutput struct {
+	Account AccountOutput `json:"account"`
+	Error   string        `json:"error,omitempty"`
+}
+
+func SaveAccount(ctx context.Context, _ *mcp.CallToolRequest, input SaveAccountInput) (*mcp.CallToolResult, SaveAccountOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, SaveAccountOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, SaveAccountOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	a := domain.Account{
+		UserID:         userID,
+		Name:           strings.TrimSpace(input.Name),
+		Currency:       strings.ToUpper(strings.TrimSpace(input.Currency)),
+		Type:           domain.AccountType(input.Type),
+		OpeningBalance: input.OpeningBalance,
+	}
+	if a.Name == "" {
+		return nil, SaveAccountOutput{Error: "name is required"}, nil
+	}
+	if a.Currency == "" {
+		a.Currency = "EUR"
+	}
+	if len(a.Currency) != 3 {
+		return nil, SaveAccountOutput{Error: "currency must be exactly 3 characters (ISO 4217)"}, nil
+	}
+	if a.Type == "" {
+		a.Type = domain.AccountTypeChecking
+	}
+	if !a.Type.IsValid() {
+		return nil, SaveAccountOutput{Error: "type must be one of: checking, savings, credit, cash"}, nil
+	}
+	if input.OpenedAt != nil {
+		a.OpenedAt = *input.OpenedAt
+	}
+
+	id, err := db.SaveAccount(ctx, &a)
+	if err != nil {
+		return nil, SaveAccountOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	a.ID = id
+
+	return nil, SaveAccountOutput{Account: toOutput(a)}, nil
+}
+
+func toOutput(a domain.Account) AccountOutput {
+	return AccountOutput{
+		ID:             a.ID,
+		Name:           a.Name,
+		Currency:       a.Currency,
+		Type:           string(a.Type),
+		OpeningBalance: a.OpeningBalance,
+		OpenedAt:       a.OpenedAt,
+	}
+}
@@ -32,22 +32,48 @@ type TransactionInput struct {
 	Merchant            string    `json:"merchant,omitempty"`
 	Note                *string   `json:"note,omitempty"`
 	OriginalDescription *string   `json:"original_description,omitempty"`
+	Direction           string    `json:"direction,omitempty" jsonschema:"Money into or out of the account: in or out. Defaults to in for income, out otherwise; set it for incoming transfers"`
 	TransactedAt        time.Time `json:"transacted_at"`
 }
 
 // TransactionOutput mirrors domain.Transaction for JSON serialization.
 type TransactionOutput struct {
-	ID                  int64     `json:"id"`
-	Type                string    `json:"type"`
-	AmountOriginal      float64   `json:"amount_original"`
-	Currency            string    `json:"currency"`
-	AmountEUR           float64   `json:"amount_eur"`
-	Account             string    `json:"account"`
-	Category            string    `json:"category"`
-	Merchant            string    `json:"merchant"`
-	Note                *string   `json:"note,omitempty"`
-	OriginalDescription *string   `json:"original_description,omitempty"`
-	TransactedAt        time.Time `json:"transacted_at"`
+	ID                  int64         `json:"id"`
+	Type                string        `json:"type"`
+	AmountOriginal      float64       `json:"amount_original"`
+	Currency            string        `json:"currency"`
+	AmountEUR           float64       `json:"amount_eur"`
+	Account             string        `json:"account"`
+	Category            string        `json:"category"`
+	Merchant            string        `json:"merchant"`
+	Note                *string       `json:"note,omitempty"`
+	OriginalDescription *string       `json:"original_description,omitempty"`
+	ExternalID          *string       `json:"external_id,omitempty"`
+	Direction           string        `json:"direction"`
+	BalanceAfter        *float64      `json:"balance_after,omitempty"`
+	TransferPeerID      *int64        `json:"transfer_peer_id,omitempty"`
+	TransactedAt        time.Time     `json:"transacted_at"`
+	ValueDate           *time.Time    `json:"value_date,omitempty"`
+	Splits              []SplitOutput `json:"splits,omitempty"`
+	Tags                []string      `json:"tags,omitempty"`
+}
+
+// SplitOutput is one line item of a split transaction.
+type SplitOutput struct {
+	ID        int64   `json:"id"`
+	Category  string  `json:"category"`
+	Amount    float64 `json:"amount"`
+	AmountEUR float64 `json:"amount_eur"`
+	Note      *string `json:"note,omitempty"`
+}
+
+// SplitsToOutput converts split lines for JSON serialization.
+func SplitsToOutput(splits []domain.TransactionSplit) []SplitOutput {
+	out := make([]SplitOutput, len(splits))
+	for i, sp := range splits {
+		out[i] = SplitOutput{ID: sp.ID, Category: sp.Category, Amount: sp.Amount, AmountEUR: sp.AmountEUR, Note: sp.Note}
+	}
+	return out
 }
 
 // AddTransactionsInput is the MCP tool input.
@@ -96,6 +122,7 @@ func AddTransactions(ctx context.Context, _ *mcp.CallToolRequest, input AddTrans
 			Merchant:            t.Merchant,
 			Note:                t.Note,
 			OriginalDescription: t.OriginalDescription,
+			Direction:           domain.Direction(t.Direction),
 			TransactedAt:        t.TransactedAt,
 		}
 	}
@@ -134,6 +161,11 @@ func validateTransactionInput(t TransactionInput) error {
 	if t.Account == "" {
 		return fmt.Errorf("account is required")
 	}
+	switch domain.Direction(t.Direction) {
+	case "", domain.DirectionIn, domain.DirectionOut:
+	default:
+		return fmt.Errorf("direction must be one of: in, out")
+	}
 	return nil
 }
 
@@ -149,6 +181,11 @@ func toOutput(tx *domain.Transaction) TransactionOutput {
 		Merchant:            tx.Merchant,
 		Note:                tx.Note,
 		OriginalDescription: tx.OriginalDescription,
+		ExternalID:          tx.ExternalID,
+		Direction:           string(tx.Direction),
+		BalanceAfter:        tx.BalanceAfter,
+		TransferPeerID:      tx.TransferPeerID,
 		TransactedAt:        tx.TransactedAt,
+		ValueDate:           tx.ValueDate,
 	}
 }
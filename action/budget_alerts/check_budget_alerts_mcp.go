@@ -0,0 +1,97 @@
+package budget_alerts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/action/get_budget_progress"
+	"personal/action/notifications"
+	"personal/domain"
+	"personal/gateways"
+	"personal/gateways/notify"
+)
+
+var CheckBudgetAlertsMCPDefinition = mcp.Tool{
+	Name: "check_budget_alerts",
+	Description: "Evaluate active budgets against the alert settings and raise new notifications. Each threshold and the overspend forecast fire at most once per budget period. " +
+		"Runs automatically after every import; returns only alerts raised by this call.",
+}
+
+// CheckBudgetAlertsInput is the MCP tool input.
+type CheckBudgetAlertsInput struct {
+	At *time.Time `json:"at,omitempty" jsonschema:"Evaluate as of this time (default now)"`
+}
+
+// CheckBudgetAlertsOutput is the MCP tool output.
+type CheckBudgetAlertsOutput struct {
+	Notifications []domain.Notification `json:"notifications"`
+}
+
+func CheckBudgetAlerts(ctx context.Context, _ *mcp.CallToolRequest, input CheckBudgetAlertsInput) (*mcp.CallToolResult, CheckBudgetAlertsOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, CheckBudgetAlertsOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, CheckBudgetAlertsOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	at := time.Now().UTC()
+	if input.At != nil {
+		at = *input.At
+	}
+
+	raised, err := Check(ctx, db, userID, at)
+	if err != nil {
+		return nil, CheckBudgetAlertsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	if raised == nil {
+		raised = []domain.Notification{}
+	}
+	return nil, CheckBudgetAlertsOutput{Notifications: raised}, nil
+}
+
+// Check stores alerts for budgets active at at that have not fired yet and
+// delivers them to the webhook when one is configured. Delivery failures are
+// recorded on the notification, not returned: the inbox copy is kept either way.
+func Check(ctx context.Context, db gateways.DB, userID int64, at time.Time) ([]domain.Notification, error) {
+	settings, err := loadSettings(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations, err := get_budget_progress.Evaluate(ctx, db, userID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	var sink gateways.NotificationSink
+	if settings.WebhookURL != nil {
+		sink = notify.Webhook{URL: *settings.WebhookURL}
+	}
+
+	var raised []domain.Notification
+	for _, e := range evaluations {
+		for _, n := range domain.BudgetAlerts(e.Progress, e.Forecast, settings) {
+			n.UserID = userID
+			added, err := db.AddNotification(ctx, &n)
+			if err != nil {
+				return nil, err
+			}
+			if !added {
+				continue
+			}
+			if sink != nil {
+				if err := notifications.Deliver(ctx, db, sink, &n); err != nil {
+					return nil, err
+				}
+			}
+			raised = append(raised, n)
+		}
+	}
+	return raised, nil
+}
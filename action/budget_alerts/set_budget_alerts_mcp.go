@@ -0,0 +1,105 @@
+package budget_alerts
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+var SetBudgetAlertsMCPDefinition = mcp.Tool{
+	Name: "set_budget_alerts",
+	Description: "Configure budget alerts: percent-used thresholds (default 80 and 100) and whether to alert when the forecast projects overspending (default on). " +
+		"Alerts always land in the notification inbox (list_notifications); with webhook_url they are also POSTed there as JSON. Omitted fields keep their current value.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Set budget alerts",
+	},
+}
+
+// SetBudgetAlertsInput is the MCP tool input.
+type SetBudgetAlertsInput struct {
+	Thresholds     []int   `json:"thresholds,omitempty" jsonschema:"Percent of the budget used that triggers an alert, e.g. [50, 80, 100]"`
+	ForecastAlerts *bool   `json:"forecast_alerts,omitempty" jsonschema:"Alert when projected end-of-period spend exceeds the budget"`
+	WebhookURL     *string `json:"webhook_url,omitempty" jsonschema:"http(s) URL that receives each alert as JSON; empty string removes it"`
+}
+
+// BudgetAlertsOutput is the saved configuration.
+type BudgetAlertsOutput struct {
+	Thresholds     []int  `json:"thresholds"`
+	ForecastAlerts bool   `json:"forecast_alerts"`
+	WebhookURL     string `json:"webhook_url,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+func SetBudgetAlerts(ctx context.Context, _ *mcp.CallToolRequest, input SetBudgetAlertsInput) (*mcp.CallToolResult, BudgetAlertsOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, BudgetAlertsOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, BudgetAlertsOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	settings, err := loadSettings(ctx, db, userID)
+	if err != nil {
+		return nil, BudgetAlertsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	if input.Thresholds != nil {
+		seen := map[int]bool{}
+		settings.Thresholds = settings.Thresholds[:0]
+		for _, t := range input.Thresholds {
+			if t <= 0 || t > 1000 {
+				return nil, BudgetAlertsOutput{Error: "thresholds must be between 1 and 1000 percent"}, nil
+			}
+			if !seen[t] {
+				seen[t] = true
+				settings.Thresholds = append(settings.Thresholds, t)
+			}
+		}
+		sort.Ints(settings.Thresholds)
+	}
+	if input.ForecastAlerts != nil {
+		settings.ForecastAlerts = *input.ForecastAlerts
+	}
+	if input.WebhookURL != nil {
+		settings.WebhookURL = nil
+		if *input.WebhookURL != "" {
+			u, err := url.Parse(*input.WebhookURL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, BudgetAlertsOutput{Error: "webhook_url must be an http(s) URL"}, nil
+			}
+			settings.WebhookURL = input.WebhookURL
+		}
+	}
+
+	if err := db.SaveBudgetAlertSettings(ctx, &settings); err != nil {
+		return nil, BudgetAlertsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	return nil, BudgetAlertsOutput{
+		Thresholds:     settings.Thresholds,
+		ForecastAlerts: settings.ForecastAlerts,
+		WebhookURL:     util.Value(settings.WebhookURL),
+	}, nil
+}
+
+// loadSettings returns the saved settings or the defaults.
+func loadSettings(ctx context.Context, db gateways.DB, userID int64) (domain.BudgetAlertSettings, error) {
+	saved, err := db.GetBudgetAlertSettings(ctx, userID)
+	if err != nil {
+		return domain.BudgetAlertSettings{}, err
+	}
+	if saved == nil {
+		return domain.DefaultBudgetAlertSettings(userID), nil
+	}
+	return *saved, nil
+}
@@ -12,7 +12,7 @@ import (
 
 var MCPDefinition = mcp.Tool{
 	Name:        "compare_periods",
-	Description: "Side-by-side comparison of expense spending between two time periods, broken down by top-level category. Shows diff in EUR and percentage change.",
+	Description: "Side-by-side comparison of expense spending between two time periods, broken down by top-level category. Shows diff in EUR and percentage change. Paired transfers between own accounts are excluded.",
 }
 
 // ComparePeriodsInput is the MCP tool input.
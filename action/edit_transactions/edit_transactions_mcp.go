@@ -14,7 +14,7 @@ import (
 
 var MCPDefinition = mcp.Tool{
 	Name:        "edit_transactions",
-	Description: "Batch edit transactions. All fields except id are optional. Useful for re-categorization or correcting details. All IDs must belong to the current user — partial updates are rejected.",
+	Description: "Batch edit transactions. All fields except id are optional. Useful for re-categorization or correcting details. Category changes are remembered and used to categorize future imports of the same merchant. All IDs must belong to the current user — partial updates are rejected.",
 	Annotations: &mcp.ToolAnnotations{
 		DestructiveHint: util.Ptr(true),
 		Title:           "Edit transactions",
@@ -25,6 +25,7 @@ var MCPDefinition = mcp.Tool{
 type TransactionUpdate struct {
 	ID                  int64      `json:"id"`
 	Type                *string    `json:"type,omitempty"`
+	Direction           *string    `json:"direction,omitempty" jsonschema:"Money into or out of the account: in or out. Defaults from type when only type changes"`
 	AmountOriginal      *float64   `json:"amount_original,omitempty"`
 	Currency            *string    `json:"currency,omitempty"`
 	AmountEUR           *float64   `json:"amount_eur,omitempty"`
@@ -71,6 +72,13 @@ func EditTransactions(ctx context.Context, _ *mcp.CallToolRequest, input EditTra
 			t := domain.TransactionType(*u.Type)
 			du.Type = &t
 		}
+		if u.Direction != nil {
+			d := domain.Direction(*u.Direction)
+			if d != domain.DirectionIn && d != domain.DirectionOut {
+				return nil, EditTransactionsOutput{Error: fmt.Sprintf("update[%d]: direction must be one of: in, out", i)}, nil
+			}
+			du.Direction = &d
+		}
 		du.AmountOriginal = u.AmountOriginal
 		du.Currency = u.Currency
 		du.AmountEUR = u.AmountEUR
@@ -0,0 +1,63 @@
+package export_transactions
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// beancountWriter writes a beancount file. Accounts are opened by the
+// auto_accounts plugin, so the file can be written in one pass.
+type beancountWriter struct {
+	w io.Writer
+}
+
+func (bw *beancountWriter) begin() error {
+	_, err := fmt.Fprint(bw.w, `option "title" "Personal finances"
+option "operating_currency" "EUR"
+plugin "beancount.plugins.auto_accounts"
+
+`)
+	return err
+}
+
+func (bw *beancountWriter) write(e entry) error {
+	tx := e.tx
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s *", tx.TransactedAt.UTC().Format("2006-01-02"))
+	if tx.Merchant != "" {
+		narration := ""
+		if tx.Note != nil {
+			narration = *tx.Note
+		}
+		fmt.Fprintf(&b, " %s %s", quote(tx.Merchant), quote(narration))
+	} else {
+		fmt.Fprintf(&b, " %s", quote(description(tx)))
+	}
+	for _, t := range e.tags {
+		b.WriteString(" #" + tagName(t))
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "  id: %s\n", quote(fmt.Sprint(tx.ID)))
+	if tx.Category != "" {
+		fmt.Fprintf(&b, "  category: %s\n", quote(tx.Category))
+	}
+	fmt.Fprintf(&b, "  %-40s  %s\n", e.account, accountAmount(tx))
+	for _, p := range e.postings {
+		fmt.Fprintf(&b, "  %-40s  %s\n", p.account, amount(p.amountEUR, "EUR"))
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(bw.w, b.String())
+	return err
+}
+
+func (bw *beancountWriter) end() error {
+	return nil
+}
+
+// quote makes a beancount string literal on a single line.
+func quote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(oneLine(s))
+	return `"` + s + `"`
+}
@@ -0,0 +1,79 @@
+package export_transactions
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var csvHeader = []string{
+	"id", "date", "type", "direction", "account", "amount", "currency", "amount_eur",
+	"category", "merchant", "note", "original_description", "tags", "splits",
+	"external_id", "transfer_peer_id",
+}
+
+// csvWriter writes one row per transaction. Amounts are signed: negative when
+// money leaves the account. Split lines go into one column as
+// "category=amount" pairs in the original currency.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) begin() error {
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvWriter) write(e entry) error {
+	tx := e.tx
+	sign := 1.0
+	if tx.SignedAmount() < 0 {
+		sign = -1
+	}
+
+	splits := make([]string, len(e.splits))
+	for i, sp := range e.splits {
+		splits[i] = fmt.Sprintf("%s=%.2f", sp.Category, sp.Amount)
+	}
+	var peer string
+	if tx.TransferPeerID != nil {
+		peer = strconv.FormatInt(*tx.TransferPeerID, 10)
+	}
+
+	return c.w.Write([]string{
+		strconv.FormatInt(tx.ID, 10),
+		tx.TransactedAt.UTC().Format(time.RFC3339),
+		string(tx.Type),
+		string(tx.Direction),
+		tx.Account,
+		fmt.Sprintf("%.2f", tx.SignedAmount()),
+		tx.Currency,
+		fmt.Sprintf("%.2f", sign*tx.AmountEUR),
+		tx.Category,
+		tx.Merchant,
+		deref(tx.Note),
+		deref(tx.OriginalDescription),
+		strings.Join(e.tags, ";"),
+		strings.Join(splits, "; "),
+		deref(tx.ExternalID),
+		peer,
+	})
+}
+
+func (c *csvWriter) end() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,248 @@
+package export_transactions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+// Export formats.
+const (
+	FormatCSV       = "csv"
+	FormatLedger    = "ledger"
+	FormatBeancount = "beancount"
+)
+
+// batchSize is how many transactions are loaded, with their splits and tags,
+// before being written out.
+const batchSize = 500
+
+// transferClearing is the counter account of every transfer leg. Both legs of
+// a transfer between own accounts post to it, so it nets to zero once both
+// sides are exported.
+const transferClearing = "Assets:Transfers"
+
+// entry is one transaction ready to be written: the posting on its own
+// account in the original currency and the counter postings in EUR, which
+// sum to the opposite of the EUR amount.
+type entry struct {
+	tx       *domain.Transaction
+	account  string
+	postings []posting
+	tags     []string
+	splits   []domain.TransactionSplit
+}
+
+type posting struct {
+	account   string
+	amountEUR float64
+}
+
+// writer renders entries in one format.
+type writer interface {
+	begin() error
+	write(e entry) error
+	end() error
+}
+
+// IsValidFormat reports whether format is a known export format.
+func IsValidFormat(format string) bool {
+	switch format {
+	case FormatCSV, FormatLedger, FormatBeancount:
+		return true
+	}
+	return false
+}
+
+// Export writes every transaction matching filter to w, oldest first, and
+// returns how many were written. filter.Limit and filter.Offset are ignored.
+func Export(ctx context.Context, db gateways.DB, filter domain.TransactionFilter, format string, w io.Writer) (int, error) {
+	var out writer
+	switch format {
+	case FormatCSV:
+		out = newCSVWriter(w)
+	case FormatLedger:
+		out = &ledgerWriter{w: w}
+	case FormatBeancount:
+		out = &beancountWriter{w: w}
+	default:
+		return 0, fmt.Errorf("unknown export format %q", format)
+	}
+
+	accounts, err := db.GetAccountBalances(ctx, filter.UserID, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	accountTypes := make(map[string]domain.AccountType, len(accounts))
+	for _, a := range accounts {
+		accountTypes[strings.ToLower(a.Name)] = a.Type
+	}
+
+	if err = out.begin(); err != nil {
+		return 0, err
+	}
+	count := 0
+	err = db.StreamTransactions(ctx, filter, batchSize, func(txs []*domain.Transaction) error {
+		ids := make([]int64, len(txs))
+		for i, tx := range txs {
+			ids[i] = tx.ID
+		}
+		splits, err := db.ListTransactionSplits(ctx, filter.UserID, ids)
+		if err != nil {
+			return err
+		}
+		splitsByTx := make(map[int64][]domain.TransactionSplit)
+		for _, sp := range splits {
+			splitsByTx[sp.TransactionID] = append(splitsByTx[sp.TransactionID], sp)
+		}
+		tags, err := db.ListTransactionTags(ctx, filter.UserID, ids)
+		if err != nil {
+			return err
+		}
+		tagsByTx := make(map[int64][]string)
+		for _, t := range tags {
+			tagsByTx[t.TransactionID] = append(tagsByTx[t.TransactionID], t.Name)
+		}
+
+		for _, tx := range txs {
+			e := newEntry(tx, accountTypes[strings.ToLower(tx.Account)], splitsByTx[tx.ID])
+			e.tags = tagsByTx[tx.ID]
+			if err := out.write(e); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, out.end()
+}
+
+func newEntry(tx *domain.Transaction, accountType domain.AccountType, splits []domain.TransactionSplit) entry {
+	e := entry{tx: tx, account: accountPath(tx.Account, accountType), splits: splits}
+
+	// Money leaving the account lands on the counter account and vice versa.
+	counterEUR := tx.AmountEUR
+	if tx.Direction == domain.DirectionIn {
+		counterEUR = -tx.AmountEUR
+	}
+
+	// Paired transfers between own accounts keep their expense/income type
+	// but are not P&L either.
+	if tx.Type == domain.TransactionTypeTransfer || tx.TransferPeerID != nil {
+		e.postings = []posting{{account: transferClearing, amountEUR: counterEUR}}
+		return e
+	}
+
+	root := "Expenses"
+	if tx.Type == domain.TransactionTypeIncome {
+		root = "Income"
+	}
+
+	if len(splits) == 0 {
+		e.postings = []posting{{account: categoryPath(root, tx.Category), amountEUR: counterEUR}}
+		return e
+	}
+	// Split lines are converted at the parent rate; the last line takes the
+	// rounding difference so the entry balances to the cent.
+	sign := math.Copysign(1, counterEUR)
+	rest := counterEUR
+	for i, sp := range splits {
+		amount := roundCents(sign * sp.AmountEUR)
+		if i == len(splits)-1 {
+			amount = roundCents(rest)
+		}
+		rest -= amount
+		e.postings = append(e.postings, posting{account: categoryPath(root, sp.Category), amountEUR: amount})
+	}
+	return e
+}
+
+// accountPath maps an account to Assets, or Liabilities for credit accounts.
+func accountPath(name string, t domain.AccountType) string {
+	if t == domain.AccountTypeCredit {
+		return "Liabilities:" + accountComponent(name)
+	}
+	return "Assets:" + accountComponent(name)
+}
+
+// categoryPath turns a slash category into an account under root, e.g.
+// food/cafe under Expenses becomes Expenses:Food:Cafe.
+func categoryPath(root, category string) string {
+	path := root
+	for _, segment := range strings.Split(category, "/") {
+		if c := accountComponent(segment); c != "" {
+			path += ":" + c
+		}
+	}
+	if path == root {
+		path += ":Uncategorized"
+	}
+	return path
+}
+
+// accountComponent makes a name safe for both ledger and beancount account
+// names: words capitalized and joined with dashes, e.g. "personal_care"
+// becomes "Personal-Care".
+func accountComponent(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, "-")
+}
+
+// tagName makes a tag usable as a ledger or beancount tag.
+func tagName(tag string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, tag)
+}
+
+// description is the text that best names a transaction.
+func description(tx *domain.Transaction) string {
+	switch {
+	case tx.Merchant != "":
+		return tx.Merchant
+	case tx.Note != nil && *tx.Note != "":
+		return *tx.Note
+	case tx.OriginalDescription != nil && *tx.OriginalDescription != "":
+		return *tx.OriginalDescription
+	}
+	return string(tx.Type)
+}
+
+// amount formats a value with two decimals and its commodity.
+func amount(v float64, currency string) string {
+	return fmt.Sprintf("%.2f %s", v, currency)
+}
+
+// accountAmount is the posting amount on the transaction's own account, with
+// the EUR value as a total price for other currencies.
+func accountAmount(tx *domain.Transaction) string {
+	a := amount(tx.SignedAmount(), tx.Currency)
+	if tx.Currency != "EUR" {
+		a += " @@ " + amount(tx.AmountEUR, "EUR")
+	}
+	return a
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
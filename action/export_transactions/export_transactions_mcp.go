@@ -0,0 +1,111 @@
+package export_transactions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+var MCPDefinition = mcp.Tool{
+	Name: "export_transactions",
+	Description: "Export every transaction matching the get_transactions filters, oldest first and without the 200-row limit, " +
+		"as CSV, a ledger/hledger journal or a beancount file. Accounts become Assets:<Account> (Liabilities for credit accounts), " +
+		"categories become Expenses:/Income: account paths (food/cafe -> Expenses:Food:Cafe), split lines become separate postings, " +
+		"transfers post to Assets:Transfers, and foreign-currency amounts keep their currency with the EUR value as price. " +
+		"The same export is downloadable from /web/money/export.",
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+		Title:        "Export transactions",
+	},
+}
+
+// ExportTransactionsInput is the MCP tool input.
+type ExportTransactionsInput struct {
+	Format   string     `json:"format,omitempty" jsonschema:"csv, ledger or beancount (default csv)"`
+	From     *time.Time `json:"from,omitempty"`
+	To       *time.Time `json:"to,omitempty"`
+	Account  *string    `json:"account,omitempty"`
+	Category *string    `json:"category,omitempty" jsonschema:"Category prefix, also matches split lines"`
+	Type     *string    `json:"type,omitempty" jsonschema:"expense, income or transfer"`
+	Merchant *string    `json:"merchant,omitempty"`
+	Tags     []string   `json:"tags,omitempty" jsonschema:"Only transactions carrying every listed tag"`
+	Query    *string    `json:"query,omitempty" jsonschema:"Words to find in merchant, note or original description"`
+	MinEUR   *float64   `json:"min_amount_eur,omitempty" jsonschema:"Minimum amount_eur, inclusive"`
+	MaxEUR   *float64   `json:"max_amount_eur,omitempty" jsonschema:"Maximum amount_eur, inclusive"`
+}
+
+// ExportTransactionsOutput is the MCP tool output.
+type ExportTransactionsOutput struct {
+	Format  string `json:"format"`
+	Count   int    `json:"count"`
+	Content string `json:"content"`
+	Error   string `json:"error,omitempty"`
+}
+
+func ExportTransactions(ctx context.Context, _ *mcp.CallToolRequest, input ExportTransactionsInput) (*mcp.CallToolResult, ExportTransactionsOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, ExportTransactionsOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, ExportTransactionsOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	format, filter, errMsg := input.filter(userID)
+	if errMsg != "" {
+		return nil, ExportTransactionsOutput{Error: errMsg}, nil
+	}
+
+	var content strings.Builder
+	count, err := Export(ctx, db, filter, format, &content)
+	if err != nil {
+		return nil, ExportTransactionsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	return nil, ExportTransactionsOutput{Format: format, Count: count, Content: content.String()}, nil
+}
+
+// filter validates the input and converts it to a repository filter.
+func (input ExportTransactionsInput) filter(userID int64) (string, domain.TransactionFilter, string) {
+	format := strings.ToLower(strings.TrimSpace(input.Format))
+	if format == "" {
+		format = FormatCSV
+	}
+	if !IsValidFormat(format) {
+		return "", domain.TransactionFilter{}, "format must be one of: csv, ledger, beancount"
+	}
+	if input.MinEUR != nil && input.MaxEUR != nil && *input.MinEUR > *input.MaxEUR {
+		return "", domain.TransactionFilter{}, "min_amount_eur must not exceed max_amount_eur"
+	}
+	if input.Query != nil && !domain.HasSearchWords(*input.Query) {
+		return "", domain.TransactionFilter{}, "query must contain at least one letter or digit"
+	}
+
+	filter := domain.TransactionFilter{
+		UserID:   userID,
+		From:     input.From,
+		To:       input.To,
+		Account:  input.Account,
+		Category: input.Category,
+		Merchant: input.Merchant,
+		Query:    input.Query,
+		MinEUR:   input.MinEUR,
+		MaxEUR:   input.MaxEUR,
+	}
+	if input.Type != nil {
+		t := domain.TransactionType(*input.Type)
+		filter.Type = &t
+	}
+	for _, tag := range input.Tags {
+		if tag = domain.NormalizeTag(tag); tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+	return format, filter, ""
+}
@@ -0,0 +1,112 @@
+package export_transactions
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"personal/gateways"
+)
+
+const defaultUserID int64 = 1
+
+var (
+	contentTypes = map[string]string{
+		FormatCSV:       "text/csv; charset=utf-8",
+		FormatLedger:    "text/plain; charset=utf-8",
+		FormatBeancount: "text/plain; charset=utf-8",
+	}
+	fileNames = map[string]string{
+		FormatCSV:       "transactions.csv",
+		FormatLedger:    "transactions.journal",
+		FormatBeancount: "transactions.beancount",
+	}
+)
+
+// ExportGETHandler streams the export as a file download. Query parameters
+// mirror the MCP tool input; from and to take YYYY-MM-DD (to is inclusive)
+// or RFC 3339, tags repeat as tag=a&tag=b.
+func ExportGETHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		c.String(http.StatusInternalServerError, "Database not available")
+		return
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		userID = defaultUserID
+	}
+
+	input := ExportTransactionsInput{Format: c.Query("format"), Tags: c.QueryArray("tag")}
+	var err error
+	if input.From, err = queryTime(c, "from", false); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if input.To, err = queryTime(c, "to", true); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	for name, dst := range map[string]**string{
+		"account": &input.Account, "category": &input.Category, "type": &input.Type,
+		"merchant": &input.Merchant, "query": &input.Query,
+	} {
+		if v := strings.TrimSpace(c.Query(name)); v != "" {
+			*dst = &v
+		}
+	}
+	for name, dst := range map[string]**float64{"min_amount_eur": &input.MinEUR, "max_amount_eur": &input.MaxEUR} {
+		if v := c.Query(name); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				c.String(http.StatusBadRequest, "%s must be a number", name)
+				return
+			}
+			*dst = &f
+		}
+	}
+
+	format, filter, errMsg := input.filter(userID)
+	if errMsg != "" {
+		c.String(http.StatusBadRequest, errMsg)
+		return
+	}
+
+	c.Header("Content-Type", contentTypes[format])
+	c.Header("Content-Disposition", `attachment; filename="`+fileNames[format]+`"`)
+	c.Status(http.StatusOK)
+	if _, err := Export(ctx, db, filter, format, c.Writer); err != nil {
+		// Rows already sent cannot be taken back; the client gets a
+		// truncated file and the error goes to the log.
+		log.Printf("export transactions: %v", err)
+		if !c.Writer.Written() {
+			c.String(http.StatusInternalServerError, "Export failed: %v", err)
+		}
+	}
+}
+
+// queryTime parses a date or RFC 3339 query parameter. A bare date used as an
+// upper bound covers the whole day.
+func queryTime(c *gin.Context, name string, endOfDay bool) (*time.Time, error) {
+	v := c.Query(name)
+	if v == "" {
+		return nil, nil
+	}
+	if day, err := time.Parse(time.DateOnly, v); err == nil {
+		if endOfDay {
+			day = day.AddDate(0, 0, 1).Add(-time.Microsecond)
+		}
+		return &day, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be YYYY-MM-DD or RFC 3339", name)
+	}
+	return &t, nil
+}
@@ -0,0 +1,51 @@
+package export_transactions
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ledgerWriter writes a journal readable by both ledger and hledger.
+type ledgerWriter struct {
+	w io.Writer
+}
+
+func (l *ledgerWriter) begin() error {
+	_, err := fmt.Fprint(l.w, "; Transactions exported from personal\n\n")
+	return err
+}
+
+func (l *ledgerWriter) write(e entry) error {
+	tx := e.tx
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s * %s\n", tx.TransactedAt.UTC().Format("2006-01-02"), oneLine(description(tx)))
+	fmt.Fprintf(&b, "    ; id: %d\n", tx.ID)
+	if len(e.tags) > 0 {
+		tags := make([]string, len(e.tags))
+		for i, t := range e.tags {
+			tags[i] = tagName(t)
+		}
+		fmt.Fprintf(&b, "    ; :%s:\n", strings.Join(tags, ":"))
+	}
+	if tx.Note != nil && *tx.Note != "" && *tx.Note != description(tx) {
+		fmt.Fprintf(&b, "    ; %s\n", oneLine(*tx.Note))
+	}
+	fmt.Fprintf(&b, "    %-40s  %s\n", e.account, accountAmount(tx))
+	for _, p := range e.postings {
+		fmt.Fprintf(&b, "    %-40s  %s\n", p.account, amount(p.amountEUR, "EUR"))
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(l.w, b.String())
+	return err
+}
+
+func (l *ledgerWriter) end() error {
+	return nil
+}
+
+// oneLine collapses whitespace so free text cannot break the journal layout.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,352 @@
+package find_correlations
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+const (
+	defaultDays     = 90
+	maxDays         = 730
+	defaultMaxLag   = 2
+	maxLag          = 7
+	defaultMinDays  = 10
+	maxCorrelations = 25
+	// Below these a correlation is reported with a caveat.
+	fewDays        = 30
+	fewNonZeroDays = 5
+	significance   = 0.05
+)
+
+const (
+	MetricCalories           = "calories"
+	MetricCaffeine           = "caffeine"
+	MetricAlcohol            = "alcohol"
+	MetricTrainingVolume     = "training_volume"
+	MetricDiscretionarySpend = "discretionary_spend"
+)
+
+var allMetrics = []string{MetricCalories, MetricCaffeine, MetricAlcohol, MetricTrainingVolume, MetricDiscretionarySpend}
+
+var defaultDiscretionaryCategories = []string{"food", "shopping", "entertainment"}
+
+var MCPDefinition = mcp.Tool{
+	Name: "find_correlations",
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+		Title:          "Find correlations",
+	},
+	Description: `Look for relations between mood/habit check-ins, food, workouts and spending by lining up daily series
+on the same day axis and computing lagged Pearson correlations.
+
+Use this tool when:
+- User asks what affects an activity ("does alcohol the day before lower my mood?", "do I sleep better after training?")
+- User wants patterns across food, workouts, money and check-ins
+
+Series (one value per local day):
+- activity:<id>: average check-in value of the activity that day; days without check-ins are left out
+- calories, caffeine (mg), alcohol (g): from the food log; days without any food logged are left out
+- training_volume: sum of reps x weight; days without sets count as 0
+- discretionary_spend: EUR expenses under discretionary_categories, transfers excluded; days without count as 0
+
+Metrics and activities are drivers, activities are outcomes. lag_days = k pairs the driver on day d-k
+with the outcome on day d, so alcohol -> mood at lag 1 is "alcohol yesterday vs mood today".
+
+Parameters (all optional):
+- activity_ids: outcome activities (default: all active activities)
+- metrics: any of calories, caffeine, alcohol, training_volume, discretionary_spend (default: all)
+- days: window ending today (default 90, max 730)
+- max_lag: lags 0..max_lag are tested (default 2, max 7)
+- min_days: minimum paired days to report a pair (default 10)
+- timezone: IANA timezone for day boundaries (default UTC)
+- discretionary_categories: category prefixes counted as discretionary (default food, shopping, entertainment)
+
+Returns the strongest pairs first (up to 25) with r, n, p_value, strength and caveats.
+Correlation is not causation; present findings as hypotheses to watch, not conclusions.`,
+}
+
+// FindCorrelationsInput is the MCP tool input.
+type FindCorrelationsInput struct {
+	ActivityIDs             []int64  `json:"activity_ids,omitempty" jsonschema:"Outcome activity IDs (default all active)"`
+	Metrics                 []string `json:"metrics,omitempty" jsonschema:"calories, caffeine, alcohol, training_volume, discretionary_spend (default all)"`
+	Days                    int      `json:"days,omitempty" jsonschema:"Days to look back, including today (default 90, max 730)"`
+	MaxLag                  *int     `json:"max_lag,omitempty" jsonschema:"Largest lag in days (default 2, max 7)"`
+	MinDays                 int      `json:"min_days,omitempty" jsonschema:"Minimum paired days to report a pair (default 10)"`
+	Timezone                string   `json:"timezone,omitempty" jsonschema:"IANA timezone for day boundaries (default UTC)"`
+	DiscretionaryCategories []string `json:"discretionary_categories,omitempty" jsonschema:"Category prefixes counted as discretionary spend"`
+}
+
+// SeriesItem describes one daily series.
+type SeriesItem struct {
+	Key          string  `json:"key" jsonschema:"Series key used in correlations"`
+	Label        string  `json:"label" jsonschema:"Human readable name"`
+	DaysObserved int     `json:"days_observed" jsonschema:"Days with a value"`
+	NonZeroDays  int     `json:"non_zero_days" jsonschema:"Days with a non-zero value"`
+	Mean         float64 `json:"mean" jsonschema:"Mean over observed days"`
+}
+
+// CorrelationItem is one driver/outcome/lag result.
+type CorrelationItem struct {
+	Driver       string   `json:"driver" jsonschema:"Driver series key"`
+	DriverLabel  string   `json:"driver_label" jsonschema:"Driver name"`
+	Outcome      string   `json:"outcome" jsonschema:"Outcome series key"`
+	OutcomeLabel string   `json:"outcome_label" jsonschema:"Outcome name"`
+	LagDays      int      `json:"lag_days" jsonschema:"Driver on day d-lag_days vs outcome on day d"`
+	R            float64  `json:"r" jsonschema:"Pearson correlation, -1..1"`
+	N            int      `json:"n" jsonschema:"Paired days"`
+	PValue       float64  `json:"p_value" jsonschema:"Two-sided p-value of r != 0"`
+	Strength     string   `json:"strength" jsonschema:"negligible, weak, moderate or strong"`
+	Direction    string   `json:"direction" jsonschema:"positive or negative"`
+	Caveats      []string `json:"caveats,omitempty" jsonschema:"Reasons to be careful with this pair"`
+}
+
+// FindCorrelationsOutput is the MCP tool output.
+type FindCorrelationsOutput struct {
+	From         string            `json:"from" jsonschema:"First day (YYYY-MM-DD)"`
+	To           string            `json:"to" jsonschema:"Last day (YYYY-MM-DD)"`
+	Timezone     string            `json:"timezone" jsonschema:"Timezone of the day boundaries"`
+	Series       []SeriesItem      `json:"series" jsonschema:"Series that were aligned"`
+	TestsRun     int               `json:"tests_run" jsonschema:"Pairs with enough paired days"`
+	Correlations []CorrelationItem `json:"correlations" jsonschema:"Strongest pairs first"`
+	Caveats      []string          `json:"caveats" jsonschema:"General caveats"`
+}
+
+func FindCorrelations(ctx context.Context, _ *mcp.CallToolRequest, input FindCorrelationsInput) (*mcp.CallToolResult, FindCorrelationsOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, FindCorrelationsOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, FindCorrelationsOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	days := input.Days
+	if days == 0 {
+		days = defaultDays
+	}
+	if days < 1 || days > maxDays {
+		return nil, FindCorrelationsOutput{}, fmt.Errorf("days must be between 1 and %d", maxDays)
+	}
+	lags := defaultMaxLag
+	if input.MaxLag != nil {
+		lags = *input.MaxLag
+	}
+	if lags < 0 || lags > maxLag {
+		return nil, FindCorrelationsOutput{}, fmt.Errorf("max_lag must be between 0 and %d", maxLag)
+	}
+	minDays := input.MinDays
+	if minDays == 0 {
+		minDays = defaultMinDays
+	}
+	if minDays < 3 {
+		return nil, FindCorrelationsOutput{}, fmt.Errorf("min_days must be at least 3")
+	}
+	tz := input.Timezone
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, FindCorrelationsOutput{}, fmt.Errorf("invalid timezone %q", tz)
+	}
+	metrics := input.Metrics
+	if len(metrics) == 0 {
+		metrics = allMetrics
+	}
+	wanted := make(map[string]bool, len(metrics))
+	for _, m := range metrics {
+		if !slices.Contains(allMetrics, m) {
+			return nil, FindCorrelationsOutput{}, fmt.Errorf("unknown metric %q, expected one of %s", m, strings.Join(allMetrics, ", "))
+		}
+		wanted[m] = true
+	}
+	categories := defaultDiscretionaryCategories
+	if len(input.DiscretionaryCategories) > 0 {
+		categories = nil
+		for _, c := range input.DiscretionaryCategories {
+			if c = strings.Trim(strings.TrimSpace(c), "/"); c != "" {
+				categories = append(categories, c)
+			}
+		}
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	from, to := today.AddDate(0, 0, -(days-1)), now
+
+	activities, err := outcomeActivities(ctx, db, userID, input.ActivityIDs)
+	if err != nil {
+		return nil, FindCorrelationsOutput{}, err
+	}
+	points, err := db.ListProgress(ctx, domain.ProgressFilter{UserID: userID, From: from, To: to})
+	if err != nil {
+		return nil, FindCorrelationsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	byActivity := make(map[int64][]domain.ActivityPoint)
+	for _, p := range points {
+		byActivity[p.ActivityID] = append(byActivity[p.ActivityID], p)
+	}
+	var outcomes []domain.DailySeries
+	for _, a := range activities {
+		key := "activity:" + strconv.FormatInt(a.ID, 10)
+		outcomes = append(outcomes, domain.ActivityDailySeries(key, a.Name, byActivity[a.ID], loc))
+	}
+
+	var metricSeries []domain.DailySeries
+	if wanted[MetricCalories] || wanted[MetricCaffeine] || wanted[MetricAlcohol] {
+		logs, err := db.ListConsumptionLogs(ctx, userID, from, to)
+		if err != nil {
+			return nil, FindCorrelationsOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		calories, caffeine, alcohol := domain.NutritionDailySeries(logs, loc)
+		for _, s := range []domain.DailySeries{calories, caffeine, alcohol} {
+			if wanted[s.Key] {
+				metricSeries = append(metricSeries, s)
+			}
+		}
+	}
+	if wanted[MetricTrainingVolume] {
+		sets, err := db.ListSets(ctx, userID, from, to)
+		if err != nil {
+			return nil, FindCorrelationsOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		metricSeries = append(metricSeries, domain.TrainingVolumeSeries(sets, from, to, loc))
+	}
+	if wanted[MetricDiscretionarySpend] {
+		var txs []*domain.Transaction
+		expense := domain.TransactionTypeExpense
+		filter := domain.TransactionFilter{UserID: userID, From: &from, To: &to, Type: &expense}
+		err := db.StreamTransactions(ctx, filter, 0, func(batch []*domain.Transaction) error {
+			txs = append(txs, batch...)
+			return nil
+		})
+		if err != nil {
+			return nil, FindCorrelationsOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		ids := make([]int64, len(txs))
+		for i, tx := range txs {
+			ids[i] = tx.ID
+		}
+		lines, err := db.ListTransactionSplits(ctx, userID, ids)
+		if err != nil {
+			return nil, FindCorrelationsOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		splits := make(map[int64][]domain.TransactionSplit)
+		for _, l := range lines {
+			splits[l.TransactionID] = append(splits[l.TransactionID], l)
+		}
+		metricSeries = append(metricSeries, domain.SpendSeries(txs, splits, categories, from, to, loc))
+	}
+
+	drivers := append(metricSeries, outcomes...)
+	results := domain.FindCorrelations(drivers, outcomes, lags, minDays)
+
+	output := FindCorrelationsOutput{
+		From:         from.Format(time.DateOnly),
+		To:           today.Format(time.DateOnly),
+		Timezone:     loc.String(),
+		Series:       make([]SeriesItem, 0, len(drivers)),
+		TestsRun:     len(results),
+		Correlations: []CorrelationItem{},
+		Caveats: []string{
+			"Correlation is not causation: a third factor (stress, illness, travel) can drive both series.",
+		},
+	}
+	labels := make(map[string]string, len(drivers))
+	for _, s := range drivers {
+		labels[s.Key] = s.Label
+		output.Series = append(output.Series, seriesToItem(s))
+	}
+	if len(results) > 1 {
+		output.Caveats = append(output.Caveats, fmt.Sprintf(
+			"%d pairs were tested; about %d of them would look significant at p < %.2f by chance alone.",
+			len(results), int(math.Round(float64(len(results))*significance)), significance))
+	}
+	if len(results) == 0 {
+		output.Caveats = append(output.Caveats, fmt.Sprintf(
+			"No pair had %d or more paired days with variation on both sides; log more days or lower min_days.", minDays))
+	}
+	if len(results) > maxCorrelations {
+		results = results[:maxCorrelations]
+	}
+	for _, c := range results {
+		output.Correlations = append(output.Correlations, correlationToItem(c, labels))
+	}
+
+	return nil, output, nil
+}
+
+// outcomeActivities returns the requested activities, or all active ones.
+func outcomeActivities(ctx context.Context, db gateways.DB, userID int64, ids []int64) ([]domain.Activity, error) {
+	if len(ids) == 0 {
+		activities, err := db.ListActivities(ctx, domain.ActivityFilter{UserID: userID, ActiveOnly: true})
+		if err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+		return activities, nil
+	}
+	activities := make([]domain.Activity, 0, len(ids))
+	for _, id := range ids {
+		activity, err := db.GetActivity(ctx, id, userID)
+		if err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+		if activity == nil {
+			return nil, fmt.Errorf("activity %d not found", id)
+		}
+		activities = append(activities, *activity)
+	}
+	return activities, nil
+}
+
+func seriesToItem(s domain.DailySeries) SeriesItem {
+	item := SeriesItem{Key: s.Key, Label: s.Label, DaysObserved: len(s.Values), NonZeroDays: s.NonZeroDays()}
+	if len(s.Values) > 0 {
+		var sum float64
+		for _, v := range s.Values {
+			sum += v
+		}
+		item.Mean = math.Round(sum/float64(len(s.Values))*100) / 100
+	}
+	return item
+}
+
+func correlationToItem(c domain.Correlation, labels map[string]string) CorrelationItem {
+	item := CorrelationItem{
+		Driver:       c.Driver,
+		DriverLabel:  labels[c.Driver],
+		Outcome:      c.Outcome,
+		OutcomeLabel: labels[c.Outcome],
+		LagDays:      c.Lag,
+		R:            c.R,
+		N:            c.N,
+		PValue:       c.PValue,
+		Strength:     domain.CorrelationStrength(c.R),
+		Direction:    "positive",
+	}
+	if c.R < 0 {
+		item.Direction = "negative"
+	}
+	if c.N < fewDays {
+		item.Caveats = append(item.Caveats, fmt.Sprintf("only %d paired days", c.N))
+	}
+	if c.NonZero < fewNonZeroDays {
+		item.Caveats = append(item.Caveats, fmt.Sprintf("driver is non-zero on only %d of the paired days", c.NonZero))
+	}
+	if c.PValue >= significance {
+		item.Caveats = append(item.Caveats, "not statistically significant")
+	}
+	return item
+}
@@ -12,7 +12,7 @@ import (
 
 var MCPDefinition = mcp.Tool{
 	Name:        "get_balance",
-	Description: "Income minus expenses for a period in EUR. Transfer transactions are excluded from the calculation.",
+	Description: "Income minus expenses for a period in EUR. Transfer transactions and paired transfers between own accounts are excluded from the calculation.",
 }
 
 // GetBalanceInput is the MCP tool input.
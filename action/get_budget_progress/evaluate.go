@@ -0,0 +1,116 @@
+package get_budget_progress
+
+import (
+	"context"
+	"time"
+
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+// seasonalPeriods is how many past periods shape the expected spending curve.
+const seasonalPeriods = 6
+
+// Evaluation is one budget active at a point in time, with rollover applied
+// and its forecast.
+type Evaluation struct {
+	Progress   domain.BudgetProgress
+	Definition *domain.RecurringBudget // nil for one-off budgets
+	Past       []domain.BudgetProgress // earlier periods of a recurring budget, oldest first
+	Forecast   domain.BudgetForecast
+}
+
+// Evaluate materializes recurring budget periods up to at and evaluates
+// every budget active at at.
+func Evaluate(ctx context.Context, db gateways.DB, userID int64, at time.Time) ([]Evaluation, error) {
+	definitions, err := Materialize(ctx, db, userID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.GetBudgetProgress(ctx, userID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations := make([]Evaluation, len(rows))
+	for i, r := range rows {
+		e := Evaluation{Progress: r}
+		if rb, ok := definitions[util.Value(r.RecurringBudgetID)]; ok {
+			e.Definition = &rb
+			if err := replayRollover(ctx, db, &e); err != nil {
+				return nil, err
+			}
+		}
+
+		shares, err := pastShares(ctx, db, e, at)
+		if err != nil {
+			return nil, err
+		}
+		e.Forecast = domain.ForecastBudget(e.Progress, at, shares)
+		evaluations[i] = e
+	}
+	return evaluations, nil
+}
+
+// Materialize stores every period of the user's recurring budgets that has
+// started by at and returns the definitions by ID.
+func Materialize(ctx context.Context, db gateways.DB, userID int64, at time.Time) (map[int64]domain.RecurringBudget, error) {
+	definitions, err := db.ListRecurringBudgets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]domain.RecurringBudget, len(definitions))
+	for _, rb := range definitions {
+		byID[rb.ID] = rb
+		if err := db.AddBudgetPeriods(ctx, rb.PeriodsUntil(at)); err != nil {
+			return nil, err
+		}
+	}
+	return byID, nil
+}
+
+// replayRollover replays rollover over all periods up to the current one,
+// since any past edit to a transaction changes what carries forward.
+func replayRollover(ctx context.Context, db gateways.DB, e *Evaluation) error {
+	periods, err := db.ListBudgetPeriods(ctx, e.Progress.UserID, e.Definition.ID, e.Progress.StartsAt)
+	if err != nil {
+		return err
+	}
+	domain.ApplyRollover(periods, e.Definition.Rollover)
+	if len(periods) == 0 {
+		return nil
+	}
+	current := periods[len(periods)-1]
+	e.Progress.CarriedOverEUR = current.CarriedOverEUR
+	e.Progress.RemainingEUR = current.RemainingEUR
+	e.Past = periods[:len(periods)-1]
+	return nil
+}
+
+// pastShares returns, for recent past periods with spending, the share of
+// their total spent by the same point of the period as at.
+func pastShares(ctx context.Context, db gateways.DB, e Evaluation, at time.Time) ([]float64, error) {
+	p := e.Progress
+	length := p.EndsAt.Sub(p.StartsAt)
+	if length <= 0 {
+		return nil, nil
+	}
+	fraction := float64(at.Sub(p.StartsAt)) / float64(length)
+
+	var shares []float64
+	for i := len(e.Past) - 1; i >= 0 && len(shares) < seasonalPeriods; i-- {
+		past := e.Past[i]
+		if past.SpentEUR <= 0 {
+			continue
+		}
+		cutoff := past.StartsAt.Add(time.Duration(fraction * float64(past.EndsAt.Sub(past.StartsAt))))
+		spent, err := db.GetCategorySpending(ctx, past.UserID, past.Category, past.StartsAt, cutoff)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, spent/past.SpentEUR)
+	}
+	return shares, nil
+}
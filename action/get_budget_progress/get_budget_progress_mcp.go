@@ -3,6 +3,7 @@ package get_budget_progress
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -10,31 +11,65 @@ import (
 	"personal/gateways"
 )
 
+const defaultHistory = 6
+
 var MCPDefinition = mcp.Tool{
-	Name:        "get_budget_progress",
-	Description: "Active budgets with spent and remaining EUR amounts as of a given date. Only expense transactions matching the budget category prefix are counted.",
+	Name: "get_budget_progress",
+	Description: "Active budgets with spent and remaining EUR amounts as of a given date. Only expense transactions matching the budget category prefix are counted. " +
+		"Recurring budgets show the current period with the amount carried over from the previous one (rollover) and a history of past periods, newest first. " +
+		"Each budget has a forecast: daily burn, projected end-of-period spend, whether spending is on pace and days until the budget is exhausted. " +
+		"Recurring budgets use the average spending curve of past periods (seasonal), others assume even spending (linear).",
 }
 
 // GetBudgetProgressInput is the MCP tool input.
 type GetBudgetProgressInput struct {
-	At time.Time `json:"at"`
+	At      time.Time `json:"at"`
+	History int       `json:"history,omitempty" jsonschema:"Past periods to include for recurring budgets (default 6)"`
 }
 
 // BudgetProgressRow is one budget with its spending progress.
 type BudgetProgressRow struct {
-	ID           int64     `json:"id"`
-	Name         string    `json:"name"`
-	Category     string    `json:"category"`
-	AmountEUR    float64   `json:"amount_eur"`
-	SpentEUR     float64   `json:"spent_eur"`
-	RemainingEUR float64   `json:"remaining_eur"`
-	StartsAt     time.Time `json:"starts_at"`
-	EndsAt       time.Time `json:"ends_at"`
+	ID             int64       `json:"id"`
+	Name           string      `json:"name"`
+	Category       string      `json:"category"`
+	AmountEUR      float64     `json:"amount_eur"`
+	SpentEUR       float64     `json:"spent_eur"`
+	RemainingEUR   float64     `json:"remaining_eur"`
+	StartsAt       time.Time   `json:"starts_at"`
+	EndsAt         time.Time   `json:"ends_at"`
+	Period         string      `json:"period,omitempty"`
+	Rollover       bool        `json:"rollover,omitempty"`
+	CarriedOverEUR float64     `json:"carried_over_eur,omitempty"`
+	Forecast       ForecastRow `json:"forecast"`
+	History        []PeriodRow `json:"history,omitempty"`
+}
+
+// ForecastRow tells whether the budget is on pace to last its period.
+type ForecastRow struct {
+	Curve                 string   `json:"curve"`
+	ElapsedPct            float64  `json:"elapsed_pct"`
+	DailyBurnEUR          float64  `json:"daily_burn_eur"`
+	ExpectedSpentEUR      float64  `json:"expected_spent_eur"`
+	ProjectedSpendEUR     float64  `json:"projected_spend_eur"`
+	ProjectedRemainingEUR float64  `json:"projected_remaining_eur"`
+	OnPace                bool     `json:"on_pace"`
+	DaysUntilExhausted    *float64 `json:"days_until_exhausted,omitempty"`
+}
+
+// PeriodRow is one finished period of a recurring budget.
+type PeriodRow struct {
+	StartsAt       time.Time `json:"starts_at"`
+	EndsAt         time.Time `json:"ends_at"`
+	AmountEUR      float64   `json:"amount_eur"`
+	CarriedOverEUR float64   `json:"carried_over_eur"`
+	SpentEUR       float64   `json:"spent_eur"`
+	RemainingEUR   float64   `json:"remaining_eur"`
 }
 
 // GetBudgetProgressOutput is the MCP tool output.
 type GetBudgetProgressOutput struct {
 	Budgets []BudgetProgressRow `json:"budgets"`
+	Error   string              `json:"error,omitempty"`
 }
 
 func GetBudgetProgress(ctx context.Context, _ *mcp.CallToolRequest, input GetBudgetProgressInput) (*mcp.CallToolResult, GetBudgetProgressOutput, error) {
@@ -47,29 +82,72 @@ func GetBudgetProgress(ctx context.Context, _ *mcp.CallToolRequest, input GetBud
 		return nil, GetBudgetProgressOutput{}, fmt.Errorf("user_id not available in context")
 	}
 
+	if input.History < 0 {
+		return nil, GetBudgetProgressOutput{Error: "history must not be negative"}, nil
+	}
+	history := input.History
+	if history == 0 {
+		history = defaultHistory
+	}
+
 	at := input.At
 	if at.IsZero() {
 		at = time.Now().UTC()
 	}
 
-	rows, err := db.GetBudgetProgress(ctx, userID, at)
+	evaluations, err := Evaluate(ctx, db, userID, at)
 	if err != nil {
 		return nil, GetBudgetProgressOutput{}, fmt.Errorf("database error: %w", err)
 	}
 
-	budgets := make([]BudgetProgressRow, len(rows))
-	for i, r := range rows {
-		budgets[i] = BudgetProgressRow{
-			ID:           r.ID,
-			Name:         r.Name,
-			Category:     r.Category,
-			AmountEUR:    r.AmountEUR,
-			SpentEUR:     r.SpentEUR,
-			RemainingEUR: r.RemainingEUR,
-			StartsAt:     r.StartsAt,
-			EndsAt:       r.EndsAt,
-		}
+	budgets := make([]BudgetProgressRow, len(evaluations))
+	for i, e := range evaluations {
+		budgets[i] = toRow(e, history)
 	}
 
 	return nil, GetBudgetProgressOutput{Budgets: budgets}, nil
 }
+
+func toRow(e Evaluation, history int) BudgetProgressRow {
+	p, f := e.Progress, e.Forecast
+	row := BudgetProgressRow{
+		ID:             p.ID,
+		Name:           p.Name,
+		Category:       p.Category,
+		AmountEUR:      p.AmountEUR,
+		SpentEUR:       p.SpentEUR,
+		RemainingEUR:   p.RemainingEUR,
+		StartsAt:       p.StartsAt,
+		EndsAt:         p.EndsAt,
+		CarriedOverEUR: p.CarriedOverEUR,
+		Forecast: ForecastRow{
+			Curve:                 f.Curve,
+			ElapsedPct:            math.Round(f.ElapsedFraction * 100),
+			DailyBurnEUR:          f.DailyBurnEUR,
+			ExpectedSpentEUR:      f.ExpectedSpentEUR,
+			ProjectedSpendEUR:     f.ProjectedSpendEUR,
+			ProjectedRemainingEUR: f.ProjectedRemainingEUR,
+			OnPace:                f.OnPace,
+			DaysUntilExhausted:    f.DaysUntilExhausted,
+		},
+	}
+	if e.Definition == nil {
+		return row
+	}
+
+	row.Name = e.Definition.Name
+	row.Period = string(e.Definition.Period)
+	row.Rollover = e.Definition.Rollover
+	for i := len(e.Past) - 1; i >= 0 && len(row.History) < history; i-- {
+		past := e.Past[i]
+		row.History = append(row.History, PeriodRow{
+			StartsAt:       past.StartsAt,
+			EndsAt:         past.EndsAt,
+			AmountEUR:      past.AmountEUR,
+			CarriedOverEUR: past.CarriedOverEUR,
+			SpentEUR:       past.SpentEUR,
+			RemainingEUR:   past.RemainingEUR,
+		})
+	}
+	return row
+}
@@ -0,0 +1,181 @@
+package get_cashflow_report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+const (
+	defaultMonths = 12
+	maxMonths     = 60
+	monthLayout   = "2006-01"
+)
+
+var MCPDefinition = mcp.Tool{
+	Name: "get_cashflow_report",
+	Description: "Monthly cash-flow timeline in EUR: income, expense, net and savings rate per month, " +
+		"month-end balance per registered account (from its opening balance, in the account currency), " +
+		"and a category-by-month expense matrix for charting. Transfers are excluded from income and expense. " +
+		"Covers whole calendar months (UTC); default the last 12 months, max 60.",
+}
+
+// GetCashflowReportInput is the MCP tool input.
+type GetCashflowReportInput struct {
+	From  *time.Time `json:"from,omitempty" jsonschema:"Any moment in the first month (default 11 months before to)"`
+	To    *time.Time `json:"to,omitempty" jsonschema:"Any moment in the last month (default now)"`
+	Depth int        `json:"depth,omitempty" jsonschema:"Category levels in the matrix (default 1)"`
+}
+
+// MonthOutput is one month of the cash-flow series.
+type MonthOutput struct {
+	Month          string   `json:"month"`
+	IncomeEUR      float64  `json:"income_eur"`
+	ExpenseEUR     float64  `json:"expense_eur"`
+	NetEUR         float64  `json:"net_eur"`
+	SavingsRatePct *float64 `json:"savings_rate_pct,omitempty"`
+}
+
+// TotalsOutput sums the whole report range.
+type TotalsOutput struct {
+	IncomeEUR      float64  `json:"income_eur"`
+	ExpenseEUR     float64  `json:"expense_eur"`
+	NetEUR         float64  `json:"net_eur"`
+	SavingsRatePct *float64 `json:"savings_rate_pct,omitempty"`
+}
+
+// NetWorthOutput is the month-end balance line of one account, or the sum of
+// all accounts in one currency when Account is empty.
+type NetWorthOutput struct {
+	Account  string    `json:"account,omitempty"`
+	Currency string    `json:"currency"`
+	Balances []float64 `json:"balances"`
+}
+
+// CategoryRowOutput is one category of the matrix.
+type CategoryRowOutput struct {
+	Category  string    `json:"category"`
+	MonthsEUR []float64 `json:"months_eur"`
+	TotalEUR  float64   `json:"total_eur"`
+}
+
+// GetCashflowReportOutput is the MCP tool output. Every series is aligned
+// with Months.
+type GetCashflowReportOutput struct {
+	From           time.Time           `json:"from"`
+	To             time.Time           `json:"to"`
+	Months         []string            `json:"months"`
+	Cashflow       []MonthOutput       `json:"cashflow"`
+	Totals         TotalsOutput        `json:"totals"`
+	NetWorth       []NetWorthOutput    `json:"net_worth"`
+	NetWorthTotals []NetWorthOutput    `json:"net_worth_totals"`
+	CategoryMatrix []CategoryRowOutput `json:"category_matrix"`
+	Error          string              `json:"error,omitempty"`
+}
+
+func GetCashflowReport(ctx context.Context, _ *mcp.CallToolRequest, input GetCashflowReportInput) (*mcp.CallToolResult, GetCashflowReportOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, GetCashflowReportOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, GetCashflowReportOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	to := time.Now().UTC()
+	if input.To != nil {
+		to = input.To.UTC()
+	}
+	from := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1-defaultMonths, 0)
+	if input.From != nil {
+		from = input.From.UTC()
+	}
+	if from.After(to) {
+		return nil, GetCashflowReportOutput{Error: "from must not be after to"}, nil
+	}
+	months := domain.MonthsBetween(from, to)
+	if len(months) > maxMonths {
+		return nil, GetCashflowReportOutput{Error: fmt.Sprintf("range covers %d months, max %d", len(months), maxMonths)}, nil
+	}
+	depth := input.Depth
+	if depth < 1 {
+		depth = 1
+	}
+
+	// Whole months: from the 1st of the first month to the last microsecond
+	// of the last one.
+	start := months[0]
+	end := months[len(months)-1].AddDate(0, 1, 0).Add(-time.Microsecond)
+
+	cashflow, err := db.GetMonthlyCashflow(ctx, userID, start, end)
+	if err != nil {
+		return nil, GetCashflowReportOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	categories, err := db.GetMonthlyCategorySpending(ctx, userID, start, end, depth)
+	if err != nil {
+		return nil, GetCashflowReportOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	opening, err := db.GetAccountBalances(ctx, userID, start.Add(-time.Microsecond))
+	if err != nil {
+		return nil, GetCashflowReportOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	nets, err := db.GetMonthlyAccountNets(ctx, userID, start, end)
+	if err != nil {
+		return nil, GetCashflowReportOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	out := GetCashflowReportOutput{From: start, To: end, Months: make([]string, len(months))}
+	for i, m := range months {
+		out.Months[i] = m.Format(monthLayout)
+	}
+
+	for _, p := range domain.CashflowSeries(months, cashflow) {
+		out.Cashflow = append(out.Cashflow, MonthOutput{
+			Month:          p.Month.Format(monthLayout),
+			IncomeEUR:      p.IncomeEUR,
+			ExpenseEUR:     p.ExpenseEUR,
+			NetEUR:         p.NetEUR,
+			SavingsRatePct: p.SavingsRate,
+		})
+		out.Totals.IncomeEUR += p.IncomeEUR
+		out.Totals.ExpenseEUR += p.ExpenseEUR
+	}
+	out.Totals.IncomeEUR = roundCents(out.Totals.IncomeEUR)
+	out.Totals.ExpenseEUR = roundCents(out.Totals.ExpenseEUR)
+	out.Totals.NetEUR = roundCents(out.Totals.IncomeEUR - out.Totals.ExpenseEUR)
+	out.Totals.SavingsRatePct = domain.SavingsRate(out.Totals.IncomeEUR, out.Totals.ExpenseEUR)
+
+	out.NetWorth = []NetWorthOutput{}
+	out.NetWorthTotals = []NetWorthOutput{}
+	byCurrency := map[string]int{}
+	for _, s := range domain.NetWorth(months, opening, nets) {
+		out.NetWorth = append(out.NetWorth, NetWorthOutput{Account: s.Account, Currency: s.Currency, Balances: s.Balances})
+
+		i, ok := byCurrency[s.Currency]
+		if !ok {
+			i = len(out.NetWorthTotals)
+			byCurrency[s.Currency] = i
+			out.NetWorthTotals = append(out.NetWorthTotals, NetWorthOutput{Currency: s.Currency, Balances: make([]float64, len(months))})
+		}
+		for m, b := range s.Balances {
+			out.NetWorthTotals[i].Balances[m] = roundCents(out.NetWorthTotals[i].Balances[m] + b)
+		}
+	}
+
+	out.CategoryMatrix = []CategoryRowOutput{}
+	for _, row := range domain.CategoryMatrix(months, categories) {
+		out.CategoryMatrix = append(out.CategoryMatrix, CategoryRowOutput{Category: row.Category, MonthsEUR: row.MonthsEUR, TotalEUR: row.TotalEUR})
+	}
+	return nil, out, nil
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -26,16 +26,19 @@ Use this before starting an exercise to see previous weights and reps.
 Parameters:
 - exercise_id: ID of the exercise
 - limit: max sessions to return (optional, default 20)
-- offset: pagination offset (optional, default 0)
+- cursor: next_cursor from the previous page (optional)
+- offset: pagination offset (optional, default 0, ignored with cursor)
 
 Returns:
-- sessions: array of {workout_id, date, sets: [{set_id, weight_kg, reps, duration_seconds}]}`,
+- sessions: array of {workout_id, date, sets: [{set_id, weight_kg, reps, duration_seconds}]}
+- next_cursor: pass as cursor to get older sessions; absent on the last page`,
 }
 
 type GetExerciseHistoryInput struct {
-	ExerciseID int64 `json:"exercise_id" jsonschema:"Exercise ID"`
-	Limit      int   `json:"limit,omitempty" jsonschema:"Max sessions to return (default 20)"`
-	Offset     int   `json:"offset,omitempty" jsonschema:"Pagination offset (default 0)"`
+	ExerciseID int64  `json:"exercise_id" jsonschema:"Exercise ID"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"Max sessions to return (default 20)"`
+	Offset     int    `json:"offset,omitempty" jsonschema:"Pagination offset (default 0, ignored with cursor)"`
+	Cursor     string `json:"cursor,omitempty" jsonschema:"next_cursor of the previous page"`
 }
 
 type SetSummary struct {
@@ -52,7 +55,8 @@ type ExerciseSession struct {
 }
 
 type GetExerciseHistoryOutput struct {
-	Sessions []ExerciseSession `json:"sessions"`
+	Sessions   []ExerciseSession `json:"sessions"`
+	NextCursor string            `json:"next_cursor,omitempty"`
 }
 
 func GetExerciseHistory(ctx context.Context, _ *mcp.CallToolRequest, input GetExerciseHistoryInput) (*mcp.CallToolResult, GetExerciseHistoryOutput, error) {
@@ -75,10 +79,26 @@ func GetExerciseHistory(ctx context.Context, _ *mcp.CallToolRequest, input GetEx
 		limit = 20
 	}
 
-	workouts, err := db.GetExerciseHistory(ctx, userID, input.ExerciseID, limit, input.Offset)
+	var after *domain.Cursor
+	if input.Cursor != "" {
+		var err error
+		after, err = domain.DecodeCursor(input.Cursor, domain.CursorExerciseHistory)
+		if err != nil {
+			return nil, GetExerciseHistoryOutput{}, fmt.Errorf("invalid cursor: %w", err)
+		}
+	}
+
+	// One extra workout tells whether another page follows.
+	workouts, err := db.GetExerciseHistory(ctx, userID, input.ExerciseID, after, limit+1, input.Offset)
 	if err != nil {
 		return nil, GetExerciseHistoryOutput{}, fmt.Errorf("failed to get exercise history: %w", err)
 	}
+	var nextCursor string
+	if len(workouts) > limit {
+		workouts = workouts[:limit]
+		last := workouts[limit-1]
+		nextCursor = domain.Cursor{Kind: domain.CursorExerciseHistory, At: last.StartedAt, ID: last.ID}.Encode()
+	}
 
 	if len(workouts) == 0 {
 		return nil, GetExerciseHistoryOutput{Sessions: []ExerciseSession{}}, nil
@@ -118,5 +138,5 @@ func GetExerciseHistory(ctx context.Context, _ *mcp.CallToolRequest, input GetEx
 		})
 	}
 
-	return nil, GetExerciseHistoryOutput{Sessions: sessions}, nil
+	return nil, GetExerciseHistoryOutput{Sessions: sessions, NextCursor: nextCursor}, nil
 }
@@ -12,7 +12,7 @@ import (
 
 var MCPDefinition = mcp.Tool{
 	Name:        "get_spending_by_category",
-	Description: "Aggregated expense spending per category for a date range. depth=1 groups by top-level (e.g. 'food'), depth=2 by subcategory (e.g. 'food/cafe'). Income and transfers excluded.",
+	Description: "Aggregated expense spending per category for a date range. depth=1 groups by top-level (e.g. 'food'), depth=2 by subcategory (e.g. 'food/cafe'). Income, transfers and paired transfers between own accounts excluded.",
 }
 
 // GetSpendingByCategoryInput is the MCP tool input.
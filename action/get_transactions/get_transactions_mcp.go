@@ -13,26 +13,37 @@ import (
 )
 
 var MCPDefinition = mcp.Tool{
-	Name:        "get_transactions",
-	Description: "List transactions with optional filters: date range, account, category (prefix match), type, merchant. Default limit 50, max 200.",
+	Name: "get_transactions",
+	Description: "List transactions with optional filters: date range, account, category (prefix match, also matches split lines), type, merchant, " +
+		"tags (all must match), text search over merchant, note and bank description, and EUR amount range. " +
+		"Split transactions include their line items. Newest first; default limit 50, max 1000. " +
+		"Page with next_cursor (stable while rows are added) rather than offset; set skip_total to avoid counting every match on large ranges.",
 }
 
 // GetTransactionsInput is the MCP tool input.
 type GetTransactionsInput struct {
-	From     *time.Time `json:"from,omitempty"`
-	To       *time.Time `json:"to,omitempty"`
-	Account  *string    `json:"account,omitempty"`
-	Category *string    `json:"category,omitempty"`
-	Type     *string    `json:"type,omitempty"`
-	Merchant *string    `json:"merchant,omitempty"`
-	Limit    int        `json:"limit,omitempty"`
-	Offset   int        `json:"offset,omitempty"`
+	From      *time.Time `json:"from,omitempty"`
+	To        *time.Time `json:"to,omitempty"`
+	Account   *string    `json:"account,omitempty"`
+	Category  *string    `json:"category,omitempty"`
+	Type      *string    `json:"type,omitempty"`
+	Merchant  *string    `json:"merchant,omitempty"`
+	Tags      []string   `json:"tags,omitempty" jsonschema:"Only transactions carrying every listed tag"`
+	Query     *string    `json:"query,omitempty" jsonschema:"Words to find in merchant, note or original description; each word matches as a prefix"`
+	MinEUR    *float64   `json:"min_amount_eur,omitempty" jsonschema:"Minimum amount_eur, inclusive"`
+	MaxEUR    *float64   `json:"max_amount_eur,omitempty" jsonschema:"Maximum amount_eur, inclusive"`
+	Limit     int        `json:"limit,omitempty" jsonschema:"Page size (default 50, max 1000)"`
+	Offset    int        `json:"offset,omitempty" jsonschema:"Rows to skip; ignored with cursor"`
+	Cursor    string     `json:"cursor,omitempty" jsonschema:"next_cursor of the previous page"`
+	SkipTotal bool       `json:"skip_total,omitempty" jsonschema:"Do not count all matches; total is omitted"`
 }
 
 // GetTransactionsOutput is the MCP tool output.
 type GetTransactionsOutput struct {
 	Transactions []add_transactions.TransactionOutput `json:"transactions"`
-	Total        int                                  `json:"total"`
+	Total        int                                  `json:"total,omitempty"`
+	NextCursor   string                               `json:"next_cursor,omitempty"`
+	Error        string                               `json:"error,omitempty"`
 }
 
 func GetTransactions(ctx context.Context, _ *mcp.CallToolRequest, input GetTransactionsInput) (*mcp.CallToolResult, GetTransactionsOutput, error) {
@@ -45,13 +56,44 @@ func GetTransactions(ctx context.Context, _ *mcp.CallToolRequest, input GetTrans
 		return nil, GetTransactionsOutput{}, fmt.Errorf("user_id not available in context")
 	}
 
+	if input.MinEUR != nil && input.MaxEUR != nil && *input.MinEUR > *input.MaxEUR {
+		return nil, GetTransactionsOutput{Error: "min_amount_eur must not exceed max_amount_eur"}, nil
+	}
+	if input.Query != nil && !domain.HasSearchWords(*input.Query) {
+		return nil, GetTransactionsOutput{Error: "query must contain at least one letter or digit"}, nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > domain.MaxTransactionsPage {
+		limit = domain.MaxTransactionsPage
+	}
+
 	filter := domain.TransactionFilter{
-		UserID:  userID,
-		From:    input.From,
-		To:      input.To,
-		Account: input.Account,
-		Limit:   input.Limit,
-		Offset:  input.Offset,
+		UserID:    userID,
+		From:      input.From,
+		To:        input.To,
+		Account:   input.Account,
+		Query:     input.Query,
+		MinEUR:    input.MinEUR,
+		MaxEUR:    input.MaxEUR,
+		Limit:     limit + 1, // one extra row tells whether another page follows
+		Offset:    input.Offset,
+		SkipTotal: input.SkipTotal,
+	}
+	if input.Cursor != "" {
+		after, err := domain.DecodeCursor(input.Cursor, domain.CursorTransactions)
+		if err != nil {
+			return nil, GetTransactionsOutput{Error: err.Error()}, nil
+		}
+		filter.After = after
+	}
+	for _, tag := range input.Tags {
+		if tag = domain.NormalizeTag(tag); tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
 	}
 	if input.Category != nil {
 		filter.Category = input.Category
@@ -68,6 +110,33 @@ func GetTransactions(ctx context.Context, _ *mcp.CallToolRequest, input GetTrans
 	if err != nil {
 		return nil, GetTransactionsOutput{}, fmt.Errorf("database error: %w", err)
 	}
+	var nextCursor string
+	if len(txs) > limit {
+		txs = txs[:limit]
+		last := txs[limit-1]
+		nextCursor = domain.Cursor{Kind: domain.CursorTransactions, At: last.TransactedAt, ID: last.ID}.Encode()
+	}
+
+	ids := make([]int64, len(txs))
+	for i, tx := range txs {
+		ids[i] = tx.ID
+	}
+	splits, err := db.ListTransactionSplits(ctx, userID, ids)
+	if err != nil {
+		return nil, GetTransactionsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	splitsByTx := make(map[int64][]domain.TransactionSplit)
+	for _, sp := range splits {
+		splitsByTx[sp.TransactionID] = append(splitsByTx[sp.TransactionID], sp)
+	}
+	tags, err := db.ListTransactionTags(ctx, userID, ids)
+	if err != nil {
+		return nil, GetTransactionsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	tagsByTx := make(map[int64][]string)
+	for _, t := range tags {
+		tagsByTx[t.TransactionID] = append(tagsByTx[t.TransactionID], t.Name)
+	}
 
 	out := make([]add_transactions.TransactionOutput, len(txs))
 	for i, tx := range txs {
@@ -82,9 +151,18 @@ func GetTransactions(ctx context.Context, _ *mcp.CallToolRequest, input GetTrans
 			Merchant:            tx.Merchant,
 			Note:                tx.Note,
 			OriginalDescription: tx.OriginalDescription,
+			ExternalID:          tx.ExternalID,
+			Direction:           string(tx.Direction),
+			BalanceAfter:        tx.BalanceAfter,
+			TransferPeerID:      tx.TransferPeerID,
 			TransactedAt:        tx.TransactedAt,
+			ValueDate:           tx.ValueDate,
+			Tags:                tagsByTx[tx.ID],
+		}
+		if lines := splitsByTx[tx.ID]; len(lines) > 0 {
+			out[i].Splits = add_transactions.SplitsToOutput(lines)
 		}
 	}
 
-	return nil, GetTransactionsOutput{Transactions: out, Total: total}, nil
+	return nil, GetTransactionsOutput{Transactions: out, Total: total, NextCursor: nextCursor}, nil
 }
@@ -0,0 +1,65 @@
+package import_profile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	money_import "personal/action/money_import"
+	"personal/gateways"
+)
+
+var ListImportProfilesMCPDefinition = mcp.Tool{
+	Name:        "list_import_profiles",
+	Description: "List bank statement formats available on /money/import: built-in parsers (Revolut, Bank of Cyprus, OFX/QFX, QIF, CAMT.053) and saved CSV column-map profiles.",
+}
+
+// ListImportProfilesInput is the MCP tool input.
+type ListImportProfilesInput struct{}
+
+// BuiltinParserOutput describes a built-in parser.
+type BuiltinParserOutput struct {
+	Name            string   `json:"name"`
+	Aliases         []string `json:"aliases,omitempty"`
+	HeaderSignature []string `json:"header_signature"`
+}
+
+// ListImportProfilesOutput is the MCP tool output.
+type ListImportProfilesOutput struct {
+	Builtin  []BuiltinParserOutput `json:"builtin"`
+	Profiles []ImportProfileOutput `json:"profiles"`
+}
+
+func ListImportProfiles(ctx context.Context, _ *mcp.CallToolRequest, _ ListImportProfilesInput) (*mcp.CallToolResult, ListImportProfilesOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, ListImportProfilesOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, ListImportProfilesOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	profiles, err := db.ListImportProfiles(ctx, userID)
+	if err != nil {
+		return nil, ListImportProfilesOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	out := ListImportProfilesOutput{
+		Builtin:  []BuiltinParserOutput{},
+		Profiles: make([]ImportProfileOutput, len(profiles)),
+	}
+	for _, p := range money_import.BuiltinParsers() {
+		out.Builtin = append(out.Builtin, BuiltinParserOutput{
+			Name:            p.Name(),
+			Aliases:         p.Aliases(),
+			HeaderSignature: p.HeaderSignature(),
+		})
+	}
+	for i, p := range profiles {
+		out.Profiles[i] = toOutput(p)
+	}
+
+	return nil, out, nil
+}
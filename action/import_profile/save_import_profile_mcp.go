@@ -0,0 +1,143 @@
+package import_profile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	money_import "personal/action/money_import"
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+var SaveImportProfileMCPDefinition = mcp.Tool{
+	Name:        "save_import_profile",
+	Description: "Create or update a CSV column map for importing a bank export that has no built-in parser (e.g. Wise, N26). Upserts by name. The profile appears on the /money/import page and is auto-detected from the CSV header. Set either amount_column (signed amount) or debit_column/credit_column.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Save import profile",
+	},
+}
+
+// ImportProfileInput describes a bank CSV format.
+type ImportProfileInput struct {
+	Name              string   `json:"name" jsonschema:"Bank/format name, also the account name for auto-detected imports (e.g. Wise)"`
+	Aliases           []string `json:"aliases,omitempty" jsonschema:"Alternative names accepted on import"`
+	Delimiter         string   `json:"delimiter,omitempty" jsonschema:"Single-character field separator (default ',')"`
+	DateColumn        string   `json:"date_column" jsonschema:"Header of the date column"`
+	DateFormat        string   `json:"date_format,omitempty" jsonschema:"Date pattern like DD.MM.YYYY or a Go layout; empty = auto-detect common formats"`
+	DescriptionColumn string   `json:"description_column" jsonschema:"Header of the description/payee column"`
+	AmountColumn      string   `json:"amount_column,omitempty" jsonschema:"Header of a signed amount column"`
+	DebitColumn       string   `json:"debit_column,omitempty" jsonschema:"Header of the money-out column"`
+	CreditColumn      string   `json:"credit_column,omitempty" jsonschema:"Header of the money-in column"`
+	CurrencyColumn    string   `json:"currency_column,omitempty" jsonschema:"Header of the currency column"`
+	DefaultCurrency   string   `json:"default_currency,omitempty" jsonschema:"Currency when no currency column (default EUR)"`
+	DecimalStyle      string   `json:"decimal_style,omitempty" jsonschema:"dot (1,234.56) or comma (1.234,56); default dot"`
+	SignConvention    string   `json:"sign_convention,omitempty" jsonschema:"negative_is_expense (default) or positive_is_expense; only for amount_column"`
+}
+
+// ImportProfileOutput mirrors domain.ImportProfile for JSON serialization.
+type ImportProfileOutput struct {
+	ID                int64     `json:"id"`
+	Name              string    `json:"name"`
+	Aliases           []string  `json:"aliases,omitempty"`
+	Delimiter         string    `json:"delimiter"`
+	DateColumn        string    `json:"date_column"`
+	DateFormat        string    `json:"date_format,omitempty"`
+	DescriptionColumn string    `json:"description_column"`
+	AmountColumn      string    `json:"amount_column,omitempty"`
+	DebitColumn       string    `json:"debit_column,omitempty"`
+	CreditColumn      string    `json:"credit_column,omitempty"`
+	CurrencyColumn    string    `json:"currency_column,omitempty"`
+	DefaultCurrency   string    `json:"default_currency"`
+	DecimalStyle      string    `json:"decimal_style"`
+	SignConvention    string    `json:"sign_convention"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// SaveImportProfileOutput is the MCP tool output.
+type SaveImportProfileOutput struct {
+	Profile ImportProfileOutput `json:"profile"`
+	Error   string              `json:"error,omitempty"`
+}
+
+func SaveImportProfile(ctx context.Context, _ *mcp.CallToolRequest, input ImportProfileInput) (*mcp.CallToolResult, SaveImportProfileOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, SaveImportProfileOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, SaveImportProfileOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	p := domain.ImportProfile{
+		UserID:            userID,
+		Name:              strings.TrimSpace(input.Name),
+		Aliases:           input.Aliases,
+		Delimiter:         input.Delimiter,
+		DateColumn:        strings.TrimSpace(input.DateColumn),
+		DateFormat:        strings.TrimSpace(input.DateFormat),
+		DescriptionColumn: strings.TrimSpace(input.DescriptionColumn),
+		AmountColumn:      strings.TrimSpace(input.AmountColumn),
+		DebitColumn:       strings.TrimSpace(input.DebitColumn),
+		CreditColumn:      strings.TrimSpace(input.CreditColumn),
+		CurrencyColumn:    strings.TrimSpace(input.CurrencyColumn),
+		DefaultCurrency:   strings.ToUpper(strings.TrimSpace(input.DefaultCurrency)),
+		DecimalStyle:      domain.DecimalStyle(input.DecimalStyle),
+		SignConvention:    domain.SignConvention(input.SignConvention),
+	}
+	if p.Delimiter == "" {
+		p.Delimiter = ","
+	}
+	if p.DefaultCurrency == "" {
+		p.DefaultCurrency = "EUR"
+	}
+	if p.DecimalStyle == "" {
+		p.DecimalStyle = domain.DecimalStyleDot
+	}
+	if p.SignConvention == "" {
+		p.SignConvention = domain.SignNegativeIsExpense
+	}
+
+	if err := money_import.ValidateImportProfile(p); err != nil {
+		return nil, SaveImportProfileOutput{Error: err.Error()}, nil
+	}
+	for _, builtin := range money_import.BuiltinParsers() {
+		if strings.EqualFold(builtin.Name(), p.Name) {
+			return nil, SaveImportProfileOutput{Error: fmt.Sprintf("%q is a built-in parser name", builtin.Name())}, nil
+		}
+	}
+
+	id, err := db.SaveImportProfile(ctx, &p)
+	if err != nil {
+		return nil, SaveImportProfileOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	p.ID = id
+
+	return nil, SaveImportProfileOutput{Profile: toOutput(p)}, nil
+}
+
+func toOutput(p domain.ImportProfile) ImportProfileOutput {
+	return ImportProfileOutput{
+		ID:                p.ID,
+		Name:              p.Name,
+		Aliases:           p.Aliases,
+		Delimiter:         p.Delimiter,
+		DateColumn:        p.DateColumn,
+		DateFormat:        p.DateFormat,
+		DescriptionColumn: p.DescriptionColumn,
+		AmountColumn:      p.AmountColumn,
+		DebitColumn:       p.DebitColumn,
+		CreditColumn:      p.CreditColumn,
+		CurrencyColumn:    p.CurrencyColumn,
+		DefaultCurrency:   strings.TrimSpace(p.DefaultCurrency),
+		DecimalStyle:      string(p.DecimalStyle),
+		SignConvention:    string(p.SignConvention),
+		UpdatedAt:         p.UpdatedAt,
+	}
+}
@@ -3,8 +3,6 @@ package list_workouts
 import (
 	"context"
 	"fmt"
-	"sort"
-	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
@@ -17,19 +15,20 @@ var MCPDefinition = mcp.Tool{
 	Annotations: &mcp.ToolAnnotations{
 		Title: "List workouts",
 	},
-	Description: `List recent workouts with their exercises and sets.
+	Description: `List workouts with their exercises and sets, newest first.
 
-This tool returns recent workouts (last 30 days) with complete details:
-- Workouts sorted by start time (most recent first)
-- Each workout includes all sets grouped by exercise
+- Workouts sorted by start time (most recent first), one page at a time
+- Each workout includes all sets grouped by exercise, in the order they were logged
 - Exercise details (name, equipment type) included for each set
 - Shows active workouts (completed_at = null) and completed workouts
+- Pass next_cursor as cursor to get older workouts; it is absent on the last page
 
 Returns an array of workouts with nested exercises and sets.`,
 }
 
 type ListWorkoutsInput struct {
-	Limit int `json:"limit,omitempty" jsonschema:"Maximum number of workouts to return (default 10)"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"Maximum number of workouts to return (default 10)"`
+	Cursor string `json:"cursor,omitempty" jsonschema:"next_cursor of the previous page"`
 }
 
 type SetItem struct {
@@ -56,7 +55,8 @@ type WorkoutItem struct {
 }
 
 type ListWorkoutsOutput struct {
-	Workouts []WorkoutItem `json:"workouts" jsonschema:"List of workouts"`
+	Workouts   []WorkoutItem `json:"workouts" jsonschema:"List of workouts"`
+	NextCursor string        `json:"next_cursor,omitempty" jsonschema:"Cursor for the next page of older workouts"`
 }
 
 func ListWorkouts(ctx context.Context, _ *mcp.CallToolRequest, input ListWorkoutsInput) (*mcp.CallToolResult, ListWorkoutsOutput, error) {
@@ -74,45 +74,51 @@ func ListWorkouts(ctx context.Context, _ *mcp.CallToolRequest, input ListWorkout
 
 	// Set default limit
 	limit := input.Limit
-	if limit == 0 {
+	if limit <= 0 {
 		limit = 10
 	}
 
-	// Get sets from last 30 days
-	now := time.Now()
-	from := now.AddDate(0, 0, -30)
-	sets, err := db.ListSets(ctx, userID, from, now)
-	if err != nil {
-		return nil, ListWorkoutsOutput{}, fmt.Errorf("failed to list sets: %w", err)
+	var after *domain.Cursor
+	if input.Cursor != "" {
+		var err error
+		after, err = domain.DecodeCursor(input.Cursor, domain.CursorWorkouts)
+		if err != nil {
+			return nil, ListWorkoutsOutput{}, fmt.Errorf("invalid cursor: %w", err)
+		}
 	}
 
-	// If no sets, return empty workouts
-	if len(sets) == 0 {
-		return nil, ListWorkoutsOutput{Workouts: []WorkoutItem{}}, nil
+	// One extra workout tells whether another page follows
+	workouts, err := db.ListWorkoutsPage(ctx, userID, after, limit+1)
+	if err != nil {
+		return nil, ListWorkoutsOutput{}, fmt.Errorf("failed to list workouts: %w", err)
 	}
-
-	// Extract unique workout IDs and exercise IDs
-	workoutIDsMap := make(map[int64]bool)
-	exerciseIDsMap := make(map[int64]bool)
-	for _, set := range sets {
-		workoutIDsMap[set.WorkoutID] = true
-		exerciseIDsMap[set.ExerciseID] = true
+	output := ListWorkoutsOutput{Workouts: make([]WorkoutItem, 0, len(workouts))}
+	if len(workouts) > limit {
+		workouts = workouts[:limit]
+		last := workouts[limit-1]
+		output.NextCursor = domain.Cursor{Kind: domain.CursorWorkouts, At: last.StartedAt, ID: last.ID}.Encode()
+	}
+	if len(workouts) == 0 {
+		return nil, output, nil
 	}
 
-	// Convert maps to slices
-	workoutIDs := make([]int64, 0, len(workoutIDsMap))
-	for id := range workoutIDsMap {
-		workoutIDs = append(workoutIDs, id)
+	workoutIDs := make([]int64, len(workouts))
+	for i, w := range workouts {
+		workoutIDs[i] = w.ID
 	}
-	exerciseIDs := make([]int64, 0, len(exerciseIDsMap))
-	for id := range exerciseIDsMap {
-		exerciseIDs = append(exerciseIDs, id)
+	sets, err := db.ListSetsByWorkouts(ctx, userID, workoutIDs)
+	if err != nil {
+		return nil, ListWorkoutsOutput{}, fmt.Errorf("failed to list sets: %w", err)
 	}
 
-	// Get workout details
-	workouts, err := db.GetWorkoutsByIDs(ctx, userID, workoutIDs)
-	if err != nil {
-		return nil, ListWorkoutsOutput{}, fmt.Errorf("failed to get workouts: %w", err)
+	// Extract unique exercise IDs
+	exerciseIDsMap := make(map[int64]bool)
+	exerciseIDs := make([]int64, 0)
+	for _, set := range sets {
+		if !exerciseIDsMap[set.ExerciseID] {
+			exerciseIDsMap[set.ExerciseID] = true
+			exerciseIDs = append(exerciseIDs, set.ExerciseID)
+		}
 	}
 
 	// Get exercise details
@@ -120,31 +126,25 @@ func ListWorkouts(ctx context.Context, _ *mcp.CallToolRequest, input ListWorkout
 	if err != nil {
 		return nil, ListWorkoutsOutput{}, fmt.Errorf("failed to get exercises: %w", err)
 	}
-
-	// Create maps for quick lookup
-	workoutMap := make(map[int64]domain.Workout)
-	for _, w := range workouts {
-		workoutMap[w.ID] = w
-	}
 	exerciseMap := make(map[int64]domain.Exercise)
 	for _, e := range exercises {
 		exerciseMap[e.ID] = e
 	}
 
-	// Group sets by workout, then by exercise
+	// Group sets by workout, then by exercise in the order of the first set
 	workoutSets := make(map[int64]map[int64][]domain.Set)
+	workoutExercises := make(map[int64][]int64)
 	for _, set := range sets {
 		if workoutSets[set.WorkoutID] == nil {
 			workoutSets[set.WorkoutID] = make(map[int64][]domain.Set)
 		}
+		if _, ok := workoutSets[set.WorkoutID][set.ExerciseID]; !ok {
+			workoutExercises[set.WorkoutID] = append(workoutExercises[set.WorkoutID], set.ExerciseID)
+		}
 		workoutSets[set.WorkoutID][set.ExerciseID] = append(workoutSets[set.WorkoutID][set.ExerciseID], set)
 	}
 
 	// Build output
-	output := ListWorkoutsOutput{
-		Workouts: make([]WorkoutItem, 0, len(workouts)),
-	}
-
 	for _, workout := range workouts {
 		item := WorkoutItem{
 			ID:        workout.ID,
@@ -159,8 +159,8 @@ func ListWorkouts(ctx context.Context, _ *mcp.CallToolRequest, input ListWorkout
 		}
 
 		// Add exercises with their sets
-		exerciseSets := workoutSets[workout.ID]
-		for exerciseID, sets := range exerciseSets {
+		for _, exerciseID := range workoutExercises[workout.ID] {
+			sets := workoutSets[workout.ID][exerciseID]
 			exercise := exerciseMap[exerciseID]
 			exerciseWithSets := ExerciseWithSets{
 				ExerciseID:    exercise.ID,
@@ -186,15 +186,5 @@ func ListWorkouts(ctx context.Context, _ *mcp.CallToolRequest, input ListWorkout
 		output.Workouts = append(output.Workouts, item)
 	}
 
-	// Sort workouts by started_at DESC
-	sort.Slice(output.Workouts, func(i, j int) bool {
-		return output.Workouts[i].StartedAt > output.Workouts[j].StartedAt
-	})
-
-	// Limit results
-	if len(output.Workouts) > limit {
-		output.Workouts = output.Workouts[:limit]
-	}
-
 	return nil, output, nil
 }
@@ -0,0 +1,582 @@
+package money_dashboard
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"personal/action/compare_periods"
+	"personal/action/edit_transactions"
+	"personal/action/get_budget_progress"
+	"personal/domain"
+	"personal/gateways"
+)
+
+const (
+	defaultUserID      int64 = 1
+	topMerchantsCount        = 8
+	recentTransactions       = 20
+	dashboardPath            = "/web/money"
+)
+
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Money Dashboard</title>
+    <style>
+        * {
+            margin: 0;
+            padding: 0;
+            box-sizing: border-box;
+        }
+
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, monospace;
+            background: #fff;
+            color: #000;
+        }
+
+        .dashboard {
+            min-height: 100vh;
+            border: 2px solid #000;
+            display: flex;
+            flex-direction: column;
+        }
+
+        .summary {
+            padding: 12px 16px;
+            border-bottom: 2px solid #000;
+            display: flex;
+            justify-content: space-between;
+            align-items: baseline;
+        }
+
+        .summary-total {
+            font-size: 22px;
+            font-weight: 700;
+        }
+
+        .summary-stats {
+            display: flex;
+            gap: 16px;
+            font-size: 11px;
+            color: #808080;
+        }
+
+        .summary-stats strong {
+            color: #000;
+        }
+
+        .section-title {
+            font-size: 11px;
+            font-weight: 600;
+            letter-spacing: 0.5px;
+            text-transform: uppercase;
+            margin-bottom: 8px;
+        }
+
+        .panels {
+            display: flex;
+            border-bottom: 2px solid #000;
+        }
+
+        .panel {
+            flex: 1;
+            padding: 12px 16px;
+            min-width: 0;
+        }
+
+        .panel-separator {
+            width: 2px;
+            background: #000;
+        }
+
+        .bar-row {
+            margin-bottom: 8px;
+            font-size: 12px;
+        }
+
+        .bar-label {
+            display: flex;
+            justify-content: space-between;
+            gap: 8px;
+            margin-bottom: 2px;
+        }
+
+        .bar-label .muted, .muted {
+            color: #808080;
+        }
+
+        .bar {
+            position: relative;
+            height: 10px;
+            border: 1px solid #808080;
+        }
+
+        .bar-fill {
+            height: 100%;
+            background: #000;
+        }
+
+        .bar-fill.over {
+            background: repeating-linear-gradient(45deg, #000, #000 3px, #fff 3px, #fff 6px);
+        }
+
+        .bar-marker {
+            position: absolute;
+            top: -3px;
+            bottom: -3px;
+            width: 2px;
+            background: #808080;
+        }
+
+        .warning {
+            font-weight: 700;
+        }
+
+        table {
+            width: 100%;
+            border-collapse: collapse;
+            font-size: 12px;
+        }
+
+        th {
+            text-align: left;
+            font-size: 11px;
+            font-weight: 600;
+            text-transform: uppercase;
+            color: #808080;
+            padding: 4px 8px 4px 0;
+            border-bottom: 1px solid #000;
+        }
+
+        td {
+            padding: 4px 8px 4px 0;
+            border-bottom: 1px solid #e0e0e0;
+            vertical-align: middle;
+        }
+
+        td.amount, th.amount {
+            text-align: right;
+            white-space: nowrap;
+        }
+
+        .income {
+            color: #808080;
+        }
+
+        form.recategorize {
+            display: flex;
+            gap: 4px;
+        }
+
+        form.recategorize input {
+            font-family: monospace;
+            font-size: 12px;
+            padding: 2px 4px;
+            border: 1px solid #808080;
+            width: 100%;
+        }
+
+        form.recategorize button {
+            font-family: monospace;
+            font-size: 11px;
+            padding: 2px 8px;
+            border: 1px solid #000;
+            background: #fff;
+            cursor: pointer;
+        }
+
+        .recent {
+            padding: 12px 16px;
+        }
+
+        .message {
+            padding: 8px 16px;
+            border-bottom: 2px solid #000;
+            font-size: 12px;
+        }
+    </style>
+</head>
+<body>
+    <div class="dashboard">
+        <div class="summary">
+            <div>
+                <div class="section-title">Spent · {{.MonthLabel}} to date</div>
+                <div class="summary-total">{{eur .TotalEUR}}</div>
+            </div>
+            <div class="summary-stats">
+                <span>last month same days: <strong>{{eur .LastMonthEUR}}</strong></span>
+                <span>change: <strong{{if gt .ChangeEUR 0.0}} class="warning"{{end}}>{{signed .ChangeEUR}}{{if .ChangePct}} ({{.ChangePct}}){{end}}</strong></span>
+                <span>export: <a href="{{.ExportPath}}?format=csv">csv</a> · <a href="{{.ExportPath}}?format=ledger">ledger</a> · <a href="{{.ExportPath}}?format=beancount">beancount</a></span>
+            </div>
+        </div>
+        {{if .Message}}<div class="message">{{.Message}}</div>{{end}}
+
+        <div class="panels">
+            <div class="panel">
+                <div class="section-title">By category</div>
+                {{- range .Categories}}
+                <div class="bar-row">
+                    <div class="bar-label">
+                        <span>{{.Category}}</span>
+                        <span>{{eur .TotalEUR}} <span class="muted">{{signed .ChangeEUR}}</span></span>
+                    </div>
+                    <div class="bar"><div class="bar-fill" style="width: {{.WidthPct}}%"></div></div>
+                </div>
+                {{- else}}
+                <div class="muted">no spending yet</div>
+                {{- end}}
+            </div>
+            <div class="panel-separator"></div>
+            <div class="panel">
+                <div class="section-title">Budgets</div>
+                {{- range .Budgets}}
+                <div class="bar-row">
+                    <div class="bar-label">
+                        <span>{{.Name}}</span>
+                        <span{{if .Warning}} class="warning"{{end}}>{{eur .SpentEUR}} / {{eur .AvailableEUR}}</span>
+                    </div>
+                    <div class="bar">
+                        <div class="bar-fill{{if .Over}} over{{end}}" style="width: {{.WidthPct}}%"></div>
+                        <div class="bar-marker" style="left: {{.ElapsedPct}}%"></div>
+                    </div>
+                    <div class="muted">{{.Status}}</div>
+                </div>
+                {{- else}}
+                <div class="muted">no active budgets</div>
+                {{- end}}
+            </div>
+            <div class="panel-separator"></div>
+            <div class="panel">
+                <div class="section-title">Top merchants</div>
+                <table>
+                    {{- range .Merchants}}
+                    <tr><td>{{.Merchant}}</td><td class="muted">×{{.Count}}</td><td class="amount">{{eur .TotalEUR}}</td></tr>
+                    {{- else}}
+                    <tr><td class="muted">no merchants yet</td></tr>
+                    {{- end}}
+                </table>
+            </div>
+        </div>
+
+        <div class="recent">
+            <div class="section-title">Recent transactions</div>
+            <table>
+                <tr><th>Date</th><th>Merchant</th><th>Account</th><th class="amount">Amount</th><th>Category</th></tr>
+                {{- range .Transactions}}
+                <tr>
+                    <td class="muted">{{.Date}}</td>
+                    <td>{{.Merchant}}</td>
+                    <td class="muted">{{.Account}}</td>
+                    <td class="amount{{if .Income}} income{{end}}">{{.Amount}}</td>
+                    <td>
+                        {{- if .Split}}<span class="muted">split: {{.Category}}</span>
+                        {{- else}}
+                        <form class="recategorize" method="POST" action="{{$.RecategorizePath}}">
+                            <input type="hidden" name="id" value="{{.ID}}">
+                            <input type="text" name="category" value="{{.Category}}" list="categories">
+                            <button type="submit">save</button>
+                        </form>
+                        {{- end}}
+                    </td>
+                </tr>
+                {{- end}}
+            </table>
+            <datalist id="categories">
+                {{- range .KnownCategories}}<option value="{{.}}">{{- end}}
+            </datalist>
+        </div>
+    </div>
+</body>
+</html>`
+
+type CategoryView struct {
+	Category  string
+	TotalEUR  float64
+	ChangeEUR float64
+	WidthPct  int
+}
+
+type BudgetView struct {
+	Name         string
+	SpentEUR     float64
+	AvailableEUR float64
+	WidthPct     int
+	ElapsedPct   int
+	Over         bool
+	Warning      bool
+	Status       string
+}
+
+type TransactionView struct {
+	ID       int64
+	Date     string
+	Merchant string
+	Account  string
+	Amount   string
+	Income   bool
+	Category string
+	Split    bool
+}
+
+type DashboardData struct {
+	MonthLabel       string
+	TotalEUR         float64
+	LastMonthEUR     float64
+	ChangeEUR        float64
+	ChangePct        string
+	Message          string
+	Categories       []CategoryView
+	Budgets          []BudgetView
+	Merchants        []domain.MerchantSummary
+	Transactions     []TransactionView
+	KnownCategories  []string
+	RecategorizePath string
+	ExportPath       string
+}
+
+// DashboardGETHandler renders the money dashboard for the current month.
+// An optional ?date=YYYY-MM-DD shows the month of that day up to its end.
+func DashboardGETHandler(c *gin.Context) {
+	ctx := dashboardContext(c.Request.Context())
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		c.String(http.StatusInternalServerError, "Database not available")
+		return
+	}
+
+	at := time.Now().UTC()
+	if date := c.Query("date"); date != "" {
+		day, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			c.String(http.StatusBadRequest, "date must be YYYY-MM-DD")
+			return
+		}
+		at = day.AddDate(0, 0, 1).Add(-time.Microsecond)
+	}
+
+	data, err := buildDashboardData(ctx, db, at)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Failed to build dashboard data: %v", err)
+		return
+	}
+	data.Message = c.Query("message")
+	data.RecategorizePath = dashboardPath + "/recategorize"
+	data.ExportPath = dashboardPath + "/export"
+
+	funcMap := template.FuncMap{
+		"eur":    func(v float64) string { return fmt.Sprintf("€%.2f", v) },
+		"signed": func(v float64) string { return fmt.Sprintf("%+.2f", v) },
+	}
+	tmpl, err := template.New("money").Funcs(funcMap).Parse(htmlTemplate)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Template error: %v", err)
+		return
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		c.String(http.StatusInternalServerError, "Render error: %v", err)
+		return
+	}
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.String(http.StatusOK, buf.String())
+}
+
+// RecategorizePOSTHandler changes the category of one transaction through
+// edit_transactions, so the fix also trains import categorization, and
+// redirects back to the dashboard.
+func RecategorizePOSTHandler(c *gin.Context) {
+	ctx := dashboardContext(c.Request.Context())
+	if gateways.DBFromContext(ctx) == nil {
+		c.String(http.StatusInternalServerError, "Database not available")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.PostForm("id"), 10, 64)
+	category := strings.TrimSpace(c.PostForm("category"))
+	if err != nil || id == 0 || category == "" {
+		redirectWithMessage(c, "id and category are required")
+		return
+	}
+
+	_, out, err := edit_transactions.EditTransactions(ctx, nil, edit_transactions.EditTransactionsInput{
+		Updates: []edit_transactions.TransactionUpdate{{ID: id, Category: &category}},
+	})
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Failed to update transaction: %v", err)
+		return
+	}
+	if out.Error != "" {
+		redirectWithMessage(c, out.Error)
+		return
+	}
+	redirectWithMessage(c, fmt.Sprintf("✅ transaction %d moved to %s", id, category))
+}
+
+func redirectWithMessage(c *gin.Context, message string) {
+	c.Redirect(http.StatusSeeOther, dashboardPath+"?message="+url.QueryEscape(message))
+}
+
+// dashboardContext makes sure handlers called from the page see a user, the
+// same single-user fallback as the import page.
+func dashboardContext(ctx context.Context) context.Context {
+	if gateways.UserIDFromContext(ctx) != 0 {
+		return ctx
+	}
+	return gateways.WithUserID(ctx, defaultUserID)
+}
+
+func buildDashboardData(ctx context.Context, db gateways.DB, at time.Time) (DashboardData, error) {
+	userID := gateways.UserIDFromContext(ctx)
+	monthStart := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	// Last month over the same number of days, capped at its own end.
+	lastStart := monthStart.AddDate(0, -1, 0)
+	lastEnd := lastStart.Add(at.Sub(monthStart))
+	if !lastEnd.Before(monthStart) {
+		lastEnd = monthStart.Add(-time.Microsecond)
+	}
+
+	_, comparison, err := compare_periods.ComparePeriods(ctx, nil, compare_periods.ComparePeriodsInput{
+		PeriodAFrom: lastStart, PeriodATo: lastEnd,
+		PeriodBFrom: monthStart, PeriodBTo: at,
+	})
+	if err != nil {
+		return DashboardData{}, err
+	}
+
+	data := DashboardData{
+		MonthLabel:   monthStart.Format("January 2006"),
+		TotalEUR:     roundCents(comparison.PeriodB.TotalEUR),
+		LastMonthEUR: roundCents(comparison.PeriodA.TotalEUR),
+	}
+	data.ChangeEUR = roundCents(data.TotalEUR - data.LastMonthEUR)
+	if data.LastMonthEUR > 0 {
+		data.ChangePct = fmt.Sprintf("%+.0f%%", data.ChangeEUR/data.LastMonthEUR*100)
+	}
+	data.Categories = categoryViews(comparison.Diff)
+
+	_, budgets, err := get_budget_progress.GetBudgetProgress(ctx, nil, get_budget_progress.GetBudgetProgressInput{At: at})
+	if err != nil {
+		return DashboardData{}, err
+	}
+	for _, b := range budgets.Budgets {
+		data.Budgets = append(data.Budgets, budgetView(b))
+	}
+
+	data.Merchants, err = db.GetTopMerchants(ctx, userID, monthStart, at, topMerchantsCount)
+	if err != nil {
+		return DashboardData{}, fmt.Errorf("failed to get top merchants: %w", err)
+	}
+
+	txs, _, err := db.GetTransactions(ctx, domain.TransactionFilter{UserID: userID, To: &at, Limit: recentTransactions})
+	if err != nil {
+		return DashboardData{}, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	ids := make([]int64, len(txs))
+	for i, tx := range txs {
+		ids[i] = tx.ID
+	}
+	splits, err := db.ListTransactionSplits(ctx, userID, ids)
+	if err != nil {
+		return DashboardData{}, fmt.Errorf("failed to list splits: %w", err)
+	}
+	splitCategories := make(map[int64][]string)
+	for _, sp := range splits {
+		splitCategories[sp.TransactionID] = append(splitCategories[sp.TransactionID], sp.Category)
+	}
+
+	known := make(map[string]bool)
+	for _, tx := range txs {
+		view := TransactionView{
+			ID:       tx.ID,
+			Date:     tx.TransactedAt.UTC().Format("Jan 02"),
+			Merchant: tx.Merchant,
+			Account:  tx.Account,
+			Amount:   fmt.Sprintf("%.2f %s", tx.AmountOriginal, tx.Currency),
+			Income:   tx.Type == domain.TransactionTypeIncome,
+			Category: tx.Category,
+		}
+		if lines := splitCategories[tx.ID]; len(lines) > 0 {
+			view.Split = true
+			view.Category = strings.Join(lines, ", ")
+		}
+		data.Transactions = append(data.Transactions, view)
+		if tx.Category != "" {
+			known[tx.Category] = true
+		}
+	}
+	for category := range known {
+		data.KnownCategories = append(data.KnownCategories, category)
+	}
+	sort.Strings(data.KnownCategories)
+
+	return data, nil
+}
+
+// categoryViews keeps categories spent this month, largest first, with the
+// change against last month and bar widths relative to the largest.
+func categoryViews(diffs []compare_periods.CategoryDiff) []CategoryView {
+	var views []CategoryView
+	maxEUR := 0.0
+	for _, d := range diffs {
+		if d.PeriodBEUR <= 0 {
+			continue
+		}
+		views = append(views, CategoryView{Category: d.Category, TotalEUR: roundCents(d.PeriodBEUR), ChangeEUR: roundCents(d.DiffEUR)})
+		maxEUR = math.Max(maxEUR, d.PeriodBEUR)
+	}
+	sort.Slice(views, func(i, j int) bool {
+		if views[i].TotalEUR != views[j].TotalEUR {
+			return views[i].TotalEUR > views[j].TotalEUR
+		}
+		return views[i].Category < views[j].Category
+	})
+	for i := range views {
+		views[i].WidthPct = int(math.Round(views[i].TotalEUR / maxEUR * 100))
+	}
+	return views
+}
+
+func budgetView(b get_budget_progress.BudgetProgressRow) BudgetView {
+	available := b.AmountEUR + b.CarriedOverEUR
+	if available <= 0 {
+		available = b.AmountEUR
+	}
+	v := BudgetView{
+		Name:         b.Name,
+		SpentEUR:     b.SpentEUR,
+		AvailableEUR: roundCents(available),
+		WidthPct:     int(math.Min(100, math.Round(b.SpentEUR/available*100))),
+		ElapsedPct:   int(math.Round(b.Forecast.ElapsedPct)),
+		Over:         b.SpentEUR > available,
+		Warning:      !b.Forecast.OnPace,
+	}
+	switch {
+	case v.Over:
+		v.Status = fmt.Sprintf("over by €%.2f", b.SpentEUR-available)
+	case b.Forecast.ProjectedRemainingEUR < 0:
+		v.Status = fmt.Sprintf("on pace to overspend: projected €%.2f", b.Forecast.ProjectedSpendEUR)
+	default:
+		v.Status = fmt.Sprintf("€%.2f left", b.RemainingEUR)
+	}
+	return v
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
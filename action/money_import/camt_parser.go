@@ -0,0 +1,195 @@
+package money_import
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// ISO 20022 CAMT.053 parser
+// ---------------------------------------------------------------------------
+
+// CAMT053Parser parses ISO 20022 camt.053 bank-to-customer statements.
+// Namespaces are ignored, so camt.053.001.02 through .001.08+ all parse.
+//
+// Per <Ntry>: BookgDt is the booking date, ValDt the value date, Amt with
+// its Ccy attribute the amount, CdtDbtInd the direction, AcctSvcrRef (or
+// the first TxDtls reference) the bank transaction ID. Non-booked entries
+// (Sts other than BOOK) are skipped.
+type CAMT053Parser struct{}
+
+func (p *CAMT053Parser) Name() string { return "CAMT.053" }
+
+func (p *CAMT053Parser) Aliases() []string { return []string{"camt", "camt053", "iso20022"} }
+
+func (p *CAMT053Parser) HeaderSignature() []string { return nil }
+
+func (p *CAMT053Parser) DetectContent(data []byte) bool {
+	head := data[:min(len(data), 4096)]
+	return bytes.Contains(head, []byte("BkToCstmrStmt")) || bytes.Contains(head, []byte("camt.053"))
+}
+
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	AccountCurrency string      `xml:"Acct>Ccy"`
+	Entries         []camtEntry `xml:"Ntry"`
+}
+
+type camtEntry struct {
+	NtryRef     string       `xml:"NtryRef"`
+	Amount      camtAmount   `xml:"Amt"`
+	CdtDbtInd   string       `xml:"CdtDbtInd"`
+	Status      camtStatus   `xml:"Sts"`
+	BookingDate camtDate     `xml:"BookgDt"`
+	ValueDate   camtDate     `xml:"ValDt"`
+	AcctSvcrRef string       `xml:"AcctSvcrRef"`
+	AddtlInfo   string       `xml:"AddtlNtryInf"`
+	Details     []camtTxDtls `xml:"NtryDtls>TxDtls"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// camtStatus is plain text up to camt.053.001.07 and <Cd> afterwards.
+type camtStatus struct {
+	Code string `xml:"Cd"`
+	Text string `xml:",chardata"`
+}
+
+func (s camtStatus) value() string {
+	if s.Code != "" {
+		return strings.TrimSpace(s.Code)
+	}
+	return strings.TrimSpace(s.Text)
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	if d.DateTime != "" {
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05"} {
+			if t, err := time.Parse(layout, strings.TrimSpace(d.DateTime)); err == nil {
+				return t.UTC(), nil
+			}
+		}
+	}
+	return parseDate(d.Date)
+}
+
+type camtTxDtls struct {
+	AcctSvcrRef  string   `xml:"Refs>AcctSvcrRef"`
+	TxID         string   `xml:"Refs>TxId"`
+	EndToEndID   string   `xml:"Refs>EndToEndId"`
+	CreditorName string   `xml:"RltdPties>Cdtr>Nm"`
+	CreditorPty  string   `xml:"RltdPties>Cdtr>Pty>Nm"`
+	DebtorName   string   `xml:"RltdPties>Dbtr>Nm"`
+	DebtorPty    string   `xml:"RltdPties>Dbtr>Pty>Nm"`
+	Unstructured []string `xml:"RmtInf>Ustrd"`
+	AddtlTxInfo  string   `xml:"AddtlTxInf"`
+}
+
+func (p *CAMT053Parser) Parse(r io.Reader) ([]RawTransaction, error) {
+	var doc camtDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("camt.053: xml decode error: %w", err)
+	}
+
+	var result []RawTransaction
+	for _, stmt := range doc.Statements {
+		for _, e := range stmt.Entries {
+			if status := strings.ToUpper(e.Status.value()); status != "" && status != "BOOK" {
+				continue
+			}
+
+			date, err := e.BookingDate.parse()
+			if err != nil {
+				continue
+			}
+
+			amount, err := strconv.ParseFloat(strings.TrimSpace(e.Amount.Value), 64)
+			if err != nil || amount == 0 {
+				continue
+			}
+			if strings.EqualFold(strings.TrimSpace(e.CdtDbtInd), "DBIT") {
+				amount = -amount
+			}
+
+			var valueDate *time.Time
+			if vd, err := e.ValueDate.parse(); err == nil {
+				valueDate = &vd
+			}
+
+			currency := strings.ToUpper(strings.TrimSpace(e.Amount.Currency))
+			if currency == "" {
+				currency = strings.ToUpper(strings.TrimSpace(stmt.AccountCurrency))
+			}
+			if currency == "" {
+				currency = "EUR"
+			}
+
+			result = append(result, RawTransaction{
+				Date:        date,
+				ValueDate:   valueDate,
+				Description: e.description(amount < 0),
+				Amount:      amount,
+				Currency:    currency,
+				ExternalID:  e.externalID(),
+			})
+		}
+	}
+	return result, nil
+}
+
+// description prefers the counterparty name plus remittance text, falling
+// back to the free-text entry info.
+func (e camtEntry) description(isDebit bool) string {
+	if len(e.Details) > 0 {
+		d := e.Details[0]
+		counterparty := firstNonEmpty(d.DebtorName, d.DebtorPty)
+		if isDebit {
+			counterparty = firstNonEmpty(d.CreditorName, d.CreditorPty)
+		}
+		remittance := strings.Join(d.Unstructured, " ")
+		if desc := joinDescription(counterparty, firstNonEmpty(remittance, d.AddtlTxInfo)); desc != "" {
+			return desc
+		}
+	}
+	return strings.TrimSpace(e.AddtlInfo)
+}
+
+func (e camtEntry) externalID() string {
+	if id := strings.TrimSpace(e.AcctSvcrRef); id != "" {
+		return id
+	}
+	for _, d := range e.Details {
+		if id := firstNonEmpty(d.AcctSvcrRef, d.TxID); id != "" {
+			return id
+		}
+		if id := strings.TrimSpace(d.EndToEndID); id != "" && id != "NOTPROVIDED" {
+			return id
+		}
+	}
+	return strings.TrimSpace(e.NtryRef)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
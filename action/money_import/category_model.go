@@ -0,0 +1,223 @@
+package money_import
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"personal/domain"
+)
+
+// MinLearnedConfidence is the lowest confidence at which a learned suggestion
+// is applied automatically during import. Weaker guesses stay uncategorized.
+const MinLearnedConfidence = 0.5
+
+// minTokenEvidence is how many description tokens the winning class must have
+// seen before the classifier speaks up. A single shared token — usually a
+// city or a payment processor — is not enough to tell merchants apart.
+const minTokenEvidence = 2
+
+// CategoryModel learns categories from manual corrections. It has two tiers:
+// an exact merchant→category frequency table and a multinomial naive Bayes
+// classifier over tokens of the original bank description.
+type CategoryModel struct {
+	// merchant tier: lowercased merchant → category → count
+	merchants map[string]map[string]int
+
+	// description tier
+	docs        int
+	classDocs   map[string]int
+	tokenCounts map[string]map[string]int
+	classTokens map[string]int
+	vocabulary  map[string]struct{}
+}
+
+// NewCategoryModel trains a model from recorded corrections.
+func NewCategoryModel(corrections []domain.CategoryCorrection) *CategoryModel {
+	m := &CategoryModel{
+		merchants:   make(map[string]map[string]int),
+		classDocs:   make(map[string]int),
+		tokenCounts: make(map[string]map[string]int),
+		classTokens: make(map[string]int),
+		vocabulary:  make(map[string]struct{}),
+	}
+	for _, c := range corrections {
+		m.Learn(c.Merchant, c.OriginalDescription, c.Category)
+	}
+	return m
+}
+
+// Learn adds a single merchant/description → category example.
+func (m *CategoryModel) Learn(merchant, description, category string) {
+	if category == "" {
+		return
+	}
+
+	if key := merchantKey(merchant); key != "" {
+		if m.merchants[key] == nil {
+			m.merchants[key] = make(map[string]int)
+		}
+		m.merchants[key][category]++
+	}
+
+	tokens := tokenize(description)
+	if len(tokens) == 0 {
+		tokens = tokenize(merchant)
+	}
+	if len(tokens) == 0 {
+		return
+	}
+	m.docs++
+	m.classDocs[category]++
+	if m.tokenCounts[category] == nil {
+		m.tokenCounts[category] = make(map[string]int)
+	}
+	for _, t := range tokens {
+		m.tokenCounts[category][t]++
+		m.classTokens[category]++
+		m.vocabulary[t] = struct{}{}
+	}
+}
+
+// Suggest returns the best learned category for a transaction.
+// The merchant tier wins when the merchant has been corrected before;
+// otherwise the description classifier is used. ok is false when the model
+// has nothing to say.
+func (m *CategoryModel) Suggest(merchant, description string) (domain.CategorySuggestion, bool) {
+	if m == nil {
+		return domain.CategorySuggestion{}, false
+	}
+	if s, ok := m.suggestByMerchant(merchant); ok {
+		return s, true
+	}
+	return m.suggestByDescription(description)
+}
+
+// suggestByMerchant picks the most frequent category for the merchant.
+// Confidence is top/(total+1) so a single correction scores 0.5 and
+// consistent repeats approach 1.
+func (m *CategoryModel) suggestByMerchant(merchant string) (domain.CategorySuggestion, bool) {
+	counts := m.merchants[merchantKey(merchant)]
+	if len(counts) == 0 {
+		return domain.CategorySuggestion{}, false
+	}
+
+	best, bestCount, total := "", 0, 0
+	for cat, n := range counts {
+		total += n
+		if n > bestCount || (n == bestCount && cat < best) {
+			best, bestCount = cat, n
+		}
+	}
+	return domain.CategorySuggestion{
+		Category:   best,
+		Confidence: float64(bestCount) / float64(total+1),
+		Source:     domain.CategorySourceMerchant,
+	}, true
+}
+
+// suggestByDescription runs Laplace-smoothed naive Bayes. The posterior is
+// normalized only over learned classes, so with few classes it is close to 1
+// for anything; confidence is therefore scaled by the share of description
+// tokens the winning class has actually seen.
+func (m *CategoryModel) suggestByDescription(description string) (domain.CategorySuggestion, bool) {
+	if m.docs == 0 {
+		return domain.CategorySuggestion{}, false
+	}
+
+	// Only tokens seen in training carry evidence; without any the prior
+	// alone would just echo the most common category.
+	all := tokenize(description)
+	var tokens []string
+	for _, t := range all {
+		if _, ok := m.vocabulary[t]; ok {
+			tokens = append(tokens, t)
+		}
+	}
+	if len(tokens) == 0 {
+		return domain.CategorySuggestion{}, false
+	}
+
+	vocab := float64(len(m.vocabulary))
+	logPost := make(map[string]float64, len(m.classDocs))
+	maxLog := math.Inf(-1)
+	for cat, n := range m.classDocs {
+		lp := math.Log(float64(n) / float64(m.docs))
+		denom := float64(m.classTokens[cat]) + vocab
+		for _, t := range tokens {
+			lp += math.Log((float64(m.tokenCounts[cat][t]) + 1) / denom)
+		}
+		logPost[cat] = lp
+		if lp > maxLog {
+			maxLog = lp
+		}
+	}
+
+	best, bestP, sum := "", 0.0, 0.0
+	for cat, lp := range logPost {
+		p := math.Exp(lp - maxLog)
+		sum += p
+		if p > bestP || (p == bestP && cat < best) {
+			best, bestP = cat, p
+		}
+	}
+
+	matched := 0
+	for _, t := range all {
+		if m.tokenCounts[best][t] > 0 {
+			matched++
+		}
+	}
+	if matched < min(minTokenEvidence, len(all)) {
+		return domain.CategorySuggestion{}, false
+	}
+
+	return domain.CategorySuggestion{
+		Category:   best,
+		Confidence: bestP / sum * float64(matched) / float64(len(all)),
+		Source:     domain.CategorySourceDescription,
+	}, true
+}
+
+// SuggestCategory runs the static InferCategory rules first and falls back
+// to the learned model. Static rules are treated as fully confident.
+func SuggestCategory(model *CategoryModel, merchant, description string) (domain.CategorySuggestion, bool) {
+	if category := InferCategory(merchant, description); category != "" {
+		return domain.CategorySuggestion{
+			Category:   category,
+			Confidence: 1,
+			Source:     domain.CategorySourceRule,
+		}, true
+	}
+	return model.Suggest(merchant, description)
+}
+
+func merchantKey(merchant string) string {
+	return strings.ToLower(strings.TrimSpace(merchant))
+}
+
+// tokenize lowercases s and splits it into letter/digit runs, dropping
+// one-character tokens and pure numbers (card numbers, store IDs, dates).
+func tokenize(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := fields[:0]
+	for _, f := range fields {
+		if len([]rune(f)) < 2 || isDigits(f) {
+			continue
+		}
+		tokens = append(tokens, f)
+	}
+	return tokens
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
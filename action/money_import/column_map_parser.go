@@ -0,0 +1,276 @@
+package money_import
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"personal/domain"
+)
+
+// ---------------------------------------------------------------------------
+// Generic column-mapping parser
+// ---------------------------------------------------------------------------
+
+// ColumnMapParser parses any CSV export described by a saved ImportProfile:
+// which columns hold date, description, amount (signed, or debit/credit),
+// currency, plus date format, decimal style and sign convention.
+type ColumnMapParser struct {
+	Profile domain.ImportProfile
+}
+
+// NewColumnMapParser wraps a saved profile into a Parser.
+func NewColumnMapParser(profile domain.ImportProfile) *ColumnMapParser {
+	return &ColumnMapParser{Profile: profile}
+}
+
+// ValidateImportProfile checks that a profile describes a parseable format.
+func ValidateImportProfile(p domain.ImportProfile) error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if p.DateColumn == "" {
+		return fmt.Errorf("date_column is required")
+	}
+	if p.DescriptionColumn == "" {
+		return fmt.Errorf("description_column is required")
+	}
+	hasAmount := p.AmountColumn != ""
+	hasDebitCredit := p.DebitColumn != "" || p.CreditColumn != ""
+	if hasAmount == hasDebitCredit {
+		return fmt.Errorf("set either amount_column or debit_column/credit_column")
+	}
+	if p.Delimiter != "" && utf8.RuneCountInString(p.Delimiter) != 1 {
+		return fmt.Errorf("delimiter must be a single character")
+	}
+	switch p.DecimalStyle {
+	case "", domain.DecimalStyleDot, domain.DecimalStyleComma:
+	default:
+		return fmt.Errorf("decimal_style must be one of: dot, comma")
+	}
+	switch p.SignConvention {
+	case "", domain.SignNegativeIsExpense, domain.SignPositiveIsExpense:
+	default:
+		return fmt.Errorf("sign_convention must be one of: negative_is_expense, positive_is_expense")
+	}
+	if p.DefaultCurrency != "" && len(p.DefaultCurrency) != 3 {
+		return fmt.Errorf("default_currency must be exactly 3 characters (ISO 4217)")
+	}
+	return nil
+}
+
+func (p *ColumnMapParser) Name() string { return p.Profile.Name }
+
+func (p *ColumnMapParser) Aliases() []string { return p.Profile.Aliases }
+
+func (p *ColumnMapParser) HeaderSignature() []string {
+	sig := []string{p.Profile.DateColumn, p.Profile.DescriptionColumn}
+	for _, col := range []string{p.Profile.AmountColumn, p.Profile.DebitColumn, p.Profile.CreditColumn, p.Profile.CurrencyColumn} {
+		if col != "" {
+			sig = append(sig, col)
+		}
+	}
+	return sig
+}
+
+func (p *ColumnMapParser) Parse(r io.Reader) ([]RawTransaction, error) {
+	if err := ValidateImportProfile(p.Profile); err != nil {
+		return nil, fmt.Errorf("%s: invalid profile: %w", p.Profile.Name, err)
+	}
+
+	cr := csv.NewReader(skipBOM(r))
+	if p.Profile.Delimiter != "" {
+		cr.Comma, _ = utf8.DecodeRuneInString(p.Profile.Delimiter)
+	}
+	cr.FieldsPerRecord = -1 // metadata rows may differ in width
+	cr.LazyQuotes = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: csv read error: %w", p.Profile.Name, err)
+	}
+
+	// The header is the first row carrying the mapped columns.
+	headerIdx := -1
+	var idx map[string]int
+	for i, row := range records {
+		candidate := csvIndex(row)
+		if firstOf(candidate, p.Profile.DateColumn) >= 0 && firstOf(candidate, p.Profile.DescriptionColumn) >= 0 {
+			headerIdx, idx = i, candidate
+			break
+		}
+	}
+	if headerIdx < 0 {
+		return nil, nil
+	}
+
+	dateCol := firstOf(idx, p.Profile.DateColumn)
+	descCol := firstOf(idx, p.Profile.DescriptionColumn)
+	amtCol := firstOf(idx, p.Profile.AmountColumn)
+	debitCol := firstOf(idx, p.Profile.DebitColumn)
+	creditCol := firstOf(idx, p.Profile.CreditColumn)
+	curCol := firstOf(idx, p.Profile.CurrencyColumn)
+
+	layout := DateLayout(p.Profile.DateFormat)
+	defaultCurrency := p.Profile.DefaultCurrency
+	if defaultCurrency == "" {
+		defaultCurrency = "EUR"
+	}
+
+	var result []RawTransaction
+	for _, row := range records[headerIdx+1:] {
+		if len(row) == 0 {
+			continue
+		}
+
+		var date time.Time
+		if layout != "" {
+			date, err = time.Parse(layout, strings.TrimSpace(safeGet(row, dateCol)))
+			date = date.UTC()
+		} else {
+			date, err = parseDate(safeGet(row, dateCol))
+		}
+		if err != nil {
+			continue
+		}
+
+		var amount float64
+		if amtCol >= 0 {
+			v, err := ParseAmount(safeGet(row, amtCol), p.Profile.DecimalStyle)
+			if err != nil {
+				continue
+			}
+			amount = v
+			if p.Profile.SignConvention == domain.SignPositiveIsExpense {
+				amount = -amount
+			}
+		} else {
+			debit, _ := ParseAmount(safeGet(row, debitCol), p.Profile.DecimalStyle)
+			credit, _ := ParseAmount(safeGet(row, creditCol), p.Profile.DecimalStyle)
+			switch {
+			case debit != 0:
+				amount = -abs(debit)
+			case credit != 0:
+				amount = abs(credit)
+			}
+		}
+		if amount == 0 {
+			continue
+		}
+
+		currency := strings.ToUpper(strings.TrimSpace(safeGet(row, curCol)))
+		if currency == "" {
+			currency = defaultCurrency
+		}
+
+		result = append(result, RawTransaction{
+			Date:        date,
+			Description: strings.TrimSpace(safeGet(row, descCol)),
+			Amount:      amount,
+			Currency:    currency,
+		})
+	}
+	return result, nil
+}
+
+// ParseAmount parses a bank amount string in the given decimal style.
+// Handles thousands separators, currency symbols, spaces, "(12.00)" and
+// trailing-minus "12.00-" negatives. Empty input parses as 0.
+func ParseAmount(s string, style domain.DecimalStyle) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	if strings.HasSuffix(s, "-") {
+		negative = true
+		s = strings.TrimSuffix(s, "-")
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9', r == '.', r == ',':
+			b.WriteRune(r)
+		case r == '-' && b.Len() == 0:
+			negative = !negative
+		}
+	}
+	clean := b.String()
+
+	if style == domain.DecimalStyleComma {
+		clean = parseEuropeanAmount(clean)
+	} else {
+		clean = strings.ReplaceAll(clean, ",", "")
+	}
+	if clean == "" {
+		return 0, fmt.Errorf("cannot parse amount: %q", s)
+	}
+
+	v, err := strconv.ParseFloat(clean, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse amount: %q", s)
+	}
+	if negative {
+		v = -v
+	}
+	return v, nil
+}
+
+// dateTokens maps human date pattern tokens to Go layout parts,
+// longest tokens first so "YYYY" is not consumed as "YY".
+var dateTokens = []struct {
+	token  string
+	layout string
+}{
+	{"YYYY", "2006"},
+	{"YY", "06"},
+	{"MMM", "Jan"},
+	{"MM", "01"},
+	{"DD", "02"},
+	{"HH", "15"},
+	{"mm", "04"},
+	{"ss", "05"},
+}
+
+// DateLayout converts a pattern like "DD.MM.YYYY HH:mm" into a Go layout.
+// Strings that already are Go layouts pass through unchanged.
+func DateLayout(format string) string {
+	format = strings.TrimSpace(format)
+	if format == "" || strings.Contains(format, "2006") || strings.Contains(format, "06") {
+		return format
+	}
+	var b strings.Builder
+	for i := 0; i < len(format); {
+		matched := false
+		for _, t := range dateTokens {
+			if strings.HasPrefix(format[i:], t.token) {
+				b.WriteString(t.layout)
+				i += len(t.token)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(format[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -1,8 +1,11 @@
 package money_import
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"html/template"
+	"io"
 	"math"
 	"net/http"
 	"strings"
@@ -10,6 +13,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"personal/action/budget_alerts"
+	"personal/action/recurring"
+	"personal/action/transfer_pairs"
 	"personal/domain"
 	"personal/gateways"
 )
@@ -38,40 +44,101 @@ input[type=text], input[type=file], select {
 button { padding: 8px 20px; font-family: monospace; font-size: 13px; cursor: pointer; }
 .result { margin-top: 24px; padding: 12px; border: 1px solid #000; font-size: 13px; white-space: pre-wrap; }
 .error { border-color: red; color: red; }
+.warning { border-color: orange; color: #a60; }
 </style>
 </head>
 <body>
-<h1>💰 Bank CSV Import</h1>
+<h1>💰 Bank Statement Import</h1>
 <form method="POST" enctype="multipart/form-data">
-    <label>Account name:</label>
+    <label>Format:</label>
     <select name="account" required>
-        <option value="">— select account —</option>
-        <option value="revolut">Revolut</option>
-        <option value="bank of cyprus">Bank of Cyprus</option>
+        <option value="">— select format —</option>
+        <option value="{{.AutoDetect}}">Auto-detect from file</option>
+        {{range .Parsers}}<option value="{{.}}">{{.}}</option>
+        {{end}}
     </select>
 
-    <label>CSV file:</label>
-    <input type="file" name="file" accept=".csv" required>
+    <label>Account name (optional, defaults to format):</label>
+    <input type="text" name="account_name">
+
+    <label>Currency (optional, for formats without one such as QIF; defaults to the account's):</label>
+    <input type="text" name="currency" maxlength="3">
+
+    <label>Statement file (CSV, OFX/QFX, QIF, CAMT.053 XML):</label>
+    <input type="file" name="file" accept=".csv,.ofx,.qfx,.qif,.xml" required>
 
     <button type="submit">Import</button>
 </form>
 {{if .Message}}
 <div class="result{{if .IsError}} error{{end}}">{{.Message}}</div>
 {{end}}
+{{range .Warnings}}
+<div class="result warning">⚠️ {{.}}</div>
+{{end}}
 </body>
 </html>`
 
+// autoDetectAccount is the form value that picks the parser by file content.
+const autoDetectAccount = "auto"
+
 type importPageData struct {
-	Message string
-	IsError bool
+	Message    string
+	IsError    bool
+	Warnings   []string // follow-up failures after the rows were saved
+	AutoDetect string
+	Parsers    []string
+	status     int // HTTP status, 200 when unset
+}
+
+// userRegistry returns built-in parsers followed by the user's saved
+// column-map profiles.
+func userRegistry(ctx context.Context, db gateways.DB, userID int64) (*Registry, error) {
+	registry := NewRegistry(BuiltinParsers()...)
+	profiles, err := db.ListImportProfiles(ctx, userID)
+	if err != nil {
+		return registry, err
+	}
+	for _, p := range profiles {
+		registry.Register(NewColumnMapParser(p))
+	}
+	return registry, nil
+}
+
+// storedAccountName returns the spelling transactions of this account are
+// stored under: the registered account's name, otherwise the lowercased name
+// ("revolut", "bank of cyprus") imports have always used.
+func storedAccountName(name string, registered *domain.Account) string {
+	if registered != nil {
+		return registered.Name
+	}
+	return strings.ToLower(name)
+}
+
+// statementCurrency is the currency for rows whose format carries none
+// (QIF): the form's value, else the registered account's, else EUR.
+func statementCurrency(formValue string, registered *domain.Account) string {
+	if cur := strings.ToUpper(strings.TrimSpace(formValue)); cur != "" {
+		return cur
+	}
+	if registered != nil && registered.Currency != "" {
+		return registered.Currency
+	}
+	return "EUR"
 }
 
-// ImportGETHandler renders the CSV upload form.
+func importUserID(ctx context.Context) int64 {
+	if userID := gateways.UserIDFromContext(ctx); userID != 0 {
+		return userID
+	}
+	return defaultUserID
+}
+
+// ImportGETHandler renders the statement upload form.
 func ImportGETHandler(c *gin.Context) {
 	renderImportPage(c, importPageData{})
 }
 
-// ImportPOSTHandler processes the uploaded CSV file.
+// ImportPOSTHandler processes the uploaded statement file.
 func ImportPOSTHandler(c *gin.Context) {
 	db := gateways.DBFromContext(c.Request.Context())
 	if db == nil {
@@ -80,17 +147,13 @@ func ImportPOSTHandler(c *gin.Context) {
 	}
 
 	account := strings.TrimSpace(c.PostForm("account"))
-	if account == "" {
-		renderImportPage(c, importPageData{Message: "account name is required", IsError: true})
-		return
-	}
 
-	parser := ParserFor(account)
-	if parser == nil {
-		renderImportPage(c, importPageData{
-			Message: fmt.Sprintf("unknown account %q — supported: Revolut, Bank of Cyprus", account),
-			IsError: true,
-		})
+	ctx := c.Request.Context()
+	userID := importUserID(ctx)
+
+	registry, err := userRegistry(ctx, db, userID)
+	if err != nil {
+		renderImportPage(c, importPageData{Message: "database error: " + err.Error(), IsError: true})
 		return
 	}
 
@@ -107,8 +170,45 @@ func ImportPOSTHandler(c *gin.Context) {
 	}
 	defer file.Close()
 
-	// Stage 1 — parse CSV.
-	rawTxs, err := parser.Parse(file)
+	data, err := io.ReadAll(file)
+	if err != nil {
+		renderImportPage(c, importPageData{Message: "cannot read file: " + err.Error(), IsError: true})
+		return
+	}
+
+	// Stage 1 — pick the parser by name, or by file content when asked to.
+	var parser Parser
+	if account == "" || account == autoDetectAccount {
+		parser = registry.Detect(data)
+		if parser == nil {
+			renderImportPage(c, importPageData{
+				Message: "could not detect the file format — supported: " + strings.Join(registry.Names(), ", "),
+				IsError: true,
+			})
+			return
+		}
+	} else if parser = registry.Lookup(account); parser == nil {
+		renderImportPage(c, importPageData{
+			Message: fmt.Sprintf("unknown format %q — supported: %s", account, strings.Join(registry.Names(), ", ")),
+			IsError: true,
+			status:  http.StatusBadRequest,
+		})
+		return
+	}
+	if name := strings.TrimSpace(c.PostForm("account_name")); name != "" {
+		account = name
+	} else {
+		account = parser.Name()
+	}
+	registered, err := db.GetAccount(ctx, userID, account)
+	if err != nil {
+		renderImportPage(c, importPageData{Message: "database error: " + err.Error(), IsError: true})
+		return
+	}
+	account = storedAccountName(account, registered)
+	currency := statementCurrency(c.PostForm("currency"), registered)
+
+	rawTxs, err := parser.Parse(bytes.NewReader(data))
 	if err != nil {
 		renderImportPage(c, importPageData{Message: "parse error: " + err.Error(), IsError: true})
 		return
@@ -119,28 +219,67 @@ func ImportPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	// Learned fallback for stage 3, trained on manual re-categorizations.
+	corrections, err := db.ListCategoryCorrections(ctx, userID)
+	if err != nil {
+		renderImportPage(c, importPageData{Message: "database error: " + err.Error(), IsError: true})
+		return
+	}
+	model := NewCategoryModel(corrections)
+
+	// Bank transaction IDs already imported into this account.
+	seen, err := existingExternalIDs(ctx, db, userID, account, rawTxs)
+	if err != nil {
+		renderImportPage(c, importPageData{Message: "database error: " + err.Error(), IsError: true})
+		return
+	}
+
 	// Stages 2 & 3 — enrich and build domain transactions.
 	domainTxs := make([]*domain.Transaction, 0, len(rawTxs))
 	skipped := 0
+	duplicates := 0
+	learned := 0
 
 	for _, raw := range rawTxs {
 		if raw.Amount == 0 {
 			skipped++
 			continue
 		}
+		if raw.Currency == "" {
+			raw.Currency = currency
+		}
+
+		var externalID *string
+		if raw.ExternalID != "" {
+			if _, ok := seen[raw.ExternalID]; ok {
+				duplicates++
+				continue
+			}
+			seen[raw.ExternalID] = struct{}{}
+			externalID = &raw.ExternalID
+		}
 
 		// Stage 2: merchant recognition.
 		origDesc := raw.Description
 		merchant := RecognizeMerchant(origDesc)
 
-		// Stage 3: category inference.
-		category := InferCategory(merchant, origDesc)
-		if category == "" {
-			category = "uncategorized"
+		// Stage 3: category inference — static rules, then learned corrections.
+		category := "uncategorized"
+		if suggestion, ok := SuggestCategory(model, merchant, origDesc); ok {
+			if suggestion.Source == domain.CategorySourceRule {
+				category = suggestion.Category
+			} else if suggestion.Confidence >= MinLearnedConfidence {
+				category = suggestion.Category
+				learned++
+			}
 		}
 
-		// Determine type.
+		// Determine type and direction.
 		txType := domain.TransactionTypeExpense
+		direction := domain.DirectionOut
+		if raw.Amount > 0 {
+			direction = domain.DirectionIn
+		}
 		amt := math.Abs(raw.Amount)
 		if override := InferTypeOverride(raw.Description); override != "" {
 			txType = domain.TransactionType(override)
@@ -157,7 +296,7 @@ func ImportPOSTHandler(c *gin.Context) {
 		}
 
 		domainTxs = append(domainTxs, &domain.Transaction{
-			UserID:              defaultUserID,
+			UserID:              userID,
 			Type:                txType,
 			AmountOriginal:      amt,
 			Currency:            raw.Currency,
@@ -166,47 +305,100 @@ func ImportPOSTHandler(c *gin.Context) {
 			Category:            category,
 			Merchant:            merchant,
 			OriginalDescription: &origDesc,
+			ExternalID:          externalID,
+			Direction:           direction,
+			BalanceAfter:        raw.Balance,
 			TransactedAt:        raw.Date,
+			ValueDate:           raw.ValueDate,
 		})
 	}
 
 	if len(domainTxs) == 0 {
 		renderImportPage(c, importPageData{
-			Message: fmt.Sprintf("imported 0, skipped %d (no importable rows)", skipped),
+			Message: fmt.Sprintf("imported 0, skipped %d, duplicates %d (no importable rows)", skipped, duplicates),
 			IsError: true,
 		})
 		return
 	}
 
-	ctx := c.Request.Context()
-	userID := gateways.UserIDFromContext(ctx)
-	if userID == 0 {
-		userID = defaultUserID
-	}
-	// Rewrite user_id on all prepared transactions.
-	for _, tx := range domainTxs {
-		tx.UserID = userID
-	}
-
 	saved, err := db.AddTransactions(ctx, domainTxs)
 	if err != nil {
 		renderImportPage(c, importPageData{Message: "database error: " + err.Error(), IsError: true})
 		return
 	}
 
+	// Link top-ups and other moves between own accounts, within and across imports.
+	from, to := saved[0].TransactedAt, saved[0].TransactedAt
+	for _, tx := range saved {
+		if tx.TransactedAt.Before(from) {
+			from = tx.TransactedAt
+		}
+		if tx.TransactedAt.After(to) {
+			to = tx.TransactedAt
+		}
+	}
+
+	// The rows are saved at this point: follow-up failures are warnings, so
+	// the import is not mistaken for a failed one and repeated.
+	var warnings []string
+	paired, suggested, err := transfer_pairs.PairRange(ctx, db, userID, from, to, transfer_pairs.DefaultOptions)
+	if err != nil {
+		warnings = append(warnings, "transfer pairing failed: "+err.Error())
+	}
+
+	// Pairing runs first so top-ups are not mistaken for subscriptions.
+	recurringCount, err := recurring.Refresh(ctx, db, userID)
+	if err != nil {
+		warnings = append(warnings, "recurring detection failed: "+err.Error())
+	}
+
+	alerts, err := budget_alerts.Check(ctx, db, userID, time.Now().UTC())
+	if err != nil {
+		warnings = append(warnings, "budget alert check failed: "+err.Error())
+	}
+
 	renderImportPage(c, importPageData{
 		Message: fmt.Sprintf(
-			"✅ imported %d transactions, skipped %d\nlast imported: %s — %s (%.2f %s)",
-			len(saved), skipped,
+			"✅ imported %d transactions, skipped %d, duplicates %d, categorized from corrections %d, paired transfers %d (possible transfers to review with pair_transfers %d), recurring items %d, budget alerts %d\nlast imported: %s — %s (%.2f %s)",
+			len(saved), skipped, duplicates, learned, paired, suggested, recurringCount, len(alerts),
 			saved[len(saved)-1].TransactedAt.Format(time.DateOnly),
 			saved[len(saved)-1].Merchant,
 			saved[len(saved)-1].AmountOriginal,
 			saved[len(saved)-1].Currency,
 		),
+		Warnings: warnings,
 	})
 }
 
+// existingExternalIDs returns the bank transaction IDs from rawTxs that are
+// already stored for the account.
+func existingExternalIDs(ctx context.Context, db gateways.DB, userID int64, account string, rawTxs []RawTransaction) (map[string]struct{}, error) {
+	var ids []string
+	for _, raw := range rawTxs {
+		if raw.ExternalID != "" {
+			ids = append(ids, raw.ExternalID)
+		}
+	}
+	existing, err := db.ListExternalIDs(ctx, userID, account, ids)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range existing {
+		seen[id] = struct{}{}
+	}
+	return seen, nil
+}
+
 func renderImportPage(c *gin.Context, data importPageData) {
+	data.AutoDetect = autoDetectAccount
+	registry := NewRegistry(BuiltinParsers()...)
+	if db := gateways.DBFromContext(c.Request.Context()); db != nil {
+		// Saved profiles are optional here; on error the form lists built-ins only.
+		registry, _ = userRegistry(c.Request.Context(), db, importUserID(c.Request.Context()))
+	}
+	data.Parsers = registry.Names()
+
 	tmpl, err := template.New("import").Parse(importFormHTML)
 	if err != nil {
 		c.String(http.StatusInternalServerError, "template error: %v", err)
@@ -220,5 +412,9 @@ func renderImportPage(c *gin.Context, data importPageData) {
 	}
 
 	c.Header("Content-Type", "text/html; charset=utf-8")
-	c.String(http.StatusOK, buf.String())
+	status := http.StatusOK
+	if data.status != 0 {
+		status = data.status
+	}
+	c.String(status, buf.String())
 }
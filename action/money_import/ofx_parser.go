@@ -0,0 +1,180 @@
+package money_import
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// OFX / QFX parser
+// ---------------------------------------------------------------------------
+
+// OFXParser parses OFX 1.x (SGML, unclosed leaf tags) and OFX 2.x (XML)
+// bank statements. QFX is OFX with Quicken headers and parses the same way.
+//
+// Per <STMTTRN>: DTPOSTED is the booking date, DTAVAIL the value date,
+// TRNAMT the signed amount, FITID the bank transaction ID, NAME/MEMO the
+// description. Currency comes from the transaction CURRENCY/ORIGCURRENCY
+// aggregate or the statement-level CURDEF.
+type OFXParser struct{}
+
+func (p *OFXParser) Name() string { return "OFX" }
+
+func (p *OFXParser) Aliases() []string { return []string{"qfx"} }
+
+func (p *OFXParser) HeaderSignature() []string { return nil }
+
+func (p *OFXParser) DetectContent(data []byte) bool {
+	head := bytes.ToUpper(data[:min(len(data), 4096)])
+	return bytes.Contains(head, []byte("OFXHEADER")) || bytes.Contains(head, []byte("<OFX>"))
+}
+
+// ofxTag matches "<TAG>text", "</TAG>" and "<TAG/>" tokens.
+var ofxTag = regexp.MustCompile(`<(/?)([A-Za-z0-9.]+)\s*/?>([^<]*)`)
+
+func (p *OFXParser) Parse(r io.Reader) ([]RawTransaction, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("ofx: read error: %w", err)
+	}
+
+	type ofxTxn struct {
+		fields   map[string]string
+		currency string
+	}
+
+	var (
+		curDef  string
+		txns    []ofxTxn
+		current *ofxTxn
+		inCur   bool // inside <CURRENCY> or <ORIGCURRENCY> of the current txn
+	)
+
+	for _, m := range ofxTag.FindAllSubmatch(data, -1) {
+		closing := len(m[1]) > 0
+		tag := strings.ToUpper(string(m[2]))
+		text := html.UnescapeString(strings.TrimSpace(string(m[3])))
+
+		switch {
+		case tag == "STMTTRN" && !closing:
+			txns = append(txns, ofxTxn{fields: map[string]string{}})
+			current = &txns[len(txns)-1]
+		case tag == "STMTTRN" && closing:
+			current, inCur = nil, false
+		case (tag == "CURRENCY" || tag == "ORIGCURRENCY") && current != nil:
+			inCur = !closing
+		case tag == "CURDEF" && !closing && text != "":
+			curDef = strings.ToUpper(text)
+		case current != nil && !closing && text != "":
+			if inCur && tag == "CURSYM" {
+				current.currency = strings.ToUpper(text)
+			} else {
+				current.fields[tag] = text
+			}
+		}
+	}
+
+	var result []RawTransaction
+	for _, t := range txns {
+		date, err := parseOFXDate(t.fields["DTPOSTED"])
+		if err != nil {
+			continue
+		}
+
+		amount, err := parseOFXAmount(t.fields["TRNAMT"])
+		if err != nil || amount == 0 {
+			continue
+		}
+
+		var valueDate *time.Time
+		if vd, err := parseOFXDate(t.fields["DTAVAIL"]); err == nil {
+			valueDate = &vd
+		}
+
+		currency := t.currency
+		if currency == "" {
+			currency = curDef
+		}
+		if currency == "" {
+			currency = "EUR"
+		}
+
+		result = append(result, RawTransaction{
+			Date:        date,
+			ValueDate:   valueDate,
+			Description: joinDescription(t.fields["NAME"], t.fields["MEMO"]),
+			Amount:      amount,
+			Currency:    currency,
+			ExternalID:  t.fields["FITID"],
+		})
+	}
+	return result, nil
+}
+
+// parseOFXDate parses "YYYYMMDD[HHMMSS[.XXX]][[-5:EST]]". The optional
+// bracketed offset is honoured; without it the time is taken as UTC.
+func parseOFXDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 8 {
+		return time.Time{}, fmt.Errorf("cannot parse ofx date: %q", s)
+	}
+
+	offset := 0
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		tz := strings.TrimSuffix(s[i+1:], "]")
+		if j := strings.IndexByte(tz, ':'); j >= 0 {
+			tz = tz[:j]
+		}
+		if hours, err := strconv.ParseFloat(tz, 64); err == nil {
+			offset = int(hours * 3600)
+		}
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s = s[:i]
+	}
+
+	layout := "20060102"
+	if len(s) >= 14 {
+		s, layout = s[:14], "20060102150405"
+	} else {
+		s = s[:8]
+	}
+
+	t, err := time.ParseInLocation(layout, s, time.FixedZone("", offset))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse ofx date: %q", s)
+	}
+	return t.UTC(), nil
+}
+
+// parseOFXAmount parses TRNAMT; some European banks use a decimal comma.
+func parseOFXAmount(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, ".") {
+		s = strings.ReplaceAll(s, ",", ".")
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// joinDescription combines a payee name and a memo, skipping duplicates.
+func joinDescription(name, memo string) string {
+	name, memo = strings.TrimSpace(name), strings.TrimSpace(memo)
+	switch {
+	case name == "":
+		return memo
+	case memo == "" || strings.EqualFold(name, memo) || strings.Contains(strings.ToLower(memo), strings.ToLower(name)):
+		if len(memo) > len(name) {
+			return memo
+		}
+		return name
+	default:
+		return name + " " + memo
+	}
+}
@@ -5,35 +5,38 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// RawTransaction is the normalized output of any account-specific CSV parser.
+// RawTransaction is the normalized output of any account-specific parser.
 type RawTransaction struct {
-	Date        time.Time
+	Date        time.Time  // booking date
+	ValueDate   *time.Time // value date, when the format provides one
 	Description string
 	Amount      float64 // positive = income, negative = expense
 	Currency    string
+	ExternalID  string   // bank-provided (or, for QIF, derived) transaction ID, used for dedup; empty if none
+	Balance     *float64 // account balance after this row, when the export has one
 }
 
 // Parser parses a bank CSV export into raw transactions.
 type Parser interface {
+	// Name is the display name, also used as the account name on auto-detect.
+	Name() string
+	// Aliases are alternative names accepted by Registry.Lookup.
+	Aliases() []string
+	// HeaderSignature lists the header columns that identify the format.
+	HeaderSignature() []string
 	Parse(r io.Reader) ([]RawTransaction, error)
 }
 
-// ParserFor returns the parser for the given account name.
+// ParserFor returns the built-in parser for the given account name.
 // Returns nil if account is unknown.
 func ParserFor(account string) Parser {
-	switch strings.ToLower(strings.TrimSpace(account)) {
-	case "revolut":
-		return &RevolutParser{}
-	case "bank of cyprus", "bankofcyprus", "boc":
-		return &BankOfCyprusParser{}
-	default:
-		return nil
-	}
+	return NewRegistry(BuiltinParsers()...).Lookup(account)
 }
 
 // ---------------------------------------------------------------------------
@@ -43,8 +46,18 @@ func ParserFor(account string) Parser {
 // RevolutParser parses Revolut CSV exports.
 // Expected columns (v10+):
 // Type,Product,Started Date,Completed Date,Description,Amount,Fee,Currency,State,Balance
+// Amount excludes the fee while Balance is after it, so the fee is added to
+// the amount charged.
 type RevolutParser struct{}
 
+func (p *RevolutParser) Name() string { return "Revolut" }
+
+func (p *RevolutParser) Aliases() []string { return nil }
+
+func (p *RevolutParser) HeaderSignature() []string {
+	return []string{"Started Date", "Completed Date", "Description", "Amount", "Currency", "State"}
+}
+
 func (p *RevolutParser) Parse(r io.Reader) ([]RawTransaction, error) {
 	records, err := csv.NewReader(r).ReadAll()
 	if err != nil {
@@ -59,9 +72,11 @@ func (p *RevolutParser) Parse(r io.Reader) ([]RawTransaction, error) {
 
 	descCol := firstOf(idx, "Description")
 	amtCol := firstOf(idx, "Amount")
+	feeCol := firstOf(idx, "Fee")
 	curCol := firstOf(idx, "Currency")
 	dateCol := firstOf(idx, "Completed Date", "Started Date")
 	stateCol := firstOf(idx, "State")
+	balanceCol := firstOf(idx, "Balance")
 
 	var result []RawTransaction
 	for _, row := range records[1:] {
@@ -86,15 +101,24 @@ func (p *RevolutParser) Parse(r io.Reader) ([]RawTransaction, error) {
 		if err != nil {
 			continue
 		}
+		if fee, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(safeGet(row, feeCol)), ",", ""), 64); err == nil {
+			amt = math.Round((amt-math.Abs(fee))*100) / 100
+		}
 		if amt == 0 {
 			continue
 		}
 
+		var balance *float64
+		if v, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(safeGet(row, balanceCol)), ",", ""), 64); err == nil {
+			balance = &v
+		}
+
 		result = append(result, RawTransaction{
 			Date:        date,
 			Description: strings.TrimSpace(safeGet(row, descCol)),
 			Amount:      amt,
 			Currency:    strings.TrimSpace(safeGet(row, curCol)),
+			Balance:     balance,
 		})
 	}
 	return result, nil
@@ -109,14 +133,17 @@ func (p *RevolutParser) Parse(r io.Reader) ([]RawTransaction, error) {
 // Date,Description,Debit,Credit,Currency,Balance
 type BankOfCyprusParser struct{}
 
+func (p *BankOfCyprusParser) Name() string { return "Bank of Cyprus" }
+
+func (p *BankOfCyprusParser) Aliases() []string { return []string{"bankofcyprus", "boc"} }
+
+func (p *BankOfCyprusParser) HeaderSignature() []string {
+	return []string{"Date", "Description", "Debit", "Credit"}
+}
+
 func (p *BankOfCyprusParser) Parse(r io.Reader) ([]RawTransaction, error) {
 	// Strip UTF-8 BOM present in BOC exports.
-	br := bufio.NewReader(r)
-	if bom, _ := br.Peek(3); len(bom) == 3 && bom[0] == 0xEF && bom[1] == 0xBB && bom[2] == 0xBF {
-		_, _ = br.Discard(3)
-	}
-
-	records, err := csv.NewReader(br).ReadAll()
+	records, err := csv.NewReader(skipBOM(r)).ReadAll()
 	if err != nil {
 		return nil, fmt.Errorf("boc: csv read error: %w", err)
 	}
@@ -141,6 +168,7 @@ func (p *BankOfCyprusParser) Parse(r io.Reader) ([]RawTransaction, error) {
 	creditCol := firstOf(idx, "Credit")
 	curCol := firstOf(idx, "Currency")
 	dateCol := firstOf(idx, "Date", "Value Date", "Transaction Date")
+	balanceCol := firstOf(idx, "Balance")
 
 	var result []RawTransaction
 	for _, row := range records[headerIdx+1:] {
@@ -179,11 +207,17 @@ func (p *BankOfCyprusParser) Parse(r io.Reader) ([]RawTransaction, error) {
 			currency = "EUR"
 		}
 
+		var balance *float64
+		if v, err := strconv.ParseFloat(parseEuropeanAmount(safeGet(row, balanceCol)), 64); err == nil {
+			balance = &v
+		}
+
 		result = append(result, RawTransaction{
 			Date:        date,
 			Description: strings.TrimSpace(safeGet(row, descCol)),
 			Amount:      amount,
 			Currency:    currency,
+			Balance:     balance,
 		})
 	}
 	return result, nil
@@ -503,6 +537,15 @@ func InferCategory(merchant, description string) string {
 // Helpers
 // ---------------------------------------------------------------------------
 
+// skipBOM drops a leading UTF-8 byte order mark.
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(3); len(bom) == 3 && bom[0] == 0xEF && bom[1] == 0xBB && bom[2] == 0xBF {
+		_, _ = br.Discard(3)
+	}
+	return br
+}
+
 func csvIndex(header []string) map[string]int {
 	m := make(map[string]int, len(header))
 	for i, h := range header {
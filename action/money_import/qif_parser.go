@@ -0,0 +1,185 @@
+package money_import
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// QIF parser
+// ---------------------------------------------------------------------------
+
+// QIFParser parses Quicken Interchange Format bank exports.
+//
+// Records are "^"-terminated groups of lines: D date, T/U amount, P payee,
+// M memo. The N field is a check or reference number that banks reuse
+// ("ATM", "DEP", "1001"), so it is not a transaction ID; instead the ID is
+// derived from date, amount and payee plus an ordinal for same-day repeats,
+// which keeps re-imports of the same file idempotent. QIF has no
+// currency field: Currency applies to the whole file, and when empty the
+// importer fills in the account's currency. The decimal separator is
+// detected per amount, so "1,234.56" and "1.234,56" both work.
+// Dates are ambiguous (MM/DD vs DD/MM): the order is inferred from the file
+// and falls back to day-first, as exported by European banks.
+type QIFParser struct {
+	Currency string
+}
+
+func (p *QIFParser) Name() string { return "QIF" }
+
+func (p *QIFParser) Aliases() []string { return []string{"quicken"} }
+
+func (p *QIFParser) HeaderSignature() []string { return nil }
+
+func (p *QIFParser) DetectContent(data []byte) bool {
+	head := bytes.TrimSpace(data[:min(len(data), 256)])
+	return bytes.HasPrefix(bytes.ToUpper(head), []byte("!TYPE:"))
+}
+
+type qifRecord struct {
+	date   [3]int // as written: first, second, year
+	amount string
+	payee  string
+	memo   string
+}
+
+func (p *QIFParser) Parse(r io.Reader) ([]RawTransaction, error) {
+	var (
+		records []qifRecord
+		current qifRecord
+		hasData bool
+	)
+
+	sc := bufio.NewScanner(skipBOM(r))
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		code, value := line[0], strings.TrimSpace(line[1:])
+		switch code {
+		case '!':
+			// Section header (!Type:Bank, !Account, ...).
+		case '^':
+			if hasData {
+				records = append(records, current)
+			}
+			current, hasData = qifRecord{}, false
+		case 'D':
+			if d, ok := splitQIFDate(value); ok {
+				current.date, hasData = d, true
+			}
+		case 'T', 'U':
+			current.amount, hasData = value, true
+		case 'P':
+			current.payee, hasData = value, true
+		case 'M':
+			current.memo, hasData = value, true
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("qif: read error: %w", err)
+	}
+	if hasData {
+		records = append(records, current)
+	}
+
+	dayFirst := qifDayFirst(records)
+
+	var result []RawTransaction
+	ordinals := make(map[string]int)
+	for _, rec := range records {
+		day, month := rec.date[0], rec.date[1]
+		if !dayFirst {
+			day, month = month, day
+		}
+		if day < 1 || day > 31 || month < 1 || month > 12 {
+			continue
+		}
+		date := time.Date(rec.date[2], time.Month(month), day, 0, 0, 0, 0, time.UTC)
+
+		amount, err := parseQIFAmount(rec.amount)
+		if err != nil || amount == 0 {
+			continue
+		}
+
+		key := fmt.Sprintf("qif:%s:%.2f:%s", date.Format("2006-01-02"), amount, strings.ToLower(rec.payee))
+		ordinals[key]++
+
+		result = append(result, RawTransaction{
+			Date:        date,
+			Description: joinDescription(rec.payee, rec.memo),
+			Amount:      amount,
+			Currency:    p.Currency,
+			ExternalID:  fmt.Sprintf("%s:%d", key, ordinals[key]),
+		})
+	}
+	return result, nil
+}
+
+// parseQIFAmount parses an amount in either notation. The last separator is
+// the decimal one when both appear; a lone separator repeated is a thousands
+// separator, otherwise it is decimal ("12,50", "-3.5").
+func parseQIFAmount(s string) (float64, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case dot >= 0 && comma >= 0:
+		if comma > dot {
+			s = strings.ReplaceAll(s, ".", "")
+			s = strings.ReplaceAll(s, ",", ".")
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	case comma >= 0:
+		if strings.Count(s, ",") > 1 {
+			s = strings.ReplaceAll(s, ",", "")
+		} else {
+			s = strings.ReplaceAll(s, ",", ".")
+		}
+	case strings.Count(s, ".") > 1:
+		s = strings.ReplaceAll(s, ".", "")
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// splitQIFDate splits "04/05/2026", "4/5'26", "04-05-2026" or "04.05.26"
+// into its numeric parts, expanding two-digit years.
+func splitQIFDate(s string) ([3]int, bool) {
+	s = strings.NewReplacer("'", "/", "-", "/", ".", "/", " ", "").Replace(s)
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 {
+		return [3]int{}, false
+	}
+	var d [3]int
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return [3]int{}, false
+		}
+		d[i] = v
+	}
+	if d[2] < 100 {
+		d[2] += 2000
+	}
+	return d, true
+}
+
+// qifDayFirst infers date order: a first part above 12 proves day-first,
+// a second part above 12 proves month-first. Defaults to day-first.
+func qifDayFirst(records []qifRecord) bool {
+	for _, rec := range records {
+		if rec.date[0] > 12 {
+			return true
+		}
+		if rec.date[1] > 12 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+package money_import
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"strings"
+)
+
+// detectScanLines is how many leading lines are searched for a header row.
+// Real BOC exports carry ~5 metadata rows before the header.
+const detectScanLines = 20
+
+// detectDelimiters are tried on every candidate header line.
+var detectDelimiters = []rune{',', ';', '\t'}
+
+// BuiltinParsers returns the parsers shipped with the code base.
+func BuiltinParsers() []Parser {
+	return []Parser{
+		&RevolutParser{},
+		&BankOfCyprusParser{},
+		&OFXParser{},
+		&QIFParser{},
+		&CAMT053Parser{},
+	}
+}
+
+// ContentDetector is implemented by parsers of non-CSV formats that
+// recognize files by content instead of a header row.
+type ContentDetector interface {
+	DetectContent(data []byte) bool
+}
+
+// Registry holds available parsers and resolves them by name or by
+// CSV header signature.
+type Registry struct {
+	parsers []Parser
+}
+
+// NewRegistry creates a registry with the given parsers in priority order.
+func NewRegistry(parsers ...Parser) *Registry {
+	r := &Registry{}
+	for _, p := range parsers {
+		r.Register(p)
+	}
+	return r
+}
+
+// Register adds a parser. Earlier parsers win name and detection ties.
+func (r *Registry) Register(p Parser) {
+	r.parsers = append(r.parsers, p)
+}
+
+// Parsers returns registered parsers in registration order.
+func (r *Registry) Parsers() []Parser {
+	return r.parsers
+}
+
+// Names returns the display names of registered parsers.
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.parsers))
+	for i, p := range r.parsers {
+		names[i] = p.Name()
+	}
+	return names
+}
+
+// Lookup returns the parser whose name or alias matches account,
+// case-insensitively. Returns nil if none matches.
+func (r *Registry) Lookup(account string) Parser {
+	key := normalizeParserName(account)
+	if key == "" {
+		return nil
+	}
+	for _, p := range r.parsers {
+		if normalizeParserName(p.Name()) == key {
+			return p
+		}
+		for _, alias := range p.Aliases() {
+			if normalizeParserName(alias) == key {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// Detect finds the parser for data. Content detectors (OFX, QIF, CAMT)
+// are asked first; otherwise the parser whose header signature appears in
+// one of the first lines wins, the longest signature on ties.
+// Returns nil if no parser recognizes the file.
+func (r *Registry) Detect(data []byte) Parser {
+	data = bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})
+
+	for _, p := range r.parsers {
+		if cd, ok := p.(ContentDetector); ok && cd.DetectContent(data) {
+			return p
+		}
+	}
+
+	var headers []map[string]struct{}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for i := 0; i < detectScanLines && sc.Scan(); i++ {
+		for _, delim := range detectDelimiters {
+			if cells := splitHeaderLine(sc.Text(), delim); len(cells) > 1 {
+				headers = append(headers, cells)
+			}
+		}
+	}
+
+	var best Parser
+	bestLen := 0
+	for _, p := range r.parsers {
+		sig := p.HeaderSignature()
+		if len(sig) <= bestLen {
+			continue
+		}
+		for _, h := range headers {
+			if hasColumns(h, sig) {
+				best, bestLen = p, len(sig)
+				break
+			}
+		}
+	}
+	return best
+}
+
+func splitHeaderLine(line string, delim rune) map[string]struct{} {
+	cr := csv.NewReader(strings.NewReader(line))
+	cr.Comma = delim
+	cr.LazyQuotes = true
+	cells, err := cr.Read()
+	if err != nil {
+		return nil
+	}
+	set := make(map[string]struct{}, len(cells))
+	for _, c := range cells {
+		if c = strings.ToLower(strings.TrimSpace(c)); c != "" {
+			set[c] = struct{}{}
+		}
+	}
+	return set
+}
+
+func hasColumns(header map[string]struct{}, signature []string) bool {
+	if len(signature) == 0 {
+		return false
+	}
+	for _, col := range signature {
+		if _, ok := header[strings.ToLower(strings.TrimSpace(col))]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeParserName(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,22 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+// Deliver sends n and records the outcome; only a failure to record is returned.
+func Deliver(ctx context.Context, db gateways.DB, sink gateways.NotificationSink, n *domain.Notification) error {
+	if sendErr := sink.Send(ctx, *n); sendErr != nil {
+		msg := sendErr.Error()
+		n.DeliveryError = &msg
+	} else {
+		now := time.Now().UTC()
+		n.DeliveredAt = &now
+		n.DeliveryError = nil
+	}
+	return db.SetNotificationDelivery(ctx, n.ID, n.DeliveredAt, n.DeliveryError)
+}
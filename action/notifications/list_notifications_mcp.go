@@ -0,0 +1,62 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+var ListNotificationsMCPDefinition = mcp.Tool{
+	Name: "list_notifications",
+	Description: "Notification inbox, newest first: budget alerts and other events raised in the background. " +
+		"Poll with unread_only and acknowledge with mark_notifications_read.",
+}
+
+// ListNotificationsInput is the MCP tool input.
+type ListNotificationsInput struct {
+	UnreadOnly bool `json:"unread_only,omitempty" jsonschema:"Only notifications not marked read"`
+	Limit      int  `json:"limit,omitempty" jsonschema:"Max notifications (default 50, max 200)"`
+}
+
+// ListNotificationsOutput is the MCP tool output.
+type ListNotificationsOutput struct {
+	Notifications []domain.Notification `json:"notifications"`
+	Error         string                `json:"error,omitempty"`
+}
+
+func ListNotifications(ctx context.Context, _ *mcp.CallToolRequest, input ListNotificationsInput) (*mcp.CallToolResult, ListNotificationsOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, ListNotificationsOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, ListNotificationsOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	list, err := db.ListNotifications(ctx, userID, input.UnreadOnly, limit)
+	if err != nil {
+		return nil, ListNotificationsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	if list == nil {
+		list = []domain.Notification{}
+	}
+	return nil, ListNotificationsOutput{Notifications: list}, nil
+}
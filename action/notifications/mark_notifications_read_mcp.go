@@ -0,0 +1,46 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+)
+
+var MarkNotificationsReadMCPDefinition = mcp.Tool{
+	Name:        "mark_notifications_read",
+	Description: "Acknowledge notifications so unread_only polls skip them. Without ids marks every unread notification.",
+}
+
+// MarkNotificationsReadInput is the MCP tool input.
+type MarkNotificationsReadInput struct {
+	IDs []int64 `json:"ids,omitempty" jsonschema:"Notification IDs; empty marks all unread"`
+}
+
+// MarkNotificationsReadOutput is the MCP tool output.
+type MarkNotificationsReadOutput struct {
+	MarkedCount int `json:"marked_count"`
+}
+
+func MarkNotificationsRead(ctx context.Context, _ *mcp.CallToolRequest, input MarkNotificationsReadInput) (*mcp.CallToolResult, MarkNotificationsReadOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, MarkNotificationsReadOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, MarkNotificationsReadOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	ids := input.IDs
+	if ids == nil {
+		ids = []int64{}
+	}
+	count, err := db.MarkNotificationsRead(ctx, userID, ids)
+	if err != nil {
+		return nil, MarkNotificationsReadOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	return nil, MarkNotificationsReadOutput{MarkedCount: count}, nil
+}
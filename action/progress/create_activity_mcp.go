@@ -3,7 +3,9 @@ package progress
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
@@ -31,36 +33,59 @@ Optional inputs:
 - description: Brief description of the activity
 - life_part_ids: Array of life area IDs this belongs to
 - started_at: When tracking started (ISO8601, defaults to now)
+- scale_id: Custom value scale from save_progress_scale (defaults to the -2..+2 scale of progress_type)
+- unit: Unit of a measured quantity, e.g. "pages", "ml", "km" (points then carry a quantity)
+- target: Quantity to reach per target period, e.g. 140 (pages per week)
+- target_days: Target period in days (defaults to frequency_days)
+- parent_id: Parent activity, to break a big project into sub-activities
 
 Example:
 User: "I want to track my user outreach project"
-You: [Call create_activity(name="User Outreach", progress_type="project_progress", frequency_days=1)]`,
+You: [Call create_activity(name="User Outreach", progress_type="project_progress", frequency_days=1)]
+
+User: "I want to read 140 pages a week, ask me daily"
+You: [Call create_activity(name="Reading", progress_type="habit_progress", frequency_days=1, unit="pages", target=140, target_days=7)]
+
+User: "Writing the literature review is part of my thesis"
+You: [Call create_activity(name="Literature review", progress_type="project_progress", frequency_days=7, parent_id=<thesis id>)]`,
 }
 
 type CreateActivityInput struct {
-	Name          string  `json:"name" jsonschema:"Activity name"`
-	ProgressType  string  `json:"progress_type" jsonschema:"Progress type: mood|habit_progress|project_progress|promise_state"`
-	FrequencyDays int     `json:"frequency_days" jsonschema:"Check-in frequency in days (1 = daily, 7 = weekly)"`
-	Description   string  `json:"description,omitempty" jsonschema:"Activity description"`
-	LifePartIDs   []int64 `json:"life_part_ids,omitempty" jsonschema:"Life area IDs this activity belongs to"`
-	StartedAt     string  `json:"started_at,omitempty" jsonschema:"When tracking started (ISO8601, defaults to now)"`
+	Name          string   `json:"name" jsonschema:"Activity name"`
+	ProgressType  string   `json:"progress_type" jsonschema:"Progress type: mood|habit_progress|project_progress|promise_state"`
+	FrequencyDays int      `json:"frequency_days" jsonschema:"Check-in frequency in days (1 = daily, 7 = weekly)"`
+	Description   string   `json:"description,omitempty" jsonschema:"Activity description"`
+	LifePartIDs   []int64  `json:"life_part_ids,omitempty" jsonschema:"Life area IDs this activity belongs to"`
+	StartedAt     string   `json:"started_at,omitempty" jsonschema:"When tracking started (ISO8601, defaults to now)"`
+	ScaleID       *int64   `json:"scale_id,omitempty" jsonschema:"Custom progress scale ID (omit for the built-in scale of progress_type)"`
+	Unit          string   `json:"unit,omitempty" jsonschema:"Unit of the measured quantity (pages, ml, km)"`
+	Target        *float64 `json:"target,omitempty" jsonschema:"Quantity to reach per target period"`
+	TargetDays    int      `json:"target_days,omitempty" jsonschema:"Target period in days (defaults to frequency_days)"`
+	ParentID      *int64   `json:"parent_id,omitempty" jsonschema:"Parent activity ID (omit for a top-level activity)"`
 }
 
 type ActivityResult struct {
-	ID            int64   `json:"id" jsonschema:"Activity ID"`
-	Name          string  `json:"name" jsonschema:"Activity name"`
-	Description   string  `json:"description,omitempty" jsonschema:"Activity description"`
-	ProgressType  string  `json:"progress_type" jsonschema:"Progress type"`
-	FrequencyDays int     `json:"frequency_days" jsonschema:"Check-in frequency in days"`
-	LifePartIDs   []int64 `json:"life_part_ids" jsonschema:"Life area IDs"`
-	StartedAt     string  `json:"started_at" jsonschema:"When tracking started (ISO8601)"`
-	CreatedAt     string  `json:"created_at" jsonschema:"When activity was created (ISO8601)"`
+	ID            int64    `json:"id" jsonschema:"Activity ID"`
+	Name          string   `json:"name" jsonschema:"Activity name"`
+	Description   string   `json:"description,omitempty" jsonschema:"Activity description"`
+	ProgressType  string   `json:"progress_type" jsonschema:"Progress type"`
+	FrequencyDays int      `json:"frequency_days" jsonschema:"Check-in frequency in days"`
+	LifePartIDs   []int64  `json:"life_part_ids" jsonschema:"Life area IDs"`
+	ScaleID       *int64   `json:"scale_id,omitempty" jsonschema:"Custom progress scale ID"`
+	Unit          string   `json:"unit,omitempty" jsonschema:"Unit of the measured quantity"`
+	Target        *float64 `json:"target,omitempty" jsonschema:"Quantity to reach per target period"`
+	TargetDays    int      `json:"target_days,omitempty" jsonschema:"Target period in days (0 = frequency_days)"`
+	ParentID      *int64   `json:"parent_id,omitempty" jsonschema:"Parent activity ID"`
+	StartedAt     string   `json:"started_at" jsonschema:"When tracking started (ISO8601)"`
+	CreatedAt     string   `json:"created_at" jsonschema:"When activity was created (ISO8601)"`
 }
 
 type CreateActivityOutput struct {
 	Activity ActivityResult `json:"activity" jsonschema:"Created activity"`
 }
 
+const maxUnitLength = 30
+
 var validProgressTypes = map[string]bool{
 	"mood":             true,
 	"habit_progress":   true,
@@ -88,6 +113,10 @@ func CreateActivity(ctx context.Context, _ *mcp.CallToolRequest, input CreateAct
 	if input.FrequencyDays < 1 {
 		return nil, CreateActivityOutput{}, fmt.Errorf("frequency_days must be at least 1")
 	}
+	unit := strings.TrimSpace(input.Unit)
+	if err := checkTarget(unit, input.Target, input.TargetDays); err != nil {
+		return nil, CreateActivityOutput{}, err
+	}
 
 	startedAt := time.Now()
 	if input.StartedAt != "" {
@@ -102,6 +131,19 @@ func CreateActivity(ctx context.Context, _ *mcp.CallToolRequest, input CreateAct
 	if lifePartIDs == nil {
 		lifePartIDs = []int64{}
 	}
+	if err := checkLifePartIDs(ctx, db, userID, lifePartIDs); err != nil {
+		return nil, CreateActivityOutput{}, err
+	}
+	if input.ScaleID != nil {
+		if err := checkScaleID(ctx, db, userID, *input.ScaleID); err != nil {
+			return nil, CreateActivityOutput{}, err
+		}
+	}
+	if input.ParentID != nil {
+		if err := checkParentID(ctx, db, userID, 0, *input.ParentID); err != nil {
+			return nil, CreateActivityOutput{}, err
+		}
+	}
 
 	activity := &domain.Activity{
 		UserID:        userID,
@@ -110,6 +152,11 @@ func CreateActivity(ctx context.Context, _ *mcp.CallToolRequest, input CreateAct
 		ProgressType:  domain.ProgressType(input.ProgressType),
 		FrequencyDays: input.FrequencyDays,
 		LifePartIDs:   lifePartIDs,
+		ScaleID:       input.ScaleID,
+		Unit:          unit,
+		Target:        input.Target,
+		TargetDays:    input.TargetDays,
+		ParentID:      input.ParentID,
 		StartedAt:     startedAt,
 	}
 
@@ -138,7 +185,57 @@ func activityToResult(a *domain.Activity) ActivityResult {
 		ProgressType:  string(a.ProgressType),
 		FrequencyDays: a.FrequencyDays,
 		LifePartIDs:   lifePartIDs,
+		ScaleID:       a.ScaleID,
+		Unit:          a.Unit,
+		Target:        a.Target,
+		TargetDays:    a.TargetDays,
+		ParentID:      a.ParentID,
 		StartedAt:     a.StartedAt.Format(time.RFC3339),
 		CreatedAt:     a.CreatedAt.Format(time.RFC3339),
 	}
 }
+
+// checkTarget validates the measured quantity settings of an activity.
+func checkTarget(unit string, target *float64, targetDays int) error {
+	if utf8.RuneCountInString(unit) > maxUnitLength {
+		return fmt.Errorf("unit must be at most %d characters", maxUnitLength)
+	}
+	if target != nil && *target <= 0 {
+		return fmt.Errorf("target must be positive")
+	}
+	if targetDays < 0 {
+		return fmt.Errorf("target_days must not be negative")
+	}
+	return nil
+}
+
+// maxActivityDepth bounds the walk up the parent chain.
+const maxActivityDepth = 10
+
+// checkParentID fails when the parent does not exist or when making it the
+// parent of activityID (0 for a new activity) would create a cycle.
+func checkParentID(ctx context.Context, db gateways.DB, userID, activityID, parentID int64) error {
+	id := parentID
+	for depth := 0; ; depth++ {
+		if id == activityID {
+			return fmt.Errorf("activity %d cannot be its own ancestor", activityID)
+		}
+		if depth == maxActivityDepth {
+			return fmt.Errorf("activities nest at most %d levels deep", maxActivityDepth)
+		}
+		parent, err := db.GetActivity(ctx, id, userID)
+		if err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+		if parent == nil {
+			if id == parentID {
+				return fmt.Errorf("parent activity %d not found", parentID)
+			}
+			return nil
+		}
+		if parent.ParentID == nil {
+			return nil
+		}
+		id = *parent.ParentID
+	}
+}
@@ -0,0 +1,127 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+const maxLifePartName = 100
+
+var CreateLifePartMCPDefinition = mcp.Tool{
+	Name: "create_life_part",
+	Annotations: &mcp.ToolAnnotations{
+		Title: "Create life part",
+	},
+	Description: `Create a life area (health, finances, relationships, career...) that activities can belong to.
+
+Use this tool when:
+- User wants to organize activities by area of life
+- An activity is being created for an area that does not exist yet
+
+Required input:
+- name: Area name, up to 100 characters, unique per user (case-insensitive)
+
+Optional input:
+- description: What the area covers
+
+Link activities to the area with create_activity or edit_activity (life_part_ids).`,
+}
+
+type CreateLifePartInput struct {
+	Name        string `json:"name" jsonschema:"Life area name"`
+	Description string `json:"description,omitempty" jsonschema:"Life area description"`
+}
+
+type LifePartResult struct {
+	ID          int64  `json:"id" jsonschema:"Life part ID"`
+	Name        string `json:"name" jsonschema:"Life area name"`
+	Description string `json:"description,omitempty" jsonschema:"Life area description"`
+	CreatedAt   string `json:"created_at" jsonschema:"When the area was created (ISO8601)"`
+}
+
+type CreateLifePartOutput struct {
+	LifePart LifePartResult `json:"life_part" jsonschema:"Created life part"`
+}
+
+func CreateLifePart(ctx context.Context, _ *mcp.CallToolRequest, input CreateLifePartInput) (*mcp.CallToolResult, CreateLifePartOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, CreateLifePartOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, CreateLifePartOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, CreateLifePartOutput{}, fmt.Errorf("name is required")
+	}
+	if utf8.RuneCountInString(name) > maxLifePartName {
+		return nil, CreateLifePartOutput{}, fmt.Errorf("name must be at most %d characters", maxLifePartName)
+	}
+
+	parts, err := db.ListLifeParts(ctx, userID)
+	if err != nil {
+		return nil, CreateLifePartOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	if p := findLifePartByName(parts, name); p != nil {
+		return nil, CreateLifePartOutput{}, fmt.Errorf("life part %q already exists with id %d", p.Name, p.ID)
+	}
+
+	part := &domain.LifePart{UserID: userID, Name: name, Description: strings.TrimSpace(input.Description)}
+	if _, err := db.SaveLifePart(ctx, part); err != nil {
+		return nil, CreateLifePartOutput{}, fmt.Errorf("failed to create life part: %w", err)
+	}
+
+	return nil, CreateLifePartOutput{LifePart: lifePartToResult(*part)}, nil
+}
+
+func lifePartToResult(p domain.LifePart) LifePartResult {
+	return LifePartResult{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// findLifePartByName matches names case-insensitively.
+func findLifePartByName(parts []domain.LifePart, name string) *domain.LifePart {
+	for i := range parts {
+		if strings.EqualFold(parts[i].Name, name) {
+			return &parts[i]
+		}
+	}
+	return nil
+}
+
+// checkLifePartIDs fails when an ID is not one of the user's life parts.
+func checkLifePartIDs(ctx context.Context, db gateways.DB, userID int64, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	parts, err := db.ListLifeParts(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	known := make(map[int64]bool, len(parts))
+	for _, p := range parts {
+		known[p.ID] = true
+	}
+	for _, id := range ids {
+		if !known[id] {
+			return fmt.Errorf("life part %d not found, see list_life_parts", id)
+		}
+	}
+	return nil
+}
@@ -25,7 +25,7 @@ Use this tool to:
 
 Required inputs:
 - activity_id: Get from get_activity_list
-- value: Integer from -2 to +2 (convert from natural language using get_progress_type_examples)
+- value: Integer from -2 to +2, or within the range of the activity's custom scale (convert from natural language using get_progress_type_examples)
   -2 = worst state (hell, missing, changed plans, forgot)
   -1 = bad state (dark, rarely, setback, forgot)
    0 = neutral (gray, trying, stuck, remember)
@@ -35,11 +35,12 @@ Required inputs:
 Optional inputs:
 - note: Save user's explanation (e.g., "Feeling great after morning run")
 - hours_left: For projects only - estimated hours remaining (e.g., 5.5)
+- quantity: Measured amount in the activity unit (e.g., 25 pages); ask for it when get_activity_list shows a unit
 - progress_at: Timestamp for backdating (ISO8601 format, defaults to now)
 
 Validation:
 - Automatically verifies activity exists and user owns it
-- Rejects values outside -2 to +2 range
+- Rejects values outside the activity's scale (-2 to +2 unless scale_id is set)
 - Returns error if activity not found
 
 Example flow:
@@ -51,9 +52,10 @@ Example flow:
 
 type CreateProgressPointInput struct {
 	ActivityID int64    `json:"activity_id" jsonschema:"Activity ID to log progress for"`
-	Value      int      `json:"value" jsonschema:"Progress value from -2 to +2"`
+	Value      int      `json:"value" jsonschema:"Progress value from -2 to +2, or on the activity's custom scale"`
 	Note       string   `json:"note,omitempty" jsonschema:"Optional note about this progress point"`
 	HoursLeft  *float64 `json:"hours_left,omitempty" jsonschema:"Estimated hours remaining for projects (omit if not tracking)"`
+	Quantity   *float64 `json:"quantity,omitempty" jsonschema:"Measured amount in the activity unit (omit if not measured)"`
 	ProgressAt string   `json:"progress_at,omitempty" jsonschema:"When progress was made (ISO8601, defaults to now if empty)"`
 }
 
@@ -72,11 +74,6 @@ func CreateProgressPoint(ctx context.Context, _ *mcp.CallToolRequest, input Crea
 		return nil, CreateProgressPointOutput{}, fmt.Errorf("user_id not available in context")
 	}
 
-	// Validate value range
-	if input.Value < -2 || input.Value > 2 {
-		return nil, CreateProgressPointOutput{}, fmt.Errorf("value must be between -2 and +2")
-	}
-
 	// Verify activity ownership
 	activity, err := db.GetActivity(ctx, input.ActivityID, userID)
 	if err != nil {
@@ -86,6 +83,18 @@ func CreateProgressPoint(ctx context.Context, _ *mcp.CallToolRequest, input Crea
 		return nil, CreateProgressPointOutput{}, fmt.Errorf("activity not found or unauthorized")
 	}
 
+	// Validate value range
+	scale, err := activityScale(ctx, db, userID, activity)
+	if err != nil {
+		return nil, CreateProgressPointOutput{}, err
+	}
+	if err := checkValue(scale, input.Value); err != nil {
+		return nil, CreateProgressPointOutput{}, err
+	}
+	if input.Quantity != nil && *input.Quantity < 0 {
+		return nil, CreateProgressPointOutput{}, fmt.Errorf("quantity must not be negative")
+	}
+
 	// Parse progress_at or use now
 	var progressAt time.Time
 	if input.ProgressAt != "" {
@@ -103,6 +112,7 @@ func CreateProgressPoint(ctx context.Context, _ *mcp.CallToolRequest, input Crea
 		UserID:     userID,
 		Value:      input.Value,
 		HoursLeft:  input.HoursLeft,
+		Quantity:   input.Quantity,
 		Note:       input.Note,
 		ProgressAt: progressAt,
 	}
@@ -118,6 +128,7 @@ func CreateProgressPoint(ctx context.Context, _ *mcp.CallToolRequest, input Crea
 			ID:         id,
 			Value:      point.Value,
 			HoursLeft:  point.HoursLeft,
+			Quantity:   point.Quantity,
 			Note:       point.Note,
 			ProgressAt: point.ProgressAt.Format(time.RFC3339),
 		},
@@ -203,6 +203,11 @@ const htmlTemplate = `<!DOCTYPE html>
             color: #808080;
         }
 
+        .activity-plan {
+            font-size: 11px;
+            color: #000;
+        }
+
         .emoji-grid {
             display: flex;
             gap: 2px;
@@ -277,6 +282,7 @@ const htmlTemplate = `<!DOCTYPE html>
                             <span class="activity-ago{{if .StalenessClass}} {{.StalenessClass}}{{end}}">{{.TimeAgo}}</span>
                         </div>
                         {{if .Description}}<div class="activity-desc">{{.Description}}</div>{{end}}
+                        {{if or .Milestones .Burndown}}<div class="activity-plan">{{.Milestones}}{{if and .Milestones .Burndown}} · {{end}}{{.Burndown}}</div>{{end}}
                         <div class="emoji-grid">
                             {{- range $i, $p := .ProgressCells -}}
                                 {{if $p.IsSpacer -}}
@@ -304,6 +310,7 @@ const htmlTemplate = `<!DOCTYPE html>
                             <span class="activity-ago{{if .StalenessClass}} {{.StalenessClass}}{{end}}">{{.TimeAgo}}</span>
                         </div>
                         {{if .Description}}<div class="activity-desc">{{.Description}}</div>{{end}}
+                        {{if or .Milestones .Burndown}}<div class="activity-plan">{{.Milestones}}{{if and .Milestones .Burndown}} · {{end}}{{.Burndown}}</div>{{end}}
                         <div class="emoji-grid">
                             {{- range $i, $p := .ProgressCells -}}
                                 {{if $p.IsSpacer -}}
@@ -323,36 +330,6 @@ const htmlTemplate = `<!DOCTYPE html>
 </body>
 </html>`
 
-// Emoji mappings by progress type
-var emojiMappings = map[string]map[int]string{
-	"mood": {
-		2:  "☀️",
-		1:  "⛅",
-		0:  "☁️",
-		-1: "🌧️",
-		-2: "⛈️",
-	},
-	"habit_progress": {
-		2:  "💪",
-		1:  "👍",
-		0:  "🤔",
-		-1: "😔",
-		-2: "❌",
-	},
-	"project_progress": {
-		2:  "🚀",
-		1:  "⮕",
-		0:  "⌛",
-		-1: "⬅",
-		-2: "🔄",
-	},
-	"promise_state": {
-		1:  "✅",
-		0:  "💭",
-		-1: "🤷",
-	},
-}
-
 type StreakDay struct {
 	Active   bool
 	IsSunday bool // Флаг для быстрой проверки в шаблоне
@@ -371,6 +348,8 @@ type ActivityView struct {
 	Frequency      string
 	TimeAgo        string
 	StalenessClass string
+	Milestones     string // "2/5 milestones", empty without milestones
+	Burndown       string // "42h left · ETA Nov 3", empty without estimates
 	ProgressCells  []ProgressCell
 }
 
@@ -435,16 +414,11 @@ func formatTimeAgo(t time.Time) string {
 	return fmt.Sprintf("%dd", days)
 }
 
-func getEmoji(progressType string, value *int) string {
+func getEmoji(scale domain.ProgressScale, value *int) string {
 	if value == nil {
 		return ""
 	}
-	if mapping, ok := emojiMappings[progressType]; ok {
-		if emoji, ok := mapping[*value]; ok {
-			return emoji
-		}
-	}
-	return ""
+	return scale.Emoji(*value)
 }
 
 // calculateAvgGap вычисляет средний промежуток между днями
@@ -531,7 +505,7 @@ func isSameDay(date1, date2 time.Time) bool {
 // buildActivityProgressCells строит компактные ячейки прогресса для активности
 // Показывает только замеры (без пустых дней), вставляя разделители с количеством пропущенных дней между замерами
 // ВАЖНО: ячейки строятся СЛЕВА НАПРАВО от самых старых к новым
-func buildActivityProgressCells(activity domain.Activity, allProgress []domain.ActivityPoint, today time.Time) []ProgressCell {
+func buildActivityProgressCells(activity domain.Activity, scale domain.ProgressScale, allProgress []domain.ActivityPoint, today time.Time) []ProgressCell {
 	// Отфильтровать точки для этой активности
 	activityProgress := filterProgressByActivity(allProgress, activity.ID)
 
@@ -559,7 +533,7 @@ func buildActivityProgressCells(activity domain.Activity, allProgress []domain.A
 
 		// Добавить ячейку с замером
 		isToday := isSameDay(point.ProgressAt, today)
-		emoji := getEmoji(string(activity.ProgressType), &point.Value)
+		emoji := getEmoji(scale, &point.Value)
 
 		cells = append(cells, ProgressCell{
 			Emoji:   emoji,
@@ -715,8 +689,15 @@ func buildDashboardDataFromDB(ctx context.Context, db gateways.DB) (DashboardDat
 	avgGapWeek := calculateAvgGap(lastSevenGaps)
 
 	// Шаг 5: Построить ячейки прогресса для каждой панели
-	projectViews := buildActivityViews(projectActivities, allProgress, now)
-	habitViews := buildActivityViews(habitActivities, allProgress, now)
+	scales, err := db.ListProgressScales(ctx, userID)
+	if err != nil {
+		return DashboardData{}, fmt.Errorf("failed to list progress scales: %w", err)
+	}
+	projectViews := buildActivityViews(projectActivities, scales, allProgress, now)
+	habitViews := buildActivityViews(habitActivities, scales, allProgress, now)
+	if err := addProjectPlans(ctx, db, userID, projectActivities, projectViews, now); err != nil {
+		return DashboardData{}, err
+	}
 
 	// Шаг 6: Вызвать GetTrendStats для всех отображаемых активностей
 	for _, activity := range append(projectActivities, habitActivities...) {
@@ -736,10 +717,10 @@ func buildDashboardDataFromDB(ctx context.Context, db gateways.DB) (DashboardDat
 	}, nil
 }
 
-func buildActivityViews(activities []domain.Activity, allProgress []domain.ActivityPoint, now time.Time) []ActivityView {
+func buildActivityViews(activities []domain.Activity, scales []domain.ProgressScale, allProgress []domain.ActivityPoint, now time.Time) []ActivityView {
 	views := make([]ActivityView, 0, len(activities))
 	for _, activity := range activities {
-		cells := buildActivityProgressCells(activity, allProgress, now)
+		cells := buildActivityProgressCells(activity, domain.ScaleOf(activity, scales), allProgress, now)
 		views = append(views, ActivityView{
 			Name:           activity.Name,
 			Description:    renderBoldMarkdown(activity.Description),
@@ -751,3 +732,59 @@ func buildActivityViews(activities []domain.Activity, allProgress []domain.Activ
 	}
 	return views
 }
+
+// addProjectPlans fills milestone counts and the burndown summary of the
+// project views. Burndowns include the hours_left of sub-activities.
+func addProjectPlans(ctx context.Context, db gateways.DB, userID int64, projects []domain.Activity, views []ActivityView, now time.Time) error {
+	if len(projects) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(projects))
+	for i, a := range projects {
+		ids[i] = a.ID
+	}
+	milestones, err := db.ListMilestones(ctx, userID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	all, allPoints, err := db.ListActivitySubtrees(ctx, userID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list sub-activities: %w", err)
+	}
+
+	for i, a := range projects {
+		var total, done int
+		for _, m := range milestones {
+			if m.ActivityID == a.ID {
+				total++
+				if m.Done() {
+					done++
+				}
+			}
+		}
+		if total > 0 {
+			views[i].Milestones = fmt.Sprintf("%d/%d milestones", done, total)
+		}
+
+		var points []domain.ActivityPoint
+		for _, id := range append([]int64{a.ID}, domain.Descendants(all, a.ID)...) {
+			points = append(points, filterProgressByActivity(allPoints, id)...)
+		}
+		views[i].Burndown = formatBurndown(domain.BurndownReport(points, now))
+	}
+	return nil
+}
+
+// formatBurndown показывает остаток часов и прогноз завершения
+func formatBurndown(b *domain.Burndown) string {
+	switch {
+	case b == nil:
+		return ""
+	case b.HoursLeft == 0:
+		return "done"
+	case b.ProjectedAt == nil:
+		return fmt.Sprintf("%gh left", b.HoursLeft)
+	}
+	return fmt.Sprintf("%gh left · ETA %s", b.HoursLeft, b.ProjectedAt.Format("Jan 2"))
+}
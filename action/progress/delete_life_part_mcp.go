@@ -0,0 +1,54 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+	"personal/util"
+)
+
+var DeleteLifePartMCPDefinition = mcp.Tool{
+	Name: "delete_life_part",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Delete life part",
+	},
+	Description: `Delete a life area. Activities and their progress stay; they are only unlinked from the area.
+
+Required input:
+- life_part_id: Get from list_life_parts`,
+}
+
+type DeleteLifePartInput struct {
+	LifePartID int64 `json:"life_part_id" jsonschema:"Life part ID to delete"`
+}
+
+type DeleteLifePartOutput struct {
+	Success bool   `json:"success" jsonschema:"Whether the life part was deleted"`
+	Message string `json:"message" jsonschema:"Result message"`
+}
+
+func DeleteLifePart(ctx context.Context, _ *mcp.CallToolRequest, input DeleteLifePartInput) (*mcp.CallToolResult, DeleteLifePartOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, DeleteLifePartOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, DeleteLifePartOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	deleted, err := db.DeleteLifePart(ctx, userID, input.LifePartID)
+	if err != nil {
+		return nil, DeleteLifePartOutput{}, fmt.Errorf("failed to delete life part: %w", err)
+	}
+	if !deleted {
+		return nil, DeleteLifePartOutput{}, fmt.Errorf("life part not found")
+	}
+
+	return nil, DeleteLifePartOutput{Success: true, Message: "Life part deleted"}, nil
+}
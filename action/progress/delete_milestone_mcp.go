@@ -0,0 +1,54 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+	"personal/util"
+)
+
+var DeleteMilestoneMCPDefinition = mcp.Tool{
+	Name: "delete_milestone",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Delete milestone",
+	},
+	Description: `Delete a milestone. To mark one as reached use save_milestone with done=true instead.
+
+Required input:
+- milestone_id: Get from get_activity_stats`,
+}
+
+type DeleteMilestoneInput struct {
+	MilestoneID int64 `json:"milestone_id" jsonschema:"Milestone ID to delete"`
+}
+
+type DeleteMilestoneOutput struct {
+	Success bool   `json:"success" jsonschema:"Whether the milestone was deleted"`
+	Message string `json:"message" jsonschema:"Result message"`
+}
+
+func DeleteMilestone(ctx context.Context, _ *mcp.CallToolRequest, input DeleteMilestoneInput) (*mcp.CallToolResult, DeleteMilestoneOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, DeleteMilestoneOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, DeleteMilestoneOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	deleted, err := db.DeleteMilestone(ctx, userID, input.MilestoneID)
+	if err != nil {
+		return nil, DeleteMilestoneOutput{}, fmt.Errorf("failed to delete milestone: %w", err)
+	}
+	if !deleted {
+		return nil, DeleteMilestoneOutput{}, fmt.Errorf("milestone not found")
+	}
+
+	return nil, DeleteMilestoneOutput{Success: true, Message: "Milestone deleted"}, nil
+}
@@ -0,0 +1,57 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+	"personal/util"
+)
+
+var DeleteProgressPointMCPDefinition = mcp.Tool{
+	Name: "delete_progress_point",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Delete progress point",
+	},
+	Description: `Delete a progress point logged by mistake, e.g. a duplicate check-in.
+
+Required input:
+- progress_id: Get from get_activity_stats or search_progress_notes
+
+Prefer edit_progress_point when the check-in happened but was recorded wrong.
+The activity's last check-in time is recalculated from the remaining points.`,
+}
+
+type DeleteProgressPointInput struct {
+	ProgressID int64 `json:"progress_id" jsonschema:"Progress point ID to delete"`
+}
+
+type DeleteProgressPointOutput struct {
+	Success bool   `json:"success" jsonschema:"Whether the point was deleted"`
+	Message string `json:"message" jsonschema:"Result message"`
+}
+
+func DeleteProgressPoint(ctx context.Context, _ *mcp.CallToolRequest, input DeleteProgressPointInput) (*mcp.CallToolResult, DeleteProgressPointOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, DeleteProgressPointOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, DeleteProgressPointOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	deleted, err := db.DeleteProgress(ctx, input.ProgressID, userID)
+	if err != nil {
+		return nil, DeleteProgressPointOutput{}, fmt.Errorf("failed to delete progress point: %w", err)
+	}
+	if !deleted {
+		return nil, DeleteProgressPointOutput{}, fmt.Errorf("progress point not found or unauthorized")
+	}
+
+	return nil, DeleteProgressPointOutput{Success: true, Message: "Progress point deleted"}, nil
+}
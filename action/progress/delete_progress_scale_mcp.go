@@ -0,0 +1,54 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+	"personal/util"
+)
+
+var DeleteProgressScaleMCPDefinition = mcp.Tool{
+	Name: "delete_progress_scale",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Delete progress scale",
+	},
+	Description: `Delete a custom progress scale. Fails while activities use it; switch them to another scale with edit_activity first.
+
+Required input:
+- scale_id: Get from get_progress_type_examples (custom_scales)`,
+}
+
+type DeleteProgressScaleInput struct {
+	ScaleID int64 `json:"scale_id" jsonschema:"Scale ID to delete"`
+}
+
+type DeleteProgressScaleOutput struct {
+	Success bool   `json:"success" jsonschema:"Whether the scale was deleted"`
+	Message string `json:"message" jsonschema:"Result message"`
+}
+
+func DeleteProgressScale(ctx context.Context, _ *mcp.CallToolRequest, input DeleteProgressScaleInput) (*mcp.CallToolResult, DeleteProgressScaleOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, DeleteProgressScaleOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, DeleteProgressScaleOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	deleted, err := db.DeleteProgressScale(ctx, userID, input.ScaleID)
+	if err != nil {
+		return nil, DeleteProgressScaleOutput{}, fmt.Errorf("failed to delete progress scale: %w", err)
+	}
+	if !deleted {
+		return nil, DeleteProgressScaleOutput{}, fmt.Errorf("progress scale not found")
+	}
+
+	return nil, DeleteProgressScaleOutput{Success: true, Message: "Progress scale deleted"}, nil
+}
@@ -3,6 +3,7 @@ package progress
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -32,6 +33,11 @@ Optional inputs (at least one required):
 - life_part_ids: New life area IDs — replaces all existing (omit to keep current)
 - started_at: New start date/time (ISO8601, omit to keep current)
 - ended_at: New end date/time (ISO8601, pass empty string "" to reopen the activity, omit to keep current)
+- scale_id: Custom value scale from save_progress_scale (pass 0 to return to the built-in scale, omit to keep current); rejected while existing points fall outside the new range
+- unit: Unit of the measured quantity (pass empty string "" to clear)
+- target: Quantity to reach per target period (pass 0 to remove the target)
+- target_days: Target period in days (pass 0 to follow frequency_days)
+- parent_id: Parent activity (pass 0 to make it top-level)
 
 Example:
 User: "Update the description of my driver's license activity - the exam is done"
@@ -39,13 +45,18 @@ You: [Call edit_activity(activity_id=42, description="Exam passed, license recei
 }
 
 type EditActivityInput struct {
-	ActivityID    int64   `json:"activity_id" jsonschema:"Activity ID to edit"`
-	Name          *string `json:"name,omitempty" jsonschema:"New name (omit to keep current)"`
-	Description   *string `json:"description,omitempty" jsonschema:"New description, pass empty string to clear (omit to keep current)"`
-	FrequencyDays *int    `json:"frequency_days,omitempty" jsonschema:"New check-in frequency in days (omit to keep current)"`
-	LifePartIDs   []int64 `json:"life_part_ids,omitempty" jsonschema:"New life part IDs replacing existing (omit to keep current)"`
-	StartedAt     *string `json:"started_at,omitempty" jsonschema:"New start date/time (ISO8601, omit to keep current)"`
-	EndedAt       *string `json:"ended_at,omitempty" jsonschema:"New end date/time (ISO8601, pass empty string to reopen the activity, omit to keep current)"`
+	ActivityID    int64    `json:"activity_id" jsonschema:"Activity ID to edit"`
+	Name          *string  `json:"name,omitempty" jsonschema:"New name (omit to keep current)"`
+	Description   *string  `json:"description,omitempty" jsonschema:"New description, pass empty string to clear (omit to keep current)"`
+	FrequencyDays *int     `json:"frequency_days,omitempty" jsonschema:"New check-in frequency in days (omit to keep current)"`
+	LifePartIDs   []int64  `json:"life_part_ids,omitempty" jsonschema:"New life part IDs replacing existing (omit to keep current)"`
+	StartedAt     *string  `json:"started_at,omitempty" jsonschema:"New start date/time (ISO8601, omit to keep current)"`
+	EndedAt       *string  `json:"ended_at,omitempty" jsonschema:"New end date/time (ISO8601, pass empty string to reopen the activity, omit to keep current)"`
+	ScaleID       *int64   `json:"scale_id,omitempty" jsonschema:"Custom progress scale ID, 0 for the built-in scale (omit to keep current)"`
+	Unit          *string  `json:"unit,omitempty" jsonschema:"New unit, pass empty string to clear (omit to keep current)"`
+	Target        *float64 `json:"target,omitempty" jsonschema:"New target per period, 0 removes it (omit to keep current)"`
+	TargetDays    *int     `json:"target_days,omitempty" jsonschema:"New target period in days, 0 follows frequency_days (omit to keep current)"`
+	ParentID      *int64   `json:"parent_id,omitempty" jsonschema:"New parent activity ID, 0 for top level (omit to keep current)"`
 }
 
 type EditActivityOutput struct {
@@ -64,7 +75,8 @@ func EditActivity(ctx context.Context, _ *mcp.CallToolRequest, input EditActivit
 	}
 
 	if input.Name == nil && input.Description == nil && input.FrequencyDays == nil &&
-		input.LifePartIDs == nil && input.StartedAt == nil && input.EndedAt == nil {
+		input.LifePartIDs == nil && input.StartedAt == nil && input.EndedAt == nil && input.ScaleID == nil &&
+		input.Unit == nil && input.Target == nil && input.TargetDays == nil && input.ParentID == nil {
 		return nil, EditActivityOutput{}, fmt.Errorf("at least one field must be provided to update")
 	}
 
@@ -90,6 +102,21 @@ func EditActivity(ctx context.Context, _ *mcp.CallToolRequest, input EditActivit
 		endedAt = &t
 	}
 
+	if err := checkLifePartIDs(ctx, db, userID, input.LifePartIDs); err != nil {
+		return nil, EditActivityOutput{}, err
+	}
+	if input.ScaleID != nil && *input.ScaleID != 0 {
+		if err := checkScaleID(ctx, db, userID, *input.ScaleID); err != nil {
+			return nil, EditActivityOutput{}, err
+		}
+	}
+
+	if input.ParentID != nil && *input.ParentID != 0 {
+		if err := checkParentID(ctx, db, userID, input.ActivityID, *input.ParentID); err != nil {
+			return nil, EditActivityOutput{}, err
+		}
+	}
+
 	activity, err := db.GetActivity(ctx, input.ActivityID, userID)
 	if err != nil {
 		return nil, EditActivityOutput{}, fmt.Errorf("database error: %w", err)
@@ -116,6 +143,39 @@ func EditActivity(ctx context.Context, _ *mcp.CallToolRequest, input EditActivit
 	if input.EndedAt != nil {
 		activity.EndedAt = endedAt
 	}
+	if input.ScaleID != nil {
+		activity.ScaleID = input.ScaleID
+		if *input.ScaleID == 0 {
+			activity.ScaleID = nil
+		}
+	}
+	if input.ParentID != nil {
+		activity.ParentID = input.ParentID
+		if *input.ParentID == 0 {
+			activity.ParentID = nil
+		}
+	}
+	if input.Unit != nil {
+		activity.Unit = strings.TrimSpace(*input.Unit)
+	}
+	if input.Target != nil {
+		activity.Target = input.Target
+		if *input.Target == 0 {
+			activity.Target = nil
+		}
+	}
+	if input.TargetDays != nil {
+		activity.TargetDays = *input.TargetDays
+	}
+	if err := checkTarget(activity.Unit, activity.Target, activity.TargetDays); err != nil {
+		return nil, EditActivityOutput{}, err
+	}
+
+	if input.ScaleID != nil {
+		if err := checkPointsFitScale(ctx, db, userID, activity); err != nil {
+			return nil, EditActivityOutput{}, err
+		}
+	}
 
 	if err := db.UpdateActivity(ctx, activity); err != nil {
 		return nil, EditActivityOutput{}, fmt.Errorf("failed to update activity: %w", err)
@@ -0,0 +1,95 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+var EditLifePartMCPDefinition = mcp.Tool{
+	Name: "edit_life_part",
+	Annotations: &mcp.ToolAnnotations{
+		Title: "Rename or describe life part",
+	},
+	Description: `Rename a life area or change its description. Linked activities keep the link.
+
+Required input:
+- life_part_id: Get from list_life_parts
+
+Optional inputs (at least one required):
+- name: New name, unique per user (case-insensitive)
+- description: New description (pass empty string "" to clear)`,
+}
+
+type EditLifePartInput struct {
+	LifePartID  int64   `json:"life_part_id" jsonschema:"Life part ID to edit"`
+	Name        *string `json:"name,omitempty" jsonschema:"New name (omit to keep current)"`
+	Description *string `json:"description,omitempty" jsonschema:"New description, pass empty string to clear (omit to keep current)"`
+}
+
+type EditLifePartOutput struct {
+	LifePart LifePartResult `json:"life_part" jsonschema:"Updated life part"`
+}
+
+func EditLifePart(ctx context.Context, _ *mcp.CallToolRequest, input EditLifePartInput) (*mcp.CallToolResult, EditLifePartOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, EditLifePartOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, EditLifePartOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	if input.Name == nil && input.Description == nil {
+		return nil, EditLifePartOutput{}, fmt.Errorf("at least one field must be provided to update")
+	}
+
+	parts, err := db.ListLifeParts(ctx, userID)
+	if err != nil {
+		return nil, EditLifePartOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	var updated domain.LifePart
+	for _, p := range parts {
+		if p.ID == input.LifePartID {
+			updated = p
+		}
+	}
+	if updated.ID == 0 {
+		return nil, EditLifePartOutput{}, fmt.Errorf("life part not found")
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, EditLifePartOutput{}, fmt.Errorf("name cannot be empty")
+		}
+		if utf8.RuneCountInString(name) > maxLifePartName {
+			return nil, EditLifePartOutput{}, fmt.Errorf("name must be at most %d characters", maxLifePartName)
+		}
+		if other := findLifePartByName(parts, name); other != nil && other.ID != updated.ID {
+			return nil, EditLifePartOutput{}, fmt.Errorf("life part %q already exists with id %d", other.Name, other.ID)
+		}
+		updated.Name = name
+	}
+	if input.Description != nil {
+		updated.Description = strings.TrimSpace(*input.Description)
+	}
+
+	found, err := db.SaveLifePart(ctx, &updated)
+	if err != nil {
+		return nil, EditLifePartOutput{}, fmt.Errorf("failed to update life part: %w", err)
+	}
+	if !found {
+		return nil, EditLifePartOutput{}, fmt.Errorf("life part not found")
+	}
+
+	return nil, EditLifePartOutput{LifePart: lifePartToResult(updated)}, nil
+}
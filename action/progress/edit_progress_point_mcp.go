@@ -0,0 +1,149 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+)
+
+var EditProgressPointMCPDefinition = mcp.Tool{
+	Name: "edit_progress_point",
+	Annotations: &mcp.ToolAnnotations{
+		Title: "Edit progress point",
+	},
+	Description: `Correct a logged progress point: a mis-mapped value, a wrong note or time, or a point logged against the wrong activity.
+
+Use this tool when:
+- User says "that should have been +1, not +2"
+- A check-in was recorded for the wrong activity
+- The note or time of a check-in needs fixing
+
+Required input:
+- progress_id: Get from get_activity_stats or search_progress_notes
+
+Optional inputs (at least one required):
+- value: New value on the activity's scale (-2 to +2 unless it has a custom scale)
+- note: New note (pass empty string "" to clear)
+- hours_left: New estimated hours remaining (negative value clears it)
+- quantity: New measured amount in the activity unit (negative value clears it)
+- progress_at: New time (ISO8601)
+- activity_id: Move the point to another activity of the user
+
+The activity's last check-in time is recalculated, so check-in urgency in get_activity_list stays correct.`,
+}
+
+type EditProgressPointInput struct {
+	ProgressID int64    `json:"progress_id" jsonschema:"Progress point ID to edit"`
+	Value      *int     `json:"value,omitempty" jsonschema:"New value on the activity's scale (omit to keep current)"`
+	Note       *string  `json:"note,omitempty" jsonschema:"New note, pass empty string to clear (omit to keep current)"`
+	HoursLeft  *float64 `json:"hours_left,omitempty" jsonschema:"New hours remaining, negative clears it (omit to keep current)"`
+	Quantity   *float64 `json:"quantity,omitempty" jsonschema:"New measured amount, negative clears it (omit to keep current)"`
+	ProgressAt *string  `json:"progress_at,omitempty" jsonschema:"New time (ISO8601, omit to keep current)"`
+	ActivityID *int64   `json:"activity_id,omitempty" jsonschema:"Move to this activity (omit to keep current)"`
+}
+
+type EditProgressPointOutput struct {
+	ActivityID int64         `json:"activity_id" jsonschema:"Activity the point belongs to"`
+	Progress   ProgressPoint `json:"progress" jsonschema:"Updated progress point"`
+}
+
+func EditProgressPoint(ctx context.Context, _ *mcp.CallToolRequest, input EditProgressPointInput) (*mcp.CallToolResult, EditProgressPointOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, EditProgressPointOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, EditProgressPointOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	if input.Value == nil && input.Note == nil && input.HoursLeft == nil && input.Quantity == nil && input.ProgressAt == nil && input.ActivityID == nil {
+		return nil, EditProgressPointOutput{}, fmt.Errorf("at least one field must be provided to update")
+	}
+
+	var progressAt time.Time
+	if input.ProgressAt != nil {
+		t, err := time.Parse(time.RFC3339, *input.ProgressAt)
+		if err != nil {
+			return nil, EditProgressPointOutput{}, fmt.Errorf("invalid progress_at format, expected RFC3339: %w", err)
+		}
+		progressAt = t
+	}
+
+	// Verify point ownership
+	point, err := db.GetProgress(ctx, input.ProgressID, userID)
+	if err != nil {
+		return nil, EditProgressPointOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	if point == nil {
+		return nil, EditProgressPointOutput{}, fmt.Errorf("progress point not found or unauthorized")
+	}
+
+	// Verify ownership of the target activity
+	activityID := point.ActivityID
+	if input.ActivityID != nil {
+		activityID = *input.ActivityID
+	}
+	activity, err := db.GetActivity(ctx, activityID, userID)
+	if err != nil {
+		return nil, EditProgressPointOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	if activity == nil {
+		return nil, EditProgressPointOutput{}, fmt.Errorf("activity not found or unauthorized")
+	}
+	point.ActivityID = activity.ID
+
+	if input.Value != nil {
+		point.Value = *input.Value
+	}
+
+	// The value, kept or new, must fit the scale of the target activity
+	scale, err := activityScale(ctx, db, userID, activity)
+	if err != nil {
+		return nil, EditProgressPointOutput{}, err
+	}
+	if err := checkValue(scale, point.Value); err != nil {
+		return nil, EditProgressPointOutput{}, err
+	}
+	if input.Note != nil {
+		point.Note = *input.Note
+	}
+	if input.HoursLeft != nil {
+		point.HoursLeft = input.HoursLeft
+		if *input.HoursLeft < 0 {
+			point.HoursLeft = nil
+		}
+	}
+	if input.Quantity != nil {
+		point.Quantity = input.Quantity
+		if *input.Quantity < 0 {
+			point.Quantity = nil
+		}
+	}
+	if input.ProgressAt != nil {
+		point.ProgressAt = progressAt
+	}
+
+	if err := db.UpdateProgress(ctx, point); err != nil {
+		return nil, EditProgressPointOutput{}, fmt.Errorf("failed to update progress point: %w", err)
+	}
+
+	output := EditProgressPointOutput{
+		ActivityID: point.ActivityID,
+		Progress: ProgressPoint{
+			ID:         point.ID,
+			Value:      point.Value,
+			HoursLeft:  point.HoursLeft,
+			Quantity:   point.Quantity,
+			Note:       point.Note,
+			ProgressAt: point.ProgressAt.Format(time.RFC3339),
+		},
+	}
+
+	return nil, output, nil
+}
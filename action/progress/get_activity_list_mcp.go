@@ -3,6 +3,7 @@ package progress
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -29,7 +30,9 @@ Parameters:
 - active_only=false: returns only finished activities, ordered by ended_at DESC
 
 Each activity includes ID, name, progress type (mood/habit_progress/project_progress/promise_state), frequency in days, and optional description.
-Finished activities also include ended_at timestamp.
+Finished activities also include ended_at timestamp. Sub-activities carry parent_id; get_activity_stats on the parent shows them with milestones and burndown.
+
+areas groups the listed activities by life area (see list_life_parts), keeping the activity order; an activity linked to several areas appears in each, and activities without an area are grouped under "unassigned" with life_part_id 0. Use it to walk through a reflection area by area.
 
 Example workflow:
 1. Call this tool with active_only=true to get activity list
@@ -42,17 +45,30 @@ type GetActivityListInput struct {
 }
 
 type ActivityItem struct {
-	ID            int64  `json:"id" jsonschema:"Activity ID"`
-	Name          string `json:"name" jsonschema:"Activity name"`
-	ProgressType  string `json:"progress_type" jsonschema:"Progress type (mood|habit_progress|project_progress|promise_state)"`
-	FrequencyDays int    `json:"frequency_days" jsonschema:"Check-in frequency in days"`
-	Description   string `json:"description,omitempty" jsonschema:"Activity description"`
-	StartedAt     string `json:"started_at" jsonschema:"When activity was started (RFC3339)"`
-	EndedAt       string `json:"ended_at,omitempty" jsonschema:"When activity was finished (RFC3339), only set for finished activities"`
+	ID            int64    `json:"id" jsonschema:"Activity ID"`
+	Name          string   `json:"name" jsonschema:"Activity name"`
+	ProgressType  string   `json:"progress_type" jsonschema:"Progress type (mood|habit_progress|project_progress|promise_state)"`
+	FrequencyDays int      `json:"frequency_days" jsonschema:"Check-in frequency in days"`
+	Description   string   `json:"description,omitempty" jsonschema:"Activity description"`
+	StartedAt     string   `json:"started_at" jsonschema:"When activity was started (RFC3339)"`
+	EndedAt       string   `json:"ended_at,omitempty" jsonschema:"When activity was finished (RFC3339), only set for finished activities"`
+	LifePartIDs   []int64  `json:"life_part_ids,omitempty" jsonschema:"Life area IDs"`
+	Unit          string   `json:"unit,omitempty" jsonschema:"Unit of the measured quantity; ask for the amount at check-in"`
+	Target        *float64 `json:"target,omitempty" jsonschema:"Quantity to reach per target period"`
+	TargetDays    int      `json:"target_days,omitempty" jsonschema:"Target period in days"`
+	ParentID      *int64   `json:"parent_id,omitempty" jsonschema:"Parent activity ID of a sub-activity"`
+}
+
+// ActivityArea lists the IDs of the activities in one life area.
+type ActivityArea struct {
+	LifePartID  int64   `json:"life_part_id" jsonschema:"Life part ID, 0 for activities without an area"`
+	Name        string  `json:"name" jsonschema:"Life area name"`
+	ActivityIDs []int64 `json:"activity_ids" jsonschema:"Activities of the area in list order"`
 }
 
 type GetActivityListOutput struct {
 	Activities []ActivityItem `json:"activities" jsonschema:"List of active activities"`
+	Areas      []ActivityArea `json:"areas" jsonschema:"Listed activities grouped by life area"`
 }
 
 func GetActivityList(ctx context.Context, _ *mcp.CallToolRequest, input GetActivityListInput) (*mcp.CallToolResult, GetActivityListOutput, error) {
@@ -76,8 +92,14 @@ func GetActivityList(ctx context.Context, _ *mcp.CallToolRequest, input GetActiv
 		return nil, GetActivityListOutput{}, fmt.Errorf("database error: %w", err)
 	}
 
+	parts, err := db.ListLifeParts(ctx, userID)
+	if err != nil {
+		return nil, GetActivityListOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
 	output := GetActivityListOutput{
 		Activities: make([]ActivityItem, 0, len(activities)),
+		Areas:      groupByArea(parts, activities),
 	}
 
 	for _, a := range activities {
@@ -88,6 +110,13 @@ func GetActivityList(ctx context.Context, _ *mcp.CallToolRequest, input GetActiv
 			FrequencyDays: a.FrequencyDays,
 			Description:   a.Description,
 			StartedAt:     a.StartedAt.Format(time.RFC3339),
+			LifePartIDs:   a.LifePartIDs,
+			Unit:          a.Unit,
+			Target:        a.Target,
+			ParentID:      a.ParentID,
+		}
+		if a.Measured() {
+			item.TargetDays = a.TargetPeriodDays()
 		}
 		if a.EndedAt != nil {
 			item.EndedAt = a.EndedAt.Format(time.RFC3339)
@@ -97,3 +126,33 @@ func GetActivityList(ctx context.Context, _ *mcp.CallToolRequest, input GetActiv
 
 	return nil, output, nil
 }
+
+// groupByArea lists the activities of every life part that has any, in part
+// order, then the activities without a known part.
+func groupByArea(parts []domain.LifePart, activities []domain.Activity) []ActivityArea {
+	areas := make([]ActivityArea, 0, len(parts)+1)
+	linked := make(map[int64]bool, len(activities))
+	for _, p := range parts {
+		area := ActivityArea{LifePartID: p.ID, Name: p.Name}
+		for _, a := range activities {
+			if slices.Contains(a.LifePartIDs, p.ID) {
+				area.ActivityIDs = append(area.ActivityIDs, a.ID)
+				linked[a.ID] = true
+			}
+		}
+		if len(area.ActivityIDs) > 0 {
+			areas = append(areas, area)
+		}
+	}
+
+	unassigned := ActivityArea{Name: "unassigned"}
+	for _, a := range activities {
+		if !linked[a.ID] {
+			unassigned.ActivityIDs = append(unassigned.ActivityIDs, a.ID)
+		}
+	}
+	if len(unassigned.ActivityIDs) > 0 {
+		areas = append(areas, unassigned)
+	}
+	return areas
+}
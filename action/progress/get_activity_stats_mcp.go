@@ -7,6 +7,7 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"personal/action/savings_goals"
 	"personal/domain"
 	"personal/gateways"
 )
@@ -26,13 +27,16 @@ Use this tool when:
 
 Input: activity_id (get from get_activity_list)
 
+SCALE - the activity's value range and named levels (built-in -2..+2 for its progress type, or its custom scale).
+Averages and percentiles below are on this scale; each point carries the level word and emoji of its value.
+
 Returns:
 1. LAST 3 POINTS - Most recent progress entries with values, notes, timestamps, hours_left
    - Use to show: "Last 3 times: +2 (yesterday), +1 (3 days ago), 0 (5 days ago)"
 
 2. TREND OVERALL - All-time statistics since activity started
    - Count: total number of check-ins
-   - Average: mean progress value (-2 to +2)
+   - Average: mean progress value on the scale
    - Percentile 80: you're in top 20% when above this value
    - Use to show: "Overall: 45 check-ins, averaging +1.2"
 
@@ -44,6 +48,25 @@ Returns:
    - Shows very recent changes
    - Use to show: "This week: 3 check-ins, averaging +1.8"
 
+5. QUANTITY - Only for activities with a unit or target (omitted otherwise)
+   - Total: all-time sum of point quantities; each trend also has quantity_total
+   - Current period: sum so far and attainment % of the target
+   - Periods met, average attainment % of completed periods, current and longest streak of met periods
+   - Use to show: "92 of 140 pages this week (66%). 3 weeks in a row on target!"
+
+6. HIERARCHY AND MILESTONES
+   - parent_id, sub_activities (with their latest hours_left) and milestones (done, overdue, due_at)
+   - Use to show: "Thesis: 2 of 5 milestones done, 'Draft' is overdue"
+
+7. BURNDOWN - Only when the activity or its sub-activities have points with hours_left
+   - Daily series of remaining hours (sub-activities add up), velocity in hours/day over the last 14 days
+   - projected_completion: when remaining hours reach zero at that velocity (absent when not burning down)
+   - Use to show: "42h left, burning 3h/day - done around Nov 3"
+
+8. SAVINGS GOALS - Goals linked to the activity via save_savings_goal (omitted when none)
+   - Saved amount, required monthly contribution and on_track/behind status
+   - Use to show: "Emergency fund: 4,200 of 6,000 EUR, on track"
+
 Example conversation:
 "Let's check in on your Daily Mood. Last time you logged +1 (bright). This week you're averaging +1.8. How are you feeling today?"`,
 }
@@ -54,24 +77,87 @@ type GetActivityStatsInput struct {
 
 type ProgressPoint struct {
 	ID         int64    `json:"id" jsonschema:"Progress point ID"`
-	Value      int      `json:"value" jsonschema:"Progress value from -2 to +2"`
+	Value      int      `json:"value" jsonschema:"Progress value on the activity's scale"`
+	Level      string   `json:"level,omitempty" jsonschema:"Scale word for the value"`
+	Emoji      string   `json:"emoji,omitempty" jsonschema:"Scale emoji for the value"`
 	HoursLeft  *float64 `json:"hours_left,omitempty" jsonschema:"Estimated hours remaining"`
+	Quantity   *float64 `json:"quantity,omitempty" jsonschema:"Measured amount in the activity unit"`
 	Note       string   `json:"note,omitempty" jsonschema:"Note about this progress point"`
 	ProgressAt string   `json:"progress_at" jsonschema:"When progress was made (ISO8601)"`
 }
 
 type TrendStatsOutput struct {
-	Count        int     `json:"count" jsonschema:"Number of progress points in this period"`
-	Average      float64 `json:"average,omitempty" jsonschema:"Average progress value (0 if no data)"`
-	Percentile80 float64 `json:"percentile_80,omitempty" jsonschema:"80th percentile value (0 if no data)"`
+	Count         int      `json:"count" jsonschema:"Number of progress points in this period"`
+	Average       float64  `json:"average,omitempty" jsonschema:"Average progress value (0 if no data)"`
+	Percentile80  float64  `json:"percentile_80,omitempty" jsonschema:"80th percentile value (0 if no data)"`
+	QuantityTotal *float64 `json:"quantity_total,omitempty" jsonschema:"Sum of point quantities, only for measured activities"`
+}
+
+type QuantityPeriodOutput struct {
+	From          string   `json:"from" jsonschema:"Period start (RFC3339)"`
+	To            string   `json:"to" jsonschema:"Period end, exclusive (RFC3339)"`
+	Total         float64  `json:"total" jsonschema:"Sum of quantities in the period"`
+	AttainmentPct *float64 `json:"attainment_pct,omitempty" jsonschema:"Total as percent of the target"`
+	Met           bool     `json:"met" jsonschema:"Target reached (any quantity logged when there is no target)"`
+}
+
+type QuantityStatsOutput struct {
+	Unit             string               `json:"unit,omitempty" jsonschema:"Unit of the quantity"`
+	Target           *float64             `json:"target,omitempty" jsonschema:"Quantity to reach per period"`
+	PeriodDays       int                  `json:"period_days" jsonschema:"Target period in days"`
+	Total            float64              `json:"total" jsonschema:"All-time sum of quantities"`
+	Count            int                  `json:"count" jsonschema:"Points with a quantity"`
+	CurrentPeriod    QuantityPeriodOutput `json:"current_period" jsonschema:"Period containing now"`
+	PeriodsCompleted int                  `json:"periods_completed" jsonschema:"Finished periods since the activity started"`
+	PeriodsMet       int                  `json:"periods_met" jsonschema:"Finished periods that reached the target"`
+	AvgAttainmentPct *float64             `json:"avg_attainment_pct,omitempty" jsonschema:"Mean attainment of finished periods, each capped at 100"`
+	CurrentStreak    int                  `json:"current_streak" jsonschema:"Consecutive met periods up to now"`
+	LongestStreak    int                  `json:"longest_streak" jsonschema:"Longest run of met periods"`
+}
+
+type SubActivityOutput struct {
+	ID           int64    `json:"id" jsonschema:"Activity ID"`
+	Name         string   `json:"name" jsonschema:"Activity name"`
+	ProgressType string   `json:"progress_type" jsonschema:"Progress type"`
+	ParentID     int64    `json:"parent_id" jsonschema:"Parent activity ID"`
+	EndedAt      string   `json:"ended_at,omitempty" jsonschema:"When it was finished (ISO8601)"`
+	HoursLeft    *float64 `json:"hours_left,omitempty" jsonschema:"Latest estimate of remaining hours"`
+}
+
+type MilestonesOutput struct {
+	Total   int               `json:"total" jsonschema:"Milestones of the activity"`
+	Done    int               `json:"done" jsonschema:"Milestones reached"`
+	Overdue int               `json:"overdue" jsonschema:"Open milestones past their due date"`
+	Items   []MilestoneResult `json:"items" jsonschema:"Milestones by due date, undated last"`
+}
+
+type BurndownPointOutput struct {
+	Date      string  `json:"date" jsonschema:"Day (YYYY-MM-DD, UTC)"`
+	HoursLeft float64 `json:"hours_left" jsonschema:"Remaining hours at the end of the day"`
+}
+
+type BurndownOutput struct {
+	HoursLeft           float64               `json:"hours_left" jsonschema:"Remaining hours now"`
+	VelocityPerDay      *float64              `json:"velocity_per_day,omitempty" jsonschema:"Hours burned per day over velocity_days"`
+	VelocityDays        int                   `json:"velocity_days" jsonschema:"Window of the velocity in days"`
+	ProjectedCompletion string                `json:"projected_completion,omitempty" jsonschema:"When hours_left reaches zero at the current velocity (ISO8601)"`
+	Series              []BurndownPointOutput `json:"series" jsonschema:"Remaining hours on days the estimate changed, oldest first"`
 }
 
 type GetActivityStatsOutput struct {
 	ActivityID     int64            `json:"activity_id" jsonschema:"Activity ID"`
+	Scale          ScaleOutput      `json:"scale" jsonschema:"Value scale of the activity"`
 	Last3Points    []ProgressPoint  `json:"last_3_points" jsonschema:"Last 3 progress points"`
 	TrendOverall   TrendStatsOutput `json:"trend_overall" jsonschema:"Statistics for all time"`
 	TrendLastMonth TrendStatsOutput `json:"trend_last_month" jsonschema:"Statistics for last 30 days"`
 	TrendLastWeek  TrendStatsOutput `json:"trend_last_week" jsonschema:"Statistics for last 7 days"`
+
+	Quantity      *QuantityStatsOutput       `json:"quantity,omitempty" jsonschema:"Measured quantity totals, attainment and streaks"`
+	ParentID      *int64                     `json:"parent_id,omitempty" jsonschema:"Parent activity ID"`
+	SubActivities []SubActivityOutput        `json:"sub_activities,omitempty" jsonschema:"Sub-activities at any depth"`
+	Milestones    *MilestonesOutput          `json:"milestones,omitempty" jsonschema:"Milestones of the activity"`
+	Burndown      *BurndownOutput            `json:"burndown,omitempty" jsonschema:"Remaining hours history and projected completion"`
+	SavingsGoals  []savings_goals.GoalOutput `json:"savings_goals,omitempty" jsonschema:"Savings goals linked to this activity"`
 }
 
 func GetActivityStats(ctx context.Context, _ *mcp.CallToolRequest, input GetActivityStatsInput) (*mcp.CallToolResult, GetActivityStatsOutput, error) {
@@ -105,9 +191,17 @@ func GetActivityStats(ctx context.Context, _ *mcp.CallToolRequest, input GetActi
 		return nil, GetActivityStatsOutput{}, fmt.Errorf("failed to get last 3 points: %w", err)
 	}
 
+	scale, err := activityScale(ctx, db, userID, activity)
+	if err != nil {
+		return nil, GetActivityStatsOutput{}, err
+	}
+
 	// Convert to output format
+	scaleOutput := scaleToOutput(scale)
+	scaleOutput.Metaphors = nil
 	output := GetActivityStatsOutput{
 		ActivityID:  input.ActivityID,
+		Scale:       scaleOutput,
 		Last3Points: make([]ProgressPoint, 0, len(last3Points)),
 	}
 
@@ -116,9 +210,14 @@ func GetActivityStats(ctx context.Context, _ *mcp.CallToolRequest, input GetActi
 			ID:         p.ID,
 			Value:      p.Value,
 			HoursLeft:  p.HoursLeft,
+			Quantity:   p.Quantity,
 			Note:       p.Note,
 			ProgressAt: p.ProgressAt.Format(time.RFC3339),
 		}
+		if level := scale.Level(p.Value); level != nil {
+			point.Level = level.Word
+			point.Emoji = level.Emoji
+		}
 		output.Last3Points = append(output.Last3Points, point)
 	}
 
@@ -158,5 +257,147 @@ func GetActivityStats(ctx context.Context, _ *mcp.CallToolRequest, input GetActi
 		Percentile80: lastWeekStats.Percentile80,
 	}
 
+	points, err := db.ListProgress(ctx, domain.ProgressFilter{UserID: userID, ActivityID: input.ActivityID})
+	if err != nil {
+		return nil, GetActivityStatsOutput{}, fmt.Errorf("failed to get progress points: %w", err)
+	}
+
+	// Quantity totals, attainment and streaks
+	if activity.Measured() {
+		output.Quantity = quantityToOutput(domain.QuantityReport(*activity, points, now))
+		output.TrendOverall.QuantityTotal = &output.Quantity.Total
+		lastMonth := domain.QuantityTotal(points, now.AddDate(0, 0, -30), now)
+		output.TrendLastMonth.QuantityTotal = &lastMonth
+		lastWeek := domain.QuantityTotal(points, now.AddDate(0, 0, -7), now)
+		output.TrendLastWeek.QuantityTotal = &lastWeek
+	}
+
+	// Sub-activities, milestones and burndown
+	output.ParentID = activity.ParentID
+	subActivities, subPoints, err := loadSubActivities(ctx, db, userID, input.ActivityID)
+	if err != nil {
+		return nil, GetActivityStatsOutput{}, err
+	}
+	for _, a := range subActivities {
+		sub := SubActivityOutput{ID: a.ID, Name: a.Name, ProgressType: string(a.ProgressType), ParentID: *a.ParentID}
+		if a.EndedAt != nil {
+			sub.EndedAt = a.EndedAt.Format(time.RFC3339)
+		}
+		if b := domain.BurndownReport(filterProgressByActivity(subPoints, a.ID), now); b != nil {
+			sub.HoursLeft = &b.HoursLeft
+		}
+		output.SubActivities = append(output.SubActivities, sub)
+	}
+
+	milestones, err := db.ListMilestones(ctx, userID, []int64{input.ActivityID})
+	if err != nil {
+		return nil, GetActivityStatsOutput{}, fmt.Errorf("failed to get milestones: %w", err)
+	}
+	if len(milestones) > 0 {
+		output.Milestones = milestonesToOutput(milestones, now)
+	}
+
+	if b := domain.BurndownReport(append(points, subPoints...), now); b != nil {
+		output.Burndown = burndownToOutput(b)
+	}
+
+	// Savings goals linked to the activity
+	goals, err := db.ListSavingsGoals(ctx, userID)
+	if err != nil {
+		return nil, GetActivityStatsOutput{}, fmt.Errorf("failed to get savings goals: %w", err)
+	}
+	var linked []domain.SavingsGoal
+	for _, g := range goals {
+		if g.ActivityID != nil && *g.ActivityID == input.ActivityID {
+			linked = append(linked, g)
+		}
+	}
+	if len(linked) > 0 {
+		output.SavingsGoals, err = savings_goals.Evaluate(ctx, db, userID, linked, now.UTC())
+		if err != nil {
+			return nil, GetActivityStatsOutput{}, fmt.Errorf("failed to evaluate savings goals: %w", err)
+		}
+	}
+
 	return nil, output, nil
 }
+
+func quantityToOutput(s domain.QuantityStats) *QuantityStatsOutput {
+	return &QuantityStatsOutput{
+		Unit:       s.Unit,
+		Target:     s.Target,
+		PeriodDays: s.PeriodDays,
+		Total:      s.Total,
+		Count:      s.Count,
+		CurrentPeriod: QuantityPeriodOutput{
+			From:          s.Current.From.Format(time.RFC3339),
+			To:            s.Current.To.Format(time.RFC3339),
+			Total:         s.Current.Total,
+			AttainmentPct: s.Current.AttainmentPct,
+			Met:           s.Current.Met,
+		},
+		PeriodsCompleted: s.PeriodsCompleted,
+		PeriodsMet:       s.PeriodsMet,
+		AvgAttainmentPct: s.AvgAttainmentPct,
+		CurrentStreak:    s.CurrentStreak,
+		LongestStreak:    s.LongestStreak,
+	}
+}
+
+// loadSubActivities returns the sub-activities of id at any depth, including
+// finished ones and ones that start later, with their progress points.
+func loadSubActivities(ctx context.Context, db gateways.DB, userID, id int64) ([]domain.Activity, []domain.ActivityPoint, error) {
+	tree, treePoints, err := db.ListActivitySubtrees(ctx, userID, []int64{id})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list sub-activities: %w", err)
+	}
+
+	byID := make(map[int64]domain.Activity, len(tree))
+	for _, a := range tree {
+		byID[a.ID] = a
+	}
+	var subs []domain.Activity
+	var points []domain.ActivityPoint
+	for _, childID := range domain.Descendants(tree, id) {
+		subs = append(subs, byID[childID])
+		points = append(points, filterProgressByActivity(treePoints, childID)...)
+	}
+	return subs, points, nil
+}
+
+func milestonesToOutput(milestones []domain.Milestone, now time.Time) *MilestonesOutput {
+	out := &MilestonesOutput{Total: len(milestones), Items: make([]MilestoneResult, len(milestones))}
+	for i, m := range milestones {
+		out.Items[i] = milestoneToResult(m, now)
+		if m.Done() {
+			out.Done++
+		}
+		if m.Overdue(now) {
+			out.Overdue++
+		}
+	}
+	return out
+}
+
+// maxBurndownSeries keeps the most recent days of a long burndown.
+const maxBurndownSeries = 60
+
+func burndownToOutput(b *domain.Burndown) *BurndownOutput {
+	series := b.Series
+	if len(series) > maxBurndownSeries {
+		series = series[len(series)-maxBurndownSeries:]
+	}
+	out := &BurndownOutput{
+		HoursLeft:      b.HoursLeft,
+		VelocityPerDay: b.Velocity,
+		VelocityDays:   domain.BurndownVelocityDays,
+		Series:         make([]BurndownPointOutput, len(series)),
+	}
+	for i, p := range series {
+		out.Series[i] = BurndownPointOutput{Date: p.Day.Format(time.DateOnly), HoursLeft: p.HoursLeft}
+	}
+	if b.ProjectedAt != nil {
+		out.ProjectedCompletion = b.ProjectedAt.Format(time.RFC3339)
+	}
+	return out
+}
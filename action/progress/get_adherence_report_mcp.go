@@ -0,0 +1,201 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+const (
+	defaultAdherenceDays = 90
+	maxAdherenceDays     = 730
+	maxMissedWindows     = 20
+)
+
+var GetAdherenceReportMCPDefinition = mcp.Tool{
+	Name: "get_adherence_report",
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+		Title:          "Get check-in adherence report",
+	},
+	Description: `Measure how well check-ins keep up with each activity's frequency_days.
+
+Use this tool when:
+- User asks "how consistent am I?", "what's my streak?", "which check-ins did I miss?"
+- Reviewing habits in a weekly or monthly reflection
+
+Parameters:
+- activity_id: (optional) one activity, finished ones included; omit for all active activities
+- days: (optional) look-back window, default 90, max 730
+
+Each activity's life is split into windows of frequency_days starting at the day it started (UTC).
+A window is on time when it has at least one check-in; the window in progress only counts once it has one.
+
+Per activity:
+- adherence_pct: on-time windows / windows in the range
+- current_streak, longest_streak: consecutive on-time windows
+- months: adherence % per calendar month of the window start
+- missed: the most recent missed windows (from/to), missed_total counts all
+- gaps: skipped days between check-in days — avg, avg_break (mean of non-zero gaps), median, max,
+  and on_time / late (up to 2x frequency) / very_late buckets
+
+Example: "Meditation: 86% on time this quarter, 12-day streak; your breaks last 2 days on average."`,
+}
+
+type GetAdherenceReportInput struct {
+	ActivityID int64 `json:"activity_id,omitempty" jsonschema:"Activity ID (omit for all active activities)"`
+	Days       int   `json:"days,omitempty" jsonschema:"Look-back window in days (default 90, max 730)"`
+}
+
+type MonthAdherenceOutput struct {
+	Month   string  `json:"month" jsonschema:"Month (YYYY-MM)"`
+	Windows int     `json:"windows" jsonschema:"Check-in windows that started in the month"`
+	OnTime  int     `json:"on_time" jsonschema:"Windows with a check-in"`
+	Pct     float64 `json:"adherence_pct" jsonschema:"on_time / windows in percent"`
+}
+
+type MissedWindowOutput struct {
+	From string `json:"from" jsonschema:"Window start (ISO8601)"`
+	To   string `json:"to" jsonschema:"Window end, exclusive (ISO8601)"`
+}
+
+type GapStatsOutput struct {
+	Count    int     `json:"count" jsonschema:"Gaps between consecutive check-in days"`
+	Avg      float64 `json:"avg" jsonschema:"Mean skipped days"`
+	AvgBreak float64 `json:"avg_break" jsonschema:"Mean skipped days of the non-zero gaps"`
+	Median   float64 `json:"median" jsonschema:"Median skipped days"`
+	Max      int     `json:"max" jsonschema:"Longest break in days"`
+	OnTime   int     `json:"on_time" jsonschema:"Gaps within frequency_days"`
+	Late     int     `json:"late" jsonschema:"Gaps within twice frequency_days"`
+	VeryLate int     `json:"very_late" jsonschema:"Longer gaps"`
+}
+
+type ActivityAdherenceOutput struct {
+	ActivityID    int64                  `json:"activity_id" jsonschema:"Activity ID"`
+	Name          string                 `json:"name" jsonschema:"Activity name"`
+	FrequencyDays int                    `json:"frequency_days" jsonschema:"Check-in frequency in days"`
+	Windows       int                    `json:"windows" jsonschema:"Check-in windows in the range"`
+	OnTime        int                    `json:"on_time" jsonschema:"Windows with a check-in"`
+	Pct           *float64               `json:"adherence_pct,omitempty" jsonschema:"on_time / windows in percent, absent without windows"`
+	CurrentStreak int                    `json:"current_streak" jsonschema:"Consecutive on-time windows up to now"`
+	LongestStreak int                    `json:"longest_streak" jsonschema:"Longest run of on-time windows in the range"`
+	Months        []MonthAdherenceOutput `json:"months" jsonschema:"Adherence per month"`
+	Missed        []MissedWindowOutput   `json:"missed" jsonschema:"Most recent missed windows, newest first"`
+	MissedTotal   int                    `json:"missed_total" jsonschema:"All missed windows in the range"`
+	Gaps          GapStatsOutput         `json:"gaps" jsonschema:"Distribution of gaps between check-in days"`
+}
+
+type GetAdherenceReportOutput struct {
+	From       string                    `json:"from" jsonschema:"Range start (ISO8601)"`
+	To         string                    `json:"to" jsonschema:"Range end (ISO8601)"`
+	Activities []ActivityAdherenceOutput `json:"activities" jsonschema:"Adherence per activity"`
+}
+
+func GetAdherenceReport(ctx context.Context, _ *mcp.CallToolRequest, input GetAdherenceReportInput) (*mcp.CallToolResult, GetAdherenceReportOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, GetAdherenceReportOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, GetAdherenceReportOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	days := input.Days
+	if days <= 0 {
+		days = defaultAdherenceDays
+	}
+	if days > maxAdherenceDays {
+		return nil, GetAdherenceReportOutput{}, fmt.Errorf("days must be at most %d", maxAdherenceDays)
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+
+	var activities []domain.Activity
+	if input.ActivityID != 0 {
+		activity, err := db.GetActivity(ctx, input.ActivityID, userID)
+		if err != nil {
+			return nil, GetAdherenceReportOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		if activity == nil {
+			return nil, GetAdherenceReportOutput{}, fmt.Errorf("activity not found or unauthorized")
+		}
+		activities = []domain.Activity{*activity}
+	} else {
+		var err error
+		activities, err = db.ListActivities(ctx, domain.ActivityFilter{UserID: userID, ActiveOnly: true})
+		if err != nil {
+			return nil, GetAdherenceReportOutput{}, fmt.Errorf("database error: %w", err)
+		}
+	}
+
+	points, err := db.ListProgress(ctx, domain.ProgressFilter{UserID: userID, ActivityID: input.ActivityID, From: from, To: to})
+	if err != nil {
+		return nil, GetAdherenceReportOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	byActivity := make(map[int64][]domain.ActivityPoint, len(activities))
+	for _, p := range points {
+		byActivity[p.ActivityID] = append(byActivity[p.ActivityID], p)
+	}
+
+	output := GetAdherenceReportOutput{
+		From:       from.Format(time.RFC3339),
+		To:         to.Format(time.RFC3339),
+		Activities: make([]ActivityAdherenceOutput, 0, len(activities)),
+	}
+	for _, a := range activities {
+		report := domain.AdherenceReport(a, byActivity[a.ID], from, to)
+		output.Activities = append(output.Activities, adherenceToOutput(a, report))
+	}
+
+	return nil, output, nil
+}
+
+func adherenceToOutput(a domain.Activity, r domain.Adherence) ActivityAdherenceOutput {
+	out := ActivityAdherenceOutput{
+		ActivityID:    a.ID,
+		Name:          a.Name,
+		FrequencyDays: r.FrequencyDays,
+		Windows:       r.Windows,
+		OnTime:        r.OnTime,
+		Pct:           r.Pct,
+		CurrentStreak: r.CurrentStreak,
+		LongestStreak: r.LongestStreak,
+		Months:        make([]MonthAdherenceOutput, 0, len(r.Months)),
+		Missed:        []MissedWindowOutput{},
+		MissedTotal:   len(r.Missed),
+		Gaps: GapStatsOutput{
+			Count:    r.Gaps.Count,
+			Avg:      r.Gaps.Avg,
+			AvgBreak: r.Gaps.AvgBreak,
+			Median:   r.Gaps.Median,
+			Max:      r.Gaps.Max,
+			OnTime:   r.Gaps.OnTime,
+			Late:     r.Gaps.Late,
+			VeryLate: r.Gaps.VeryLate,
+		},
+	}
+	for _, m := range r.Months {
+		out.Months = append(out.Months, MonthAdherenceOutput{
+			Month:   m.Month.Format("2006-01"),
+			Windows: m.Windows,
+			OnTime:  m.OnTime,
+			Pct:     m.Pct,
+		})
+	}
+	for i := len(r.Missed) - 1; i >= 0 && len(out.Missed) < maxMissedWindows; i-- {
+		out.Missed = append(out.Missed, MissedWindowOutput{
+			From: r.Missed[i].From.Format(time.RFC3339),
+			To:   r.Missed[i].To.Format(time.RFC3339),
+		})
+	}
+	return out
+}
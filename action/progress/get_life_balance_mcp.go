@@ -0,0 +1,152 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+var GetLifeBalanceMCPDefinition = mcp.Tool{
+	Name: "get_life_balance",
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+		Title:          "Get life balance by area",
+	},
+	Description: `Average recent progress per life area, to see which areas thrive and which are neglected.
+
+Use this tool when:
+- Running a weekly or monthly reflection ("how is my life going overall?")
+- User asks which area needs attention
+
+Parameters:
+- days: (optional) look-back window, default 30, max 365
+
+For each life area (plus "unassigned" for activities without one):
+- activity_ids, points, expected_check_ins (from each activity's frequency over the days it ran)
+- check_in_rate: points / expected_check_ins, capped at 1
+- average: mean value of the points, custom scales mapped onto -2..+2
+- status: thriving (average >= +1), steady, struggling (average < 0),
+  neglected (no points, or under half of the expected check-ins), no_activities
+
+thriving / struggling / neglected summarize area names, e.g.
+"Health is thriving, finances neglected".`,
+}
+
+type GetLifeBalanceInput struct {
+	Days int `json:"days,omitempty" jsonschema:"Look-back window in days (default 30, max 365)"`
+}
+
+type LifeBalanceItem struct {
+	LifePartID       int64    `json:"life_part_id" jsonschema:"Life part ID, 0 for activities without an area"`
+	Name             string   `json:"name" jsonschema:"Life area name"`
+	ActivityIDs      []int64  `json:"activity_ids" jsonschema:"Activities of the area"`
+	Points           int      `json:"points" jsonschema:"Progress points in the window"`
+	ExpectedCheckIns float64  `json:"expected_check_ins" jsonschema:"Check-ins the activity frequencies ask for"`
+	CheckInRate      *float64 `json:"check_in_rate,omitempty" jsonschema:"points / expected_check_ins, capped at 1"`
+	Average          *float64 `json:"average,omitempty" jsonschema:"Mean progress value on -2..+2, absent without points"`
+	LastPointAt      string   `json:"last_point_at,omitempty" jsonschema:"Latest point in the window (ISO8601)"`
+	Status           string   `json:"status" jsonschema:"thriving|steady|struggling|neglected|no_activities"`
+}
+
+type GetLifeBalanceOutput struct {
+	From       string            `json:"from" jsonschema:"Window start (ISO8601)"`
+	To         string            `json:"to" jsonschema:"Window end (ISO8601)"`
+	Areas      []LifeBalanceItem `json:"areas" jsonschema:"Balance per life area"`
+	Thriving   []string          `json:"thriving" jsonschema:"Names of thriving areas"`
+	Struggling []string          `json:"struggling" jsonschema:"Names of struggling areas"`
+	Neglected  []string          `json:"neglected" jsonschema:"Names of neglected areas"`
+}
+
+func GetLifeBalance(ctx context.Context, _ *mcp.CallToolRequest, input GetLifeBalanceInput) (*mcp.CallToolResult, GetLifeBalanceOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, GetLifeBalanceOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, GetLifeBalanceOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	days := input.Days
+	if days <= 0 {
+		days = 30
+	}
+	if days > 365 {
+		return nil, GetLifeBalanceOutput{}, fmt.Errorf("days must be at most 365")
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+
+	parts, err := db.ListLifeParts(ctx, userID)
+	if err != nil {
+		return nil, GetLifeBalanceOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	// Active activities plus those finished inside the window
+	activities, err := db.ListActivities(ctx, domain.ActivityFilter{UserID: userID, ActiveOnly: true})
+	if err != nil {
+		return nil, GetLifeBalanceOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	finished, err := db.ListActivities(ctx, domain.ActivityFilter{UserID: userID})
+	if err != nil {
+		return nil, GetLifeBalanceOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	for _, a := range finished {
+		if a.EndedAt.After(from) {
+			activities = append(activities, a)
+		}
+	}
+
+	points, err := db.ListProgress(ctx, domain.ProgressFilter{UserID: userID, From: from, To: to})
+	if err != nil {
+		return nil, GetLifeBalanceOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	scales, err := db.ListProgressScales(ctx, userID)
+	if err != nil {
+		return nil, GetLifeBalanceOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	output := GetLifeBalanceOutput{
+		From:       from.Format(time.RFC3339),
+		To:         to.Format(time.RFC3339),
+		Areas:      []LifeBalanceItem{},
+		Thriving:   []string{},
+		Struggling: []string{},
+		Neglected:  []string{},
+	}
+	for _, b := range domain.LifeBalanceReport(parts, activities, scales, points, from, to) {
+		item := LifeBalanceItem{
+			LifePartID:       b.LifePart.ID,
+			Name:             b.LifePart.Name,
+			ActivityIDs:      b.ActivityIDs,
+			Points:           b.Points,
+			ExpectedCheckIns: b.ExpectedCheckIns,
+			CheckInRate:      b.CheckInRate,
+			Average:          b.Average,
+			Status:           b.Status,
+		}
+		if b.LastPointAt != nil {
+			item.LastPointAt = b.LastPointAt.Format(time.RFC3339)
+		}
+		output.Areas = append(output.Areas, item)
+
+		switch b.Status {
+		case domain.LifeThriving:
+			output.Thriving = append(output.Thriving, b.LifePart.Name)
+		case domain.LifeStruggling:
+			output.Struggling = append(output.Struggling, b.LifePart.Name)
+		case domain.LifeNeglected:
+			output.Neglected = append(output.Neglected, b.LifePart.Name)
+		}
+	}
+
+	return nil, output, nil
+}
@@ -2,8 +2,12 @@ package progress
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
 )
 
 var GetProgressTypeExamplesMCPDefinition = mcp.Tool{
@@ -33,7 +37,10 @@ How to use mappings:
 - When user says "we're stuck" → project_progress type, "stuck" = 0
 - When user says "I forgot" → promise_state type, "forgot" = -1
 
-Offer metaphor choices when user is uncertain: "Would you describe your mood like weather (sunny to stormy) or colors (green to red)?"`,
+Offer metaphor choices when user is uncertain: "Would you describe your mood like weather (sunny to stormy) or colors (green to red)?"
+
+CUSTOM SCALES: custom_scales lists the user's own scales (save_progress_scale) with their range and words.
+An activity with scale_id uses that scale instead of its progress type's -2..+2 mapping; check get_activity_list/get_activity_stats for scale_id.`,
 }
 
 type ProgressTypeExamplesInput struct {
@@ -42,7 +49,7 @@ type ProgressTypeExamplesInput struct {
 
 type MappingValue struct {
 	Word  string `json:"word" jsonschema:"Natural language word or phrase"`
-	Value int    `json:"value" jsonschema:"Progress value from -2 to +2"`
+	Value int    `json:"value" jsonschema:"Progress value on the scale"`
 	Emoji string `json:"emoji" jsonschema:"Associated emoji"`
 }
 
@@ -56,121 +63,75 @@ type ProgressTypeMapping struct {
 	Mappings     []MappingSet `json:"mappings" jsonschema:"Different mapping metaphors for this progress type"`
 }
 
+// CustomScaleMapping is a user-defined scale with its word sets.
+type CustomScaleMapping struct {
+	ScaleID     int64        `json:"scale_id" jsonschema:"Scale ID, referenced by activities as scale_id"`
+	Name        string       `json:"name" jsonschema:"Scale name"`
+	Description string       `json:"description,omitempty" jsonschema:"Scale description"`
+	MinValue    int          `json:"min_value" jsonschema:"Lowest value"`
+	MaxValue    int          `json:"max_value" jsonschema:"Highest value"`
+	Mappings    []MappingSet `json:"mappings" jsonschema:"Metaphor word sets, or the named levels"`
+}
+
 type ProgressTypeExamplesOutput struct {
-	Examples []ProgressTypeMapping `json:"examples" jsonschema:"Mapping examples for each progress type"`
+	Examples     []ProgressTypeMapping `json:"examples" jsonschema:"Mapping examples for each progress type"`
+	CustomScales []CustomScaleMapping  `json:"custom_scales" jsonschema:"User-defined scales"`
 }
 
 func GetProgressTypeExamples(ctx context.Context, _ *mcp.CallToolRequest, _ ProgressTypeExamplesInput) (*mcp.CallToolResult, ProgressTypeExamplesOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, ProgressTypeExamplesOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, ProgressTypeExamplesOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
 	output := ProgressTypeExamplesOutput{
-		Examples: []ProgressTypeMapping{
-			{
-				ProgressType: "mood",
-				Mappings: []MappingSet{
-					{
-						MappingName: "mood as weather",
-						Values: []MappingValue{
-							{Word: "sunny", Value: 2, Emoji: "☀️"},
-							{Word: "partly cloudy", Value: 1, Emoji: "⛅"},
-							{Word: "overcast", Value: 0, Emoji: "☁️"},
-							{Word: "rainy", Value: -1, Emoji: "🌧️"},
-							{Word: "stormy", Value: -2, Emoji: "⛈️"},
-						},
-					},
-					{
-						MappingName: "mood as light",
-						Values: []MappingValue{
-							{Word: "bright", Value: 2, Emoji: "✨"},
-							{Word: "light", Value: 1, Emoji: "💡"},
-							{Word: "dim", Value: 0, Emoji: "🕯️"},
-							{Word: "dark", Value: -1, Emoji: "🌑"},
-							{Word: "pitch black", Value: -2, Emoji: "⚫"},
-						},
-					},
-					{
-						MappingName: "mood as colors",
-						Values: []MappingValue{
-							{Word: "green", Value: 2, Emoji: "💚"},
-							{Word: "white", Value: 1, Emoji: "🤍"},
-							{Word: "gray", Value: 0, Emoji: "🩶"},
-							{Word: "black", Value: -1, Emoji: "🖤"},
-							{Word: "red", Value: -2, Emoji: "❤️‍🔥"},
-						},
-					},
-				},
-			},
-			{
-				ProgressType: "habit_progress",
-				Mappings: []MappingSet{
-					{
-						MappingName: "habit consistency",
-						Values: []MappingValue{
-							{Word: "crushing it", Value: 2, Emoji: "💪"},
-							{Word: "mostly doing", Value: 1, Emoji: "👍"},
-							{Word: "trying", Value: 0, Emoji: "🤔"},
-							{Word: "rarely", Value: -1, Emoji: "😔"},
-							{Word: "not doing", Value: -2, Emoji: "❌"},
-						},
-					},
-					{
-						MappingName: "habit as garden",
-						Values: []MappingValue{
-							{Word: "blooming", Value: 2, Emoji: "🌸"},
-							{Word: "growing", Value: 1, Emoji: "🌱"},
-							{Word: "planted", Value: 0, Emoji: "🌰"},
-							{Word: "wilting", Value: -1, Emoji: "🥀"},
-							{Word: "withered", Value: -2, Emoji: "🍂"},
-						},
-					},
-				},
-			},
-			{
-				ProgressType: "project_progress",
-				Mappings: []MappingSet{
-					{
-						MappingName: "project momentum",
-						Values: []MappingValue{
-							{Word: "breakthrough", Value: 2, Emoji: "🚀"},
-							{Word: "moving forward", Value: 1, Emoji: "➡️"},
-							{Word: "stuck", Value: 0, Emoji: "⏸️"},
-							{Word: "setback", Value: -1, Emoji: "↩️"},
-							{Word: "changed plans", Value: -2, Emoji: "🔄"},
-						},
-					},
-					{
-						MappingName: "project as journey",
-						Values: []MappingValue{
-							{Word: "sprinting", Value: 2, Emoji: "🏃"},
-							{Word: "walking", Value: 1, Emoji: "🚶"},
-							{Word: "resting", Value: 0, Emoji: "🧘"},
-							{Word: "backtracking", Value: -1, Emoji: "🔙"},
-							{Word: "lost", Value: -2, Emoji: "🗺️"},
-						},
-					},
-				},
-			},
-			{
-				ProgressType: "promise_state",
-				Mappings: []MappingSet{
-					{
-						MappingName: "promise awareness",
-						Values: []MappingValue{
-							{Word: "did something", Value: 1, Emoji: "✅"},
-							{Word: "remember", Value: 0, Emoji: "💭"},
-							{Word: "forgot", Value: -1, Emoji: "🤷"},
-						},
-					},
-					{
-						MappingName: "promise as flame",
-						Values: []MappingValue{
-							{Word: "burning", Value: 1, Emoji: "🔥"},
-							{Word: "lit", Value: 0, Emoji: "🕯️"},
-							{Word: "extinguished", Value: -1, Emoji: "💨"},
-						},
-					},
-				},
-			},
-		},
+		Examples:     []ProgressTypeMapping{},
+		CustomScales: []CustomScaleMapping{},
+	}
+	for _, scale := range domain.BuiltinScales() {
+		output.Examples = append(output.Examples, ProgressTypeMapping{
+			ProgressType: scale.Name,
+			Mappings:     mappingSets(scale),
+		})
+	}
+
+	scales, err := db.ListProgressScales(ctx, userID)
+	if err != nil {
+		return nil, ProgressTypeExamplesOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	for _, scale := range scales {
+		output.CustomScales = append(output.CustomScales, CustomScaleMapping{
+			ScaleID:     scale.ID,
+			Name:        scale.Name,
+			Description: scale.Description,
+			MinValue:    scale.MinValue,
+			MaxValue:    scale.MaxValue,
+			Mappings:    mappingSets(scale),
+		})
 	}
 
 	return nil, output, nil
 }
+
+// mappingSets lists the metaphors of a scale, or its levels as the only
+// mapping when it has no metaphors.
+func mappingSets(scale domain.ProgressScale) []MappingSet {
+	metaphors := scale.Metaphors
+	if len(metaphors) == 0 && len(scale.Levels) > 0 {
+		metaphors = []domain.ScaleMetaphor{{Name: scale.Name, Levels: scale.Levels}}
+	}
+	sets := make([]MappingSet, 0, len(metaphors))
+	for _, m := range metaphors {
+		set := MappingSet{MappingName: m.Name, Values: make([]MappingValue, 0, len(m.Levels))}
+		for _, l := range m.Levels {
+			set.Values = append(set.Values, MappingValue{Word: l.Word, Value: l.Value, Emoji: l.Emoji})
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
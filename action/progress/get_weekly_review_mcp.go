@@ -0,0 +1,285 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+const maxNotablePoints = 5
+
+var GetWeeklyReviewMCPDefinition = mcp.Tool{
+	Name: "get_weekly_review",
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+		Title:          "Get weekly review",
+	},
+	Description: `Everything needed for a weekly review in one call: per-activity values and notes, highs and lows,
+finished activities and the change against the previous week.
+
+Use this tool when:
+- Running the Sunday / end-of-week reflection ("how did my week go?")
+- User asks to compare this week with the last one
+
+Parameters:
+- week: (optional) any date (YYYY-MM-DD) inside the week; weeks run Monday to Sunday (UTC). Default: current week
+- include_nutrition: (optional) add average calories and macros per logged day
+- include_workouts: (optional) add workout, set and volume counts
+
+Returns:
+- activities: every activity that ran during the week, with its points (value, quantity, note), count,
+  average in its own scale, previous week average, delta and trend (up/down/flat), quantity_total for measured ones
+- highs / lows: up to 5 points at +1 or better / -1 or worse on the -2..+2 scale (custom scales mapped onto it)
+- finished: activities finished during the week
+- average, previous_average, delta, trend: all points normalized to -2..+2
+
+Activities without points in the week are listed with count 0 — worth asking about.`,
+}
+
+type GetWeeklyReviewInput struct {
+	Week             string `json:"week,omitempty" jsonschema:"Any date inside the week, YYYY-MM-DD (default current week)"`
+	IncludeNutrition bool   `json:"include_nutrition,omitempty" jsonschema:"Add nutrition averages per logged day"`
+	IncludeWorkouts  bool   `json:"include_workouts,omitempty" jsonschema:"Add workout counts"`
+}
+
+type WeeklyPointOutput struct {
+	ID         int64    `json:"id" jsonschema:"Progress point ID"`
+	Value      int      `json:"value" jsonschema:"Progress value in the activity scale"`
+	Quantity   *float64 `json:"quantity,omitempty" jsonschema:"Measured amount in the activity unit"`
+	Note       string   `json:"note,omitempty" jsonschema:"Note of the point"`
+	ProgressAt string   `json:"progress_at" jsonschema:"When progress was made (ISO8601)"`
+}
+
+type WeeklyActivityOutput struct {
+	ActivityID      int64               `json:"activity_id" jsonschema:"Activity ID"`
+	Name            string              `json:"name" jsonschema:"Activity name"`
+	ProgressType    string              `json:"progress_type" jsonschema:"Progress type"`
+	Unit            string              `json:"unit,omitempty" jsonschema:"Unit of the measured quantity"`
+	Count           int                 `json:"count" jsonschema:"Points in the week"`
+	Average         *float64            `json:"average,omitempty" jsonschema:"Average value in the activity scale"`
+	PreviousCount   int                 `json:"previous_count" jsonschema:"Points in the previous week"`
+	PreviousAverage *float64            `json:"previous_average,omitempty" jsonschema:"Average value in the previous week"`
+	Delta           *float64            `json:"delta,omitempty" jsonschema:"average - previous_average"`
+	Trend           string              `json:"trend,omitempty" jsonschema:"up, down or flat; absent when a week has no points"`
+	QuantityTotal   *float64            `json:"quantity_total,omitempty" jsonschema:"Summed quantity of the week, measured activities only"`
+	Points          []WeeklyPointOutput `json:"points" jsonschema:"Points of the week, oldest first"`
+}
+
+type NotablePointOutput struct {
+	ActivityID   int64   `json:"activity_id" jsonschema:"Activity ID"`
+	ActivityName string  `json:"activity_name" jsonschema:"Activity name"`
+	PointID      int64   `json:"point_id" jsonschema:"Progress point ID"`
+	Value        int     `json:"value" jsonschema:"Progress value in the activity scale"`
+	Normalized   float64 `json:"normalized" jsonschema:"Value mapped onto -2..+2"`
+	Note         string  `json:"note,omitempty" jsonschema:"Note of the point"`
+	ProgressAt   string  `json:"progress_at" jsonschema:"When progress was made (ISO8601)"`
+}
+
+type FinishedActivityOutput struct {
+	ActivityID int64  `json:"activity_id" jsonschema:"Activity ID"`
+	Name       string `json:"name" jsonschema:"Activity name"`
+	EndedAt    string `json:"ended_at" jsonschema:"When it was finished (ISO8601)"`
+}
+
+type WeeklyNutritionOutput struct {
+	DaysLogged  int     `json:"days_logged" jsonschema:"Days with food logged"`
+	AvgCalories float64 `json:"avg_calories" jsonschema:"Average calories per logged day"`
+	AvgProtein  float64 `json:"avg_protein" jsonschema:"Average protein (g) per logged day"`
+	AvgFat      float64 `json:"avg_fat" jsonschema:"Average fat (g) per logged day"`
+	AvgCarbs    float64 `json:"avg_carbs" jsonschema:"Average carbohydrates (g) per logged day"`
+}
+
+type WeeklyWorkoutsOutput struct {
+	Workouts  int     `json:"workouts" jsonschema:"Workouts with sets in the week"`
+	Sets      int     `json:"sets" jsonschema:"Sets logged"`
+	Exercises int     `json:"exercises" jsonschema:"Distinct exercises"`
+	VolumeKg  float64 `json:"volume_kg" jsonschema:"Sum of reps x weight"`
+}
+
+type GetWeeklyReviewOutput struct {
+	From            string                   `json:"from" jsonschema:"Week start, Monday (ISO8601)"`
+	To              string                   `json:"to" jsonschema:"Week end, next Monday, exclusive (ISO8601)"`
+	Activities      []WeeklyActivityOutput   `json:"activities" jsonschema:"Activities that ran during the week"`
+	Highs           []NotablePointOutput     `json:"highs" jsonschema:"Best points, best first"`
+	Lows            []NotablePointOutput     `json:"lows" jsonschema:"Worst points, worst first"`
+	Finished        []FinishedActivityOutput `json:"finished" jsonschema:"Activities finished during the week"`
+	Average         *float64                 `json:"average,omitempty" jsonschema:"Average of all points on -2..+2"`
+	PreviousAverage *float64                 `json:"previous_average,omitempty" jsonschema:"Same for the previous week"`
+	Delta           *float64                 `json:"delta,omitempty" jsonschema:"average - previous_average"`
+	Trend           string                   `json:"trend,omitempty" jsonschema:"up, down or flat"`
+	Nutrition       *WeeklyNutritionOutput   `json:"nutrition,omitempty" jsonschema:"Nutrition averages, when requested"`
+	Workouts        *WeeklyWorkoutsOutput    `json:"workouts,omitempty" jsonschema:"Workout counts, when requested"`
+}
+
+func GetWeeklyReview(ctx context.Context, _ *mcp.CallToolRequest, input GetWeeklyReviewInput) (*mcp.CallToolResult, GetWeeklyReviewOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, GetWeeklyReviewOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, GetWeeklyReviewOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	day := time.Now().UTC()
+	if input.Week != "" {
+		var err error
+		day, err = time.Parse(time.DateOnly, input.Week)
+		if err != nil {
+			return nil, GetWeeklyReviewOutput{}, fmt.Errorf("invalid week format (use YYYY-MM-DD): %w", err)
+		}
+	}
+	from, to := domain.WeekOf(day)
+
+	// Active activities plus those finished during or after the week
+	activities, err := db.ListActivities(ctx, domain.ActivityFilter{UserID: userID, ActiveOnly: true})
+	if err != nil {
+		return nil, GetWeeklyReviewOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	finished, err := db.ListActivities(ctx, domain.ActivityFilter{UserID: userID})
+	if err != nil {
+		return nil, GetWeeklyReviewOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	activities = append(activities, finished...)
+
+	points, err := db.ListProgress(ctx, domain.ProgressFilter{UserID: userID, From: from.AddDate(0, 0, -7), To: to})
+	if err != nil {
+		return nil, GetWeeklyReviewOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	scales, err := db.ListProgressScales(ctx, userID)
+	if err != nil {
+		return nil, GetWeeklyReviewOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	review := domain.WeeklyReviewReport(activities, scales, points, from, to)
+	output := GetWeeklyReviewOutput{
+		From:            from.Format(time.RFC3339),
+		To:              to.Format(time.RFC3339),
+		Activities:      []WeeklyActivityOutput{},
+		Highs:           notablePointsToOutput(review.Highs),
+		Lows:            notablePointsToOutput(review.Lows),
+		Finished:        []FinishedActivityOutput{},
+		Average:         review.Average,
+		PreviousAverage: review.PreviousAverage,
+		Delta:           review.Delta,
+		Trend:           review.Trend,
+	}
+	for _, w := range review.Activities {
+		item := WeeklyActivityOutput{
+			ActivityID:      w.Activity.ID,
+			Name:            w.Activity.Name,
+			ProgressType:    string(w.Activity.ProgressType),
+			Unit:            w.Activity.Unit,
+			Count:           len(w.Points),
+			Average:         w.Average,
+			PreviousCount:   w.PreviousCount,
+			PreviousAverage: w.PreviousAverage,
+			Delta:           w.Delta,
+			Trend:           w.Trend,
+			QuantityTotal:   w.QuantityTotal,
+			Points:          make([]WeeklyPointOutput, len(w.Points)),
+		}
+		for i, p := range w.Points {
+			item.Points[i] = WeeklyPointOutput{
+				ID:         p.ID,
+				Value:      p.Value,
+				Quantity:   p.Quantity,
+				Note:       p.Note,
+				ProgressAt: p.ProgressAt.Format(time.RFC3339),
+			}
+		}
+		output.Activities = append(output.Activities, item)
+	}
+	for _, a := range review.Finished {
+		output.Finished = append(output.Finished, FinishedActivityOutput{
+			ActivityID: a.ID,
+			Name:       a.Name,
+			EndedAt:    a.EndedAt.Format(time.RFC3339),
+		})
+	}
+
+	if input.IncludeNutrition {
+		days, err := db.GetNutritionStats(ctx, domain.NutritionStatsFilter{
+			UserID:      userID,
+			From:        from,
+			To:          to.Add(-time.Second),
+			Aggregation: domain.AggregationTypeByDay,
+		})
+		if err != nil {
+			return nil, GetWeeklyReviewOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		output.Nutrition = weeklyNutrition(days)
+	}
+
+	if input.IncludeWorkouts {
+		sets, err := db.ListSets(ctx, userID, from, to.Add(-time.Second))
+		if err != nil {
+			return nil, GetWeeklyReviewOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		output.Workouts = weeklyWorkouts(sets)
+	}
+
+	return nil, output, nil
+}
+
+func notablePointsToOutput(points []domain.NotablePoint) []NotablePointOutput {
+	if len(points) > maxNotablePoints {
+		points = points[:maxNotablePoints]
+	}
+	out := make([]NotablePointOutput, len(points))
+	for i, n := range points {
+		out[i] = NotablePointOutput{
+			ActivityID:   n.Activity.ID,
+			ActivityName: n.Activity.Name,
+			PointID:      n.Point.ID,
+			Value:        n.Point.Value,
+			Normalized:   n.Normalized,
+			Note:         n.Point.Note,
+			ProgressAt:   n.Point.ProgressAt.Format(time.RFC3339),
+		}
+	}
+	return out
+}
+
+// weeklyNutrition averages the daily totals over the days with food logged.
+func weeklyNutrition(days []domain.NutritionStats) *WeeklyNutritionOutput {
+	out := &WeeklyNutritionOutput{DaysLogged: len(days)}
+	if len(days) == 0 {
+		return out
+	}
+	for _, d := range days {
+		out.AvgCalories += d.TotalCalories
+		out.AvgProtein += d.TotalProtein
+		out.AvgFat += d.TotalFat
+		out.AvgCarbs += d.TotalCarbs
+	}
+	n := float64(len(days))
+	out.AvgCalories = math.Round(out.AvgCalories / n)
+	out.AvgProtein = math.Round(out.AvgProtein/n*10) / 10
+	out.AvgFat = math.Round(out.AvgFat/n*10) / 10
+	out.AvgCarbs = math.Round(out.AvgCarbs/n*10) / 10
+	return out
+}
+
+func weeklyWorkouts(sets []domain.Set) *WeeklyWorkoutsOutput {
+	workouts := map[int64]bool{}
+	exercises := map[int64]bool{}
+	out := &WeeklyWorkoutsOutput{Sets: len(sets)}
+	for _, s := range sets {
+		workouts[s.WorkoutID] = true
+		exercises[s.ExerciseID] = true
+		out.VolumeKg += float64(s.Reps) * s.WeightKg
+	}
+	out.Workouts = len(workouts)
+	out.Exercises = len(exercises)
+	out.VolumeKg = math.Round(out.VolumeKg*10) / 10
+	return out
+}
@@ -0,0 +1,48 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+)
+
+var ListLifePartsMCPDefinition = mcp.Tool{
+	Name: "list_life_parts",
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+		Title:        "List life parts",
+	},
+	Description: `List the user's life areas ordered by name, with their IDs for life_part_ids.`,
+}
+
+type ListLifePartsInput struct{}
+
+type ListLifePartsOutput struct {
+	LifeParts []LifePartResult `json:"life_parts" jsonschema:"Life areas"`
+}
+
+func ListLifeParts(ctx context.Context, _ *mcp.CallToolRequest, _ ListLifePartsInput) (*mcp.CallToolResult, ListLifePartsOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, ListLifePartsOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, ListLifePartsOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	parts, err := db.ListLifeParts(ctx, userID)
+	if err != nil {
+		return nil, ListLifePartsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	output := ListLifePartsOutput{LifeParts: make([]LifePartResult, 0, len(parts))}
+	for _, p := range parts {
+		output.LifeParts = append(output.LifeParts, lifePartToResult(p))
+	}
+	return nil, output, nil
+}
@@ -0,0 +1,76 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+const (
+	defaultMinNoteSimilarity = 0.5
+	noteEmbedBatch           = 32
+	// Notes are embedded lazily before the first page of a search; a long
+	// backlog is caught up over several searches.
+	maxNotesEmbeddedPerSearch = 256
+)
+
+// embedQueries returns one vector per query. With index set it first brings
+// the user's note embeddings up to date.
+func embedQueries(ctx context.Context, db gateways.DB, embedder gateways.Embedder, userID int64, queries []string, index bool) ([][]float32, error) {
+	if index {
+		if err := indexNotes(ctx, db, embedder, userID, maxNotesEmbeddedPerSearch); err != nil {
+			return nil, fmt.Errorf("index notes: %w", err)
+		}
+	}
+
+	vectors, err := embedder.Embed(ctx, queries)
+	if err != nil {
+		return nil, fmt.Errorf("embed queries: %w", err)
+	}
+	if len(vectors) != len(queries) {
+		return nil, fmt.Errorf("got %d vectors for %d queries", len(vectors), len(queries))
+	}
+	return vectors, nil
+}
+
+// indexNotes embeds up to limit notes that have no embedding from the current
+// model, or one of an older text.
+func indexNotes(ctx context.Context, db gateways.DB, embedder gateways.Embedder, userID int64, limit int) error {
+	model := embedder.Model()
+	points, err := db.ListNotesToEmbed(ctx, userID, model, limit)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(points); start += noteEmbedBatch {
+		batch := points[start:min(start+noteEmbedBatch, len(points))]
+		texts := make([]string, len(batch))
+		for i, p := range batch {
+			texts[i] = p.Note
+		}
+
+		vectors, err := embedder.Embed(ctx, texts)
+		if err != nil {
+			return err
+		}
+		if len(vectors) != len(batch) {
+			return fmt.Errorf("got %d vectors for %d notes", len(vectors), len(batch))
+		}
+
+		for i, p := range batch {
+			err := db.SaveNoteEmbedding(ctx, domain.NoteEmbedding{
+				PointID: p.ID,
+				UserID:  p.UserID,
+				Model:   model,
+				Note:    p.Note,
+				Vector:  vectors[i],
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
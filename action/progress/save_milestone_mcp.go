@@ -0,0 +1,157 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+const maxMilestoneName = 200
+
+var SaveMilestoneMCPDefinition = mcp.Tool{
+	Name: "save_milestone",
+	Annotations: &mcp.ToolAnnotations{
+		Title: "Create or update milestone",
+	},
+	Description: `Create a milestone of an activity, or update one: rename it, move its due date, tick it off.
+
+Use this tool when:
+- User breaks a project into checkpoints ("first draft by March 1st")
+- User reports a checkpoint is done ("the draft is finished")
+
+Create (milestone_id omitted):
+- activity_id: required, get from get_activity_list
+- name: required
+- due_at: (optional) due date/time (ISO8601)
+- done: (optional) create it already done
+
+Update (milestone_id set; omitted fields keep their value):
+- name, due_at (pass empty string "" to remove the due date), done (true sets done_at to now, false reopens)
+
+Milestones show up in get_activity_stats with done/overdue flags.`,
+}
+
+type SaveMilestoneInput struct {
+	MilestoneID int64   `json:"milestone_id,omitempty" jsonschema:"Milestone ID to update (omit to create)"`
+	ActivityID  int64   `json:"activity_id,omitempty" jsonschema:"Activity ID, required when creating"`
+	Name        *string `json:"name,omitempty" jsonschema:"Milestone name, required when creating"`
+	DueAt       *string `json:"due_at,omitempty" jsonschema:"Due date/time (ISO8601), empty string removes it"`
+	Done        *bool   `json:"done,omitempty" jsonschema:"Mark done (true) or open (false)"`
+}
+
+type MilestoneResult struct {
+	ID         int64  `json:"id" jsonschema:"Milestone ID"`
+	ActivityID int64  `json:"activity_id" jsonschema:"Activity ID"`
+	Name       string `json:"name" jsonschema:"Milestone name"`
+	DueAt      string `json:"due_at,omitempty" jsonschema:"Due date/time (ISO8601)"`
+	Done       bool   `json:"done" jsonschema:"Whether the milestone is reached"`
+	DoneAt     string `json:"done_at,omitempty" jsonschema:"When it was reached (ISO8601)"`
+	Overdue    bool   `json:"overdue" jsonschema:"Open and past its due date"`
+}
+
+type SaveMilestoneOutput struct {
+	Milestone MilestoneResult `json:"milestone" jsonschema:"Saved milestone"`
+}
+
+func SaveMilestone(ctx context.Context, _ *mcp.CallToolRequest, input SaveMilestoneInput) (*mcp.CallToolResult, SaveMilestoneOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, SaveMilestoneOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, SaveMilestoneOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	now := time.Now()
+	var milestone domain.Milestone
+	if input.MilestoneID == 0 {
+		if input.Name == nil {
+			return nil, SaveMilestoneOutput{}, fmt.Errorf("name is required")
+		}
+		activity, err := db.GetActivity(ctx, input.ActivityID, userID)
+		if err != nil {
+			return nil, SaveMilestoneOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		if activity == nil {
+			return nil, SaveMilestoneOutput{}, fmt.Errorf("activity not found")
+		}
+		milestone = domain.Milestone{UserID: userID, ActivityID: activity.ID}
+	} else {
+		existing, err := db.GetMilestone(ctx, userID, input.MilestoneID)
+		if err != nil {
+			return nil, SaveMilestoneOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		if existing == nil {
+			return nil, SaveMilestoneOutput{}, fmt.Errorf("milestone not found")
+		}
+		if input.ActivityID != 0 && input.ActivityID != existing.ActivityID {
+			return nil, SaveMilestoneOutput{}, fmt.Errorf("milestone %d belongs to activity %d", existing.ID, existing.ActivityID)
+		}
+		milestone = *existing
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, SaveMilestoneOutput{}, fmt.Errorf("name cannot be empty")
+		}
+		if utf8.RuneCountInString(name) > maxMilestoneName {
+			return nil, SaveMilestoneOutput{}, fmt.Errorf("name must be at most %d characters", maxMilestoneName)
+		}
+		milestone.Name = name
+	}
+	if input.DueAt != nil {
+		milestone.DueAt = nil
+		if *input.DueAt != "" {
+			t, err := time.Parse(time.RFC3339, *input.DueAt)
+			if err != nil {
+				return nil, SaveMilestoneOutput{}, fmt.Errorf("invalid due_at format, expected RFC3339: %w", err)
+			}
+			milestone.DueAt = &t
+		}
+	}
+	if input.Done != nil {
+		switch {
+		case !*input.Done:
+			milestone.DoneAt = nil
+		case milestone.DoneAt == nil:
+			milestone.DoneAt = &now
+		}
+	}
+
+	found, err := db.SaveMilestone(ctx, &milestone)
+	if err != nil {
+		return nil, SaveMilestoneOutput{}, fmt.Errorf("failed to save milestone: %w", err)
+	}
+	if !found {
+		return nil, SaveMilestoneOutput{}, fmt.Errorf("milestone not found")
+	}
+
+	return nil, SaveMilestoneOutput{Milestone: milestoneToResult(milestone, now)}, nil
+}
+
+func milestoneToResult(m domain.Milestone, now time.Time) MilestoneResult {
+	result := MilestoneResult{
+		ID:         m.ID,
+		ActivityID: m.ActivityID,
+		Name:       m.Name,
+		Done:       m.Done(),
+		Overdue:    m.Overdue(now),
+	}
+	if m.DueAt != nil {
+		result.DueAt = m.DueAt.Format(time.RFC3339)
+	}
+	if m.DoneAt != nil {
+		result.DoneAt = m.DoneAt.Format(time.RFC3339)
+	}
+	return result
+}
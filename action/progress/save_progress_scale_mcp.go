@@ -0,0 +1,160 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+var SaveProgressScaleMCPDefinition = mcp.Tool{
+	Name: "save_progress_scale",
+	Annotations: &mcp.ToolAnnotations{
+		Title: "Create or update progress scale",
+	},
+	Description: `Define a custom progress scale: a numeric range, named levels with emoji, and metaphor word sets.
+
+Use this tool when:
+- The built-in -2..+2 scales don't fit ("rate my sleep 1 to 10", "energy 0-5")
+- User wants their own words or emoji for levels
+
+Saving a scale with an existing name replaces its definition. A narrower
+range is rejected while points of activities using the scale fall outside it.
+Attach it to activities with create_activity or edit_activity (scale_id).
+
+Required inputs:
+- name: Scale name, unique per user
+- min_value, max_value: Integer range, at most 100 values apart
+
+Optional inputs:
+- description
+- levels: [{value, word, emoji}] — names shown in stats and emoji on the dashboard
+- metaphors: [{name, levels: [{value, word, emoji}]}] — alternative word sets offered by get_progress_type_examples
+
+Every level value must lie inside the range and appear at most once per set.`,
+}
+
+type SaveProgressScaleInput struct {
+	Name        string                 `json:"name" jsonschema:"Scale name, unique per user"`
+	Description string                 `json:"description,omitempty" jsonschema:"What the scale measures"`
+	MinValue    int                    `json:"min_value" jsonschema:"Lowest value"`
+	MaxValue    int                    `json:"max_value" jsonschema:"Highest value"`
+	Levels      []domain.ScaleLevel    `json:"levels,omitempty" jsonschema:"Named levels with emoji"`
+	Metaphors   []domain.ScaleMetaphor `json:"metaphors,omitempty" jsonschema:"Metaphor word sets"`
+}
+
+type ScaleOutput struct {
+	ID          int64                  `json:"id,omitempty" jsonschema:"Scale ID, absent for built-in scales"`
+	Name        string                 `json:"name" jsonschema:"Scale name (progress type for built-in scales)"`
+	Description string                 `json:"description,omitempty" jsonschema:"Scale description"`
+	MinValue    int                    `json:"min_value" jsonschema:"Lowest value"`
+	MaxValue    int                    `json:"max_value" jsonschema:"Highest value"`
+	Levels      []domain.ScaleLevel    `json:"levels" jsonschema:"Named levels with emoji"`
+	Metaphors   []domain.ScaleMetaphor `json:"metaphors,omitempty" jsonschema:"Metaphor word sets"`
+}
+
+type SaveProgressScaleOutput struct {
+	Scale ScaleOutput `json:"scale" jsonschema:"Saved scale"`
+}
+
+func SaveProgressScale(ctx context.Context, _ *mcp.CallToolRequest, input SaveProgressScaleInput) (*mcp.CallToolResult, SaveProgressScaleOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, SaveProgressScaleOutput{}, fmt.Errorf("database not available in context")
+	}
+
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, SaveProgressScaleOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	scale := &domain.ProgressScale{
+		UserID:      userID,
+		Name:        strings.TrimSpace(input.Name),
+		Description: strings.TrimSpace(input.Description),
+		MinValue:    input.MinValue,
+		MaxValue:    input.MaxValue,
+		Levels:      input.Levels,
+		Metaphors:   input.Metaphors,
+	}
+	if err := scale.Validate(); err != nil {
+		return nil, SaveProgressScaleOutput{}, err
+	}
+
+	if err := db.SaveProgressScale(ctx, scale); err != nil {
+		return nil, SaveProgressScaleOutput{}, fmt.Errorf("failed to save progress scale: %w", err)
+	}
+
+	return nil, SaveProgressScaleOutput{Scale: scaleToOutput(*scale)}, nil
+}
+
+func scaleToOutput(s domain.ProgressScale) ScaleOutput {
+	levels := s.Levels
+	if levels == nil {
+		levels = []domain.ScaleLevel{}
+	}
+	return ScaleOutput{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		MinValue:    s.MinValue,
+		MaxValue:    s.MaxValue,
+		Levels:      levels,
+		Metaphors:   s.Metaphors,
+	}
+}
+
+// activityScale resolves the scale values of an activity are checked against.
+func activityScale(ctx context.Context, db gateways.DB, userID int64, a *domain.Activity) (domain.ProgressScale, error) {
+	if a.ScaleID == nil {
+		return domain.BuiltinScale(a.ProgressType), nil
+	}
+	scales, err := db.ListProgressScales(ctx, userID)
+	if err != nil {
+		return domain.ProgressScale{}, fmt.Errorf("database error: %w", err)
+	}
+	return domain.ScaleOf(*a, scales), nil
+}
+
+// checkScaleID fails when id is not one of the user's scales.
+func checkScaleID(ctx context.Context, db gateways.DB, userID, id int64) error {
+	scales, err := db.ListProgressScales(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	for _, s := range scales {
+		if s.ID == id {
+			return nil
+		}
+	}
+	return fmt.Errorf("progress scale %d not found", id)
+}
+
+// checkPointsFitScale fails when existing points of the activity lie outside
+// the scale it is being switched to.
+func checkPointsFitScale(ctx context.Context, db gateways.DB, userID int64, a *domain.Activity) error {
+	scale, err := activityScale(ctx, db, userID, a)
+	if err != nil {
+		return err
+	}
+	outside, err := db.CountProgressOutsideRange(ctx, userID, a.ID, scale.MinValue, scale.MaxValue)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if outside > 0 {
+		return fmt.Errorf("%d progress points are outside %+d..%+d of scale %s; edit them first", outside, scale.MinValue, scale.MaxValue, scale.Name)
+	}
+	return nil
+}
+
+// checkValue fails when value is outside the scale.
+func checkValue(scale domain.ProgressScale, value int) error {
+	if !scale.Contains(value) {
+		return fmt.Errorf("value must be between %+d and %+d for scale %s", scale.MinValue, scale.MaxValue, scale.Name)
+	}
+	return nil
+}
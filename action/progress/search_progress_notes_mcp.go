@@ -3,25 +3,29 @@ package progress
 import (
 	"context"
 	"fmt"
-	"sort"
+	"log"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"personal/domain"
 	"personal/gateways"
+	"personal/util"
 )
 
 var SearchProgressNotesMCPDefinition = mcp.Tool{
 	Name: "search_progress_notes",
 	Annotations: &mcp.ToolAnnotations{
-		ReadOnlyHint:   true,
-		IdempotentHint: true,
-		Title:          "Search progress notes by keyword variants",
+		DestructiveHint: util.Ptr(false),
+		Title:           "Search progress notes by keyword variants",
 	},
 	Description: `Search across all progress point notes using 1-5 keyword variants (case-insensitive).
 
-Returns matching progress points ranked by how many variants matched, with activity name included.
+Words are matched with Russian and English stemming, so "тренировка" finds "тренировки" and "run" finds "running";
+substrings still match as a fallback. When semantic search is enabled on the server, notes close in meaning match too,
+so "burnout" finds "exhausted and overwhelmed". The first page embeds notes that are not indexed yet.
+
+Returns matching progress points ranked by relevance, with activity name included.
 
 Use this tool when:
 - User wants to find past reflections mentioning a topic ("show me when I wrote about stress")
@@ -29,14 +33,21 @@ Use this tool when:
 - Reviewing notes for a specific activity
 
 Parameters:
-- query_variants: 1-5 search terms, each does ILIKE match on note field
+- query_variants: 1-5 search terms or phrases; "quotes" and -exclusions work as in web search
 - activity_id: (optional) filter to a specific activity
 - from: (optional) ISO8601 start date for progress_at
 - to: (optional) ISO8601 end date for progress_at
 - value_min: (optional) filter by minimum value (-2 to +2)
 - value_max: (optional) filter by maximum value (-2 to +2)
-
-Returns results ranked by match_count DESC, then progress_at DESC.
+- limit: (optional) page size, default 50
+- cursor: (optional) next_cursor of the previous page
+- min_similarity: (optional) semantic match threshold, cosine similarity 0-1, default 0.5
+
+Returns results ranked by match_count DESC, then rank DESC (full-text ts_rank summed over variants plus semantic similarity),
+then progress_at DESC.
+match_count says how many variants matched; semantic tells whether semantic matching was used.
+next_cursor is set while more results remain; later pages keep the first page's ranking, so a cursor from a semantic search
+is rejected when that embedding model is no longer available.
 Returns error field (not Go error) for validation failures.`,
 }
 
@@ -47,6 +58,9 @@ type SearchProgressNotesInput struct {
 	To            string   `json:"to,omitempty" jsonschema:"ISO8601 end date filter for progress_at"`
 	ValueMin      *int     `json:"value_min,omitempty" jsonschema:"Minimum value filter (-2 to +2)"`
 	ValueMax      *int     `json:"value_max,omitempty" jsonschema:"Maximum value filter (-2 to +2)"`
+	Limit         int      `json:"limit,omitempty" jsonschema:"Maximum number of results (default 50)"`
+	Cursor        string   `json:"cursor,omitempty" jsonschema:"next_cursor of the previous page"`
+	MinSimilarity *float64 `json:"min_similarity,omitempty" jsonschema:"Semantic match threshold, cosine similarity 0-1 (default 0.5)"`
 }
 
 type NoteSearchResult struct {
@@ -58,11 +72,15 @@ type NoteSearchResult struct {
 	Note         string   `json:"note" jsonschema:"Note text"`
 	ProgressAt   string   `json:"progress_at" jsonschema:"When progress was made (ISO8601)"`
 	MatchCount   int      `json:"match_count" jsonschema:"Number of query variants that matched this note"`
+	Rank         float64  `json:"rank" jsonschema:"Relevance, higher is better"`
+	Similarity   *float64 `json:"similarity,omitempty" jsonschema:"Best semantic similarity to a variant, when semantic search is used"`
 }
 
 type SearchProgressNotesOutput struct {
-	Results []NoteSearchResult `json:"results" jsonschema:"Matching progress notes ranked by match count"`
-	Error   string             `json:"error,omitempty" jsonschema:"Validation error message if any"`
+	Results    []NoteSearchResult `json:"results" jsonschema:"Matching progress notes ranked by relevance"`
+	NextCursor string             `json:"next_cursor,omitempty" jsonschema:"Cursor for the next page of results"`
+	Semantic   bool               `json:"semantic" jsonschema:"Whether semantic matching was used"`
+	Error      string             `json:"error,omitempty" jsonschema:"Validation error message if any"`
 }
 
 func SearchProgressNotes(ctx context.Context, _ *mcp.CallToolRequest, input SearchProgressNotesInput) (*mcp.CallToolResult, SearchProgressNotesOutput, error) {
@@ -103,52 +121,95 @@ func SearchProgressNotes(ctx context.Context, _ *mcp.CallToolRequest, input Sear
 		}
 	}
 
-	// Search per variant, deduplicate by point ID with match count
-	matches := make(map[int64]*NoteSearchResult)
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 50
+	}
 
-	for _, variant := range input.QueryVariants {
-		points, err := db.SearchProgressNotes(ctx, domain.ProgressNoteSearchFilter{
-			UserID:     userID,
-			Query:      variant,
-			ActivityID: input.ActivityID,
-			From:       from,
-			To:         to,
-			ValueMin:   input.ValueMin,
-			ValueMax:   input.ValueMax,
-		})
+	var after *domain.Cursor
+	if input.Cursor != "" {
+		after, err = domain.DecodeCursor(input.Cursor, domain.CursorProgressNotes)
 		if err != nil {
-			return nil, SearchProgressNotesOutput{}, fmt.Errorf("search failed: %w", err)
+			return nil, SearchProgressNotesOutput{Error: fmt.Sprintf("invalid cursor: %v", err)}, nil
 		}
+	}
 
-		for _, p := range points {
-			if m, ok := matches[p.ID]; ok {
-				m.MatchCount++
-			} else {
-				matches[p.ID] = &NoteSearchResult{
-					ID:           p.ID,
-					ActivityID:   p.ActivityID,
-					ActivityName: p.ActivityName,
-					Value:        p.Value,
-					HoursLeft:    p.HoursLeft,
-					Note:         p.Note,
-					ProgressAt:   p.ProgressAt.Format(time.RFC3339),
-					MatchCount:   1,
-				}
-			}
+	minSimilarity := defaultMinNoteSimilarity
+	if input.MinSimilarity != nil {
+		minSimilarity = *input.MinSimilarity
+		if minSimilarity < 0 || minSimilarity > 1 {
+			return nil, SearchProgressNotesOutput{Error: "min_similarity must be between 0 and 1"}, nil
 		}
 	}
 
-	results := make([]NoteSearchResult, 0, len(matches))
-	for _, m := range matches {
-		results = append(results, *m)
+	// One extra row tells whether another page follows
+	filter := domain.ProgressNoteSearchFilter{
+		UserID:        userID,
+		Queries:       input.QueryVariants,
+		MinSimilarity: minSimilarity,
+		ActivityID:    input.ActivityID,
+		From:          from,
+		To:            to,
+		ValueMin:      input.ValueMin,
+		ValueMax:      input.ValueMax,
+		After:         after,
+		Limit:         limit + 1,
 	}
 
-	sort.Slice(results, func(i, j int) bool {
-		if results[i].MatchCount != results[j].MatchCount {
-			return results[i].MatchCount > results[j].MatchCount
+	// Semantic matching is best effort on the first page: without a working
+	// embedding server the search stays full-text only. Later pages rank
+	// the way the first one did, and only the first page indexes notes.
+	embedder := gateways.EmbedderFromContext(ctx)
+	if after != nil && after.Model != "" && (embedder == nil || embedder.Model() != after.Model) {
+		return nil, SearchProgressNotesOutput{Error: fmt.Sprintf("cursor was ranked with embedding model %s, which is not available; start a new search", after.Model)}, nil
+	}
+	if embedder != nil && (after == nil || after.Model != "") {
+		vectors, err := embedQueries(ctx, db, embedder, userID, input.QueryVariants, after == nil)
+		switch {
+		case err != nil && after != nil:
+			return nil, SearchProgressNotesOutput{}, fmt.Errorf("semantic note search: %w", err)
+		case err != nil:
+			log.Printf("semantic note search: %v", err)
+		default:
+			filter.Embeddings = vectors
+			filter.EmbeddingModel = embedder.Model()
 		}
-		return results[i].ProgressAt > results[j].ProgressAt
-	})
+	}
+
+	points, err := db.SearchProgressNotes(ctx, filter)
+	if err != nil {
+		return nil, SearchProgressNotesOutput{}, fmt.Errorf("search failed: %w", err)
+	}
+
+	output := SearchProgressNotesOutput{Semantic: filter.Embeddings != nil}
+	if len(points) > limit {
+		points = points[:limit]
+		last := points[limit-1]
+		output.NextCursor = domain.Cursor{
+			Kind:  domain.CursorProgressNotes,
+			Count: last.MatchCount,
+			Rank:  last.Rank,
+			Model: filter.EmbeddingModel,
+			At:    last.ProgressAt,
+			ID:    last.ID,
+		}.Encode()
+	}
+
+	output.Results = make([]NoteSearchResult, 0, len(points))
+	for _, p := range points {
+		output.Results = append(output.Results, NoteSearchResult{
+			ID:           p.ID,
+			ActivityID:   p.ActivityID,
+			ActivityName: p.ActivityName,
+			Value:        p.Value,
+			HoursLeft:    p.HoursLeft,
+			Note:         p.Note,
+			ProgressAt:   p.ProgressAt.Format(time.RFC3339),
+			MatchCount:   p.MatchCount,
+			Rank:         p.Rank,
+			Similarity:   p.Similarity,
+		})
+	}
 
-	return nil, SearchProgressNotesOutput{Results: results}, nil
+	return nil, output, nil
 }
@@ -0,0 +1,100 @@
+package recurring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+const (
+	defaultLookbackMonths = 24
+	defaultAmountTolPct   = 15
+)
+
+var DetectRecurringMCPDefinition = mcp.Tool{
+	Name: "detect_recurring",
+	Description: "Scan expense history per merchant for weekly, monthly and annual series (subscriptions, rent, fixed costs) and store them as recurring items, replacing the previous detection. " +
+		"Runs automatically after every import; list results with list_recurring.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Detect recurring transactions",
+	},
+}
+
+// DetectRecurringInput is the MCP tool input.
+type DetectRecurringInput struct {
+	LookbackMonths     int     `json:"lookback_months,omitempty" jsonschema:"How much history to scan (default 24)"`
+	AmountTolerancePct float64 `json:"amount_tolerance_pct,omitempty" jsonschema:"Charges within this % of the typical amount belong to one series (default 15)"`
+}
+
+// DetectRecurringOutput is the MCP tool output.
+type DetectRecurringOutput struct {
+	DetectedCount int                   `json:"detected_count"`
+	Items         []RecurringItemOutput `json:"items"`
+	Error         string                `json:"error,omitempty"`
+}
+
+func DetectRecurring(ctx context.Context, _ *mcp.CallToolRequest, input DetectRecurringInput) (*mcp.CallToolResult, DetectRecurringOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, DetectRecurringOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, DetectRecurringOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	if input.LookbackMonths < 0 || input.AmountTolerancePct < 0 {
+		return nil, DetectRecurringOutput{Error: "lookback_months and amount_tolerance_pct must not be negative"}, nil
+	}
+	lookback := input.LookbackMonths
+	if lookback == 0 {
+		lookback = defaultLookbackMonths
+	}
+	opts := domain.RecurringDetectOptions{AmountTolPct: input.AmountTolerancePct}
+	if opts.AmountTolPct == 0 {
+		opts.AmountTolPct = defaultAmountTolPct
+	}
+
+	now := time.Now().UTC()
+	items, err := detect(ctx, db, userID, now.AddDate(0, -lookback, 0), now, opts)
+	if err != nil {
+		return nil, DetectRecurringOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	out := DetectRecurringOutput{
+		DetectedCount: len(items),
+		Items:         make([]RecurringItemOutput, len(items)),
+	}
+	for i, it := range items {
+		out.Items[i] = toOutput(it, now)
+	}
+	return nil, out, nil
+}
+
+// Refresh re-runs detection over the default lookback with default options.
+// Called after imports so list_recurring stays current.
+func Refresh(ctx context.Context, db gateways.DB, userID int64) (int, error) {
+	now := time.Now().UTC()
+	items, err := detect(ctx, db, userID, now.AddDate(0, -defaultLookbackMonths, 0), now,
+		domain.RecurringDetectOptions{AmountTolPct: defaultAmountTolPct})
+	return len(items), err
+}
+
+func detect(ctx context.Context, db gateways.DB, userID int64, from, to time.Time, opts domain.RecurringDetectOptions) ([]domain.RecurringItem, error) {
+	txs, err := db.ListUnpairedTransactions(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	items := domain.DetectRecurring(txs, opts)
+	if err := db.ReplaceRecurringItems(ctx, userID, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,134 @@
+package recurring
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+var ListRecurringMCPDefinition = mcp.Tool{
+	Name: "list_recurring",
+	Description: "Subscriptions and fixed costs found by detect_recurring, with cadence, typical amount and predicted next charge date. " +
+		"Flags price increases and status: active, missed (expected charge overdue) or cancelled (two expected charges never came). " +
+		"monthly_totals sums active items per currency, normalized to one month.",
+}
+
+// ListRecurringInput is the MCP tool input.
+type ListRecurringInput struct {
+	Status             string `json:"status,omitempty" jsonschema:"Filter: active, missed or cancelled"`
+	PriceIncreasedOnly bool   `json:"price_increased_only,omitempty" jsonschema:"Only items whose latest charge went up"`
+}
+
+// RecurringItemOutput is one recurring item with status computed at request time.
+type RecurringItemOutput struct {
+	ID             int64     `json:"id"`
+	Merchant       string    `json:"merchant"`
+	Account        string    `json:"account"`
+	Category       string    `json:"category"`
+	Cadence        string    `json:"cadence"`
+	Currency       string    `json:"currency"`
+	TypicalAmount  float64   `json:"typical_amount"`
+	LastAmount     float64   `json:"last_amount"`
+	PreviousAmount float64   `json:"previous_amount"`
+	PriceIncreased bool      `json:"price_increased"`
+	PriceChangePct float64   `json:"price_change_pct"`
+	Occurrences    int       `json:"occurrences"`
+	FirstChargedAt time.Time `json:"first_charged_at"`
+	LastChargedAt  time.Time `json:"last_charged_at"`
+	NextChargeAt   time.Time `json:"next_charge_at"`
+	DaysUntilNext  int       `json:"days_until_next"`
+	Status         string    `json:"status"`
+}
+
+// ListRecurringOutput is the MCP tool output.
+type ListRecurringOutput struct {
+	Items         []RecurringItemOutput `json:"items"`
+	MonthlyTotals map[string]float64    `json:"monthly_totals"`
+	Error         string                `json:"error,omitempty"`
+}
+
+func ListRecurring(ctx context.Context, _ *mcp.CallToolRequest, input ListRecurringInput) (*mcp.CallToolResult, ListRecurringOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, ListRecurringOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, ListRecurringOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	switch domain.RecurringStatus(input.Status) {
+	case "", domain.RecurringActive, domain.RecurringMissed, domain.RecurringCancelled:
+	default:
+		return nil, ListRecurringOutput{Error: "status must be one of: active, missed, cancelled"}, nil
+	}
+
+	items, err := db.ListRecurringItems(ctx, userID)
+	if err != nil {
+		return nil, ListRecurringOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	now := time.Now().UTC()
+	out := ListRecurringOutput{
+		Items:         []RecurringItemOutput{},
+		MonthlyTotals: map[string]float64{},
+	}
+	for _, it := range items {
+		item := toOutput(it, now)
+		if input.Status != "" && item.Status != input.Status {
+			continue
+		}
+		if input.PriceIncreasedOnly && !item.PriceIncreased {
+			continue
+		}
+		out.Items = append(out.Items, item)
+		if item.Status == string(domain.RecurringActive) {
+			out.MonthlyTotals[it.Currency] = math.Round((out.MonthlyTotals[it.Currency]+monthlyAmount(it))*100) / 100
+		}
+	}
+	return nil, out, nil
+}
+
+// monthlyAmount normalizes the latest charge to one month.
+func monthlyAmount(it domain.RecurringItem) float64 {
+	switch it.Cadence {
+	case domain.CadenceWeekly:
+		return it.LastAmount * 52 / 12
+	case domain.CadenceAnnual:
+		return it.LastAmount / 12
+	default:
+		return it.LastAmount
+	}
+}
+
+func toOutput(it domain.RecurringItem, now time.Time) RecurringItemOutput {
+	changePct := 0.0
+	if it.PreviousAmount > 0 {
+		changePct = math.Round((it.LastAmount-it.PreviousAmount)/it.PreviousAmount*1000) / 10
+	}
+	return RecurringItemOutput{
+		ID:             it.ID,
+		Merchant:       it.Merchant,
+		Account:        it.Account,
+		Category:       it.Category,
+		Cadence:        string(it.Cadence),
+		Currency:       it.Currency,
+		TypicalAmount:  it.TypicalAmount,
+		LastAmount:     it.LastAmount,
+		PreviousAmount: it.PreviousAmount,
+		PriceIncreased: it.PriceIncreased(),
+		PriceChangePct: changePct,
+		Occurrences:    it.Occurrences,
+		FirstChargedAt: it.FirstChargedAt,
+		LastChargedAt:  it.LastChargedAt,
+		NextChargeAt:   it.NextChargeAt,
+		DaysUntilNext:  int(math.Floor(it.NextChargeAt.Sub(now).Hours() / 24)),
+		Status:         string(it.Status(now)),
+	}
+}
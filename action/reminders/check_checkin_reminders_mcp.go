@@ -0,0 +1,81 @@
+package reminders
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+var CheckCheckInRemindersMCPDefinition = mcp.Tool{
+	Name: "check_checkin_reminders",
+	Description: "List the activities due for a check-in now and raise today's reminder if it has not fired yet. " +
+		"The server runs the same check every minute for users with reminders enabled; use this to see what is due or to test the channel.",
+	Annotations: &mcp.ToolAnnotations{
+		Title: "Check check-in reminders",
+	},
+}
+
+// CheckCheckInRemindersInput is the MCP tool input.
+type CheckCheckInRemindersInput struct {
+	At *time.Time `json:"at,omitempty" jsonschema:"Evaluate as of this time (default now)"`
+}
+
+// DueActivityOutput is an activity waiting for a check-in.
+type DueActivityOutput struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FrequencyDays int    `json:"frequency_days"`
+	LastPointAt   string `json:"last_point_at,omitempty"`
+}
+
+// CheckCheckInRemindersOutput is the MCP tool output.
+type CheckCheckInRemindersOutput struct {
+	Settings     CheckInRemindersOutput `json:"settings"`
+	Due          []DueActivityOutput    `json:"due"`
+	Notification *domain.Notification   `json:"notification,omitempty"`
+}
+
+func CheckCheckInReminders(ctx context.Context, _ *mcp.CallToolRequest, input CheckCheckInRemindersInput) (*mcp.CallToolResult, CheckCheckInRemindersOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, CheckCheckInRemindersOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, CheckCheckInRemindersOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	at := time.Now()
+	if input.At != nil {
+		at = *input.At
+	}
+
+	settings, err := loadSettings(ctx, db, userID)
+	if err != nil {
+		return nil, CheckCheckInRemindersOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	activities, err := db.ListActivities(ctx, domain.ActivityFilter{UserID: userID, ActiveOnly: true})
+	if err != nil {
+		return nil, CheckCheckInRemindersOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	output := CheckCheckInRemindersOutput{Settings: settingsToOutput(settings), Due: []DueActivityOutput{}}
+	for _, a := range domain.DueActivities(activities, at, settings.Location()) {
+		item := DueActivityOutput{ID: a.ID, Name: a.Name, FrequencyDays: a.FrequencyDays}
+		if a.LastPointAt != nil {
+			item.LastPointAt = a.LastPointAt.Format(time.RFC3339)
+		}
+		output.Due = append(output.Due, item)
+	}
+
+	output.Notification, err = Check(ctx, db, settings, at)
+	if err != nil {
+		return nil, CheckCheckInRemindersOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	return nil, output, nil
+}
@@ -0,0 +1,127 @@
+package reminders
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"personal/action/notifications"
+	"personal/domain"
+	"personal/gateways"
+	"personal/gateways/notify"
+)
+
+// Run checks reminders of every user with them enabled once per interval
+// until ctx is done. A failing user is logged and does not stop the others.
+func Run(ctx context.Context, db gateways.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := Tick(ctx, db, time.Now()); err != nil {
+			log.Printf("check-in reminders: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick runs Check for every user with reminders enabled and returns the
+// reminders raised. The first error is returned after all users ran.
+func Tick(ctx context.Context, db gateways.DB, now time.Time) ([]domain.Notification, error) {
+	all, err := db.ListEnabledReminderSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var raised []domain.Notification
+	var firstErr error
+	for _, s := range all {
+		n, err := Check(ctx, db, s, now)
+		if err != nil {
+			log.Printf("check-in reminders for user %d: %v", s.UserID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if n != nil {
+			raised = append(raised, *n)
+		}
+	}
+	return raised, firstErr
+}
+
+// maxDeliveryAttempts caps how often a reminder is sent before it is left
+// in the inbox only.
+const maxDeliveryAttempts = 5
+
+// Check stores today's reminder of the user when it is due and not sent yet,
+// then delivers it through the configured channel. The notification's dedup
+// key makes it fire once per local day across restarts; delivery failures are
+// recorded on the notification, not returned, and retried on later checks
+// until maxDeliveryAttempts.
+func Check(ctx context.Context, db gateways.DB, s domain.ReminderSettings, now time.Time) (*domain.Notification, error) {
+	if err := retryUndelivered(ctx, db, s); err != nil {
+		return nil, err
+	}
+
+	activities, err := db.ListActivities(ctx, domain.ActivityFilter{UserID: s.UserID, ActiveOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	n := domain.CheckInReminder(s, domain.DueActivities(activities, now, s.Location()), now)
+	if n == nil {
+		return nil, nil
+	}
+	added, err := db.AddNotification(ctx, n)
+	if err != nil || !added {
+		return nil, err
+	}
+
+	if sink := sinkFor(s); sink != nil {
+		if err := notifications.Deliver(ctx, db, sink, n); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// retryUndelivered re-sends reminders whose delivery failed or never ran,
+// e.g. after a crash between storing and sending.
+func retryUndelivered(ctx context.Context, db gateways.DB, s domain.ReminderSettings) error {
+	sink := sinkFor(s)
+	if sink == nil {
+		return nil
+	}
+	pending, err := db.ListUndeliveredNotifications(ctx, s.UserID, domain.NotificationCheckInDue, maxDeliveryAttempts)
+	if err != nil {
+		return err
+	}
+	for i := range pending {
+		if err := notifications.Deliver(ctx, db, sink, &pending[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sinkFor returns the outbound sink of the channel, nil for the inbox.
+// Email goes through the relay at SMTP_ADDR (host:port).
+func sinkFor(s domain.ReminderSettings) gateways.NotificationSink {
+	switch s.Channel {
+	case domain.ReminderWebhook:
+		return notify.Webhook{URL: s.Target}
+	case domain.ReminderEmail:
+		from := os.Getenv("SMTP_FROM")
+		if from == "" {
+			from = "personal@localhost"
+		}
+		return notify.SMTP{Addr: os.Getenv("SMTP_ADDR"), From: from, To: s.Target}
+	}
+	return nil
+}
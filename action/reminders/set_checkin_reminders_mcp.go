@@ -0,0 +1,109 @@
+package reminders
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+var SetCheckInRemindersMCPDefinition = mcp.Tool{
+	Name: "set_checkin_reminders",
+	Description: "Configure the daily check-in reminder. Once a day, at remind_at in the user's timezone, the server lists the activities " +
+		"whose check-in is due (last check-in day + frequency_days has come, or never checked in) and raises one notification. " +
+		"It always lands in the inbox (list_notifications); channel webhook also POSTs it as JSON to target, channel email mails it to target " +
+		"through the SMTP relay. Omitted fields keep their current value; reminders are off until enabled.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Set check-in reminders",
+	},
+}
+
+// SetCheckInRemindersInput is the MCP tool input.
+type SetCheckInRemindersInput struct {
+	Enabled  *bool   `json:"enabled,omitempty" jsonschema:"Turn the daily reminder on or off"`
+	RemindAt *string `json:"remind_at,omitempty" jsonschema:"Local time of the reminder, HH:MM (default 09:00)"`
+	Timezone *string `json:"timezone,omitempty" jsonschema:"IANA timezone, e.g. Europe/Berlin (default UTC)"`
+	Channel  *string `json:"channel,omitempty" jsonschema:"inbox, webhook or email (default inbox)"`
+	Target   *string `json:"target,omitempty" jsonschema:"Webhook URL or email address for the webhook and email channels"`
+}
+
+// CheckInRemindersOutput is the saved configuration.
+type CheckInRemindersOutput struct {
+	Enabled  bool   `json:"enabled"`
+	RemindAt string `json:"remind_at"`
+	Timezone string `json:"timezone"`
+	Channel  string `json:"channel"`
+	Target   string `json:"target,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+func SetCheckInReminders(ctx context.Context, _ *mcp.CallToolRequest, input SetCheckInRemindersInput) (*mcp.CallToolResult, CheckInRemindersOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, CheckInRemindersOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, CheckInRemindersOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	settings, err := loadSettings(ctx, db, userID)
+	if err != nil {
+		return nil, CheckInRemindersOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	if input.Enabled != nil {
+		settings.Enabled = *input.Enabled
+	}
+	if input.RemindAt != nil {
+		settings.RemindAt = strings.TrimSpace(*input.RemindAt)
+	}
+	if input.Timezone != nil {
+		settings.Timezone = strings.TrimSpace(*input.Timezone)
+	}
+	if input.Channel != nil {
+		settings.Channel = strings.TrimSpace(*input.Channel)
+		if settings.Channel == domain.ReminderInbox {
+			settings.Target = ""
+		}
+	}
+	if input.Target != nil {
+		settings.Target = strings.TrimSpace(*input.Target)
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, CheckInRemindersOutput{Error: err.Error()}, nil
+	}
+
+	if err := db.SaveReminderSettings(ctx, &settings); err != nil {
+		return nil, CheckInRemindersOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	return nil, settingsToOutput(settings), nil
+}
+
+func settingsToOutput(s domain.ReminderSettings) CheckInRemindersOutput {
+	return CheckInRemindersOutput{
+		Enabled:  s.Enabled,
+		RemindAt: s.RemindAt,
+		Timezone: s.Timezone,
+		Channel:  s.Channel,
+		Target:   s.Target,
+	}
+}
+
+// loadSettings returns the saved settings or the defaults.
+func loadSettings(ctx context.Context, db gateways.DB, userID int64) (domain.ReminderSettings, error) {
+	saved, err := db.GetReminderSettings(ctx, userID)
+	if err != nil {
+		return domain.ReminderSettings{}, err
+	}
+	if saved == nil {
+		return domain.DefaultReminderSettings(userID), nil
+	}
+	return *saved, nil
+}
@@ -0,0 +1,193 @@
+package savings_goals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+)
+
+var CheckSavingsGoalsMCPDefinition = mcp.Tool{
+	Name: "check_savings_goals",
+	Description: "Progress of savings goals at 'at' (default now): saved amount, remaining, the monthly contribution required to hit the deadline, " +
+		"the pace of the last 90 days and a status — on_track when that pace reaches the target by the deadline, otherwise behind; " +
+		"reached, overdue or no_deadline when those apply. Filter by name or by linked progress activity.",
+	Annotations: &mcp.ToolAnnotations{
+		ReadOnlyHint: true,
+		Title:        "Check savings goals",
+	},
+}
+
+// CheckSavingsGoalsInput is the MCP tool input.
+type CheckSavingsGoalsInput struct {
+	At         *time.Time `json:"at,omitempty" jsonschema:"Moment to evaluate (default now)"`
+	Name       *string    `json:"name,omitempty" jsonschema:"Only the goal with this name"`
+	ActivityID *int64     `json:"activity_id,omitempty" jsonschema:"Only goals linked to this progress activity"`
+}
+
+// GoalOutput is a savings goal with its progress.
+type GoalOutput struct {
+	ID                  int64      `json:"id"`
+	Name                string     `json:"name"`
+	TargetAmount        float64    `json:"target_amount"`
+	Currency            string     `json:"currency"`
+	Deadline            *time.Time `json:"deadline,omitempty"`
+	FundingAccount      *string    `json:"funding_account,omitempty"`
+	FundingTag          *string    `json:"funding_tag,omitempty"`
+	StartingAmount      float64    `json:"starting_amount,omitempty"`
+	ActivityID          *int64     `json:"activity_id,omitempty"`
+	ActivityName        string     `json:"activity_name,omitempty"`
+	Saved               float64    `json:"saved"`
+	Remaining           float64    `json:"remaining"`
+	ProgressPct         float64    `json:"progress_pct"`
+	MonthlyPace         float64    `json:"monthly_pace"`
+	MonthsLeft          *float64   `json:"months_left,omitempty"`
+	RequiredMonthly     *float64   `json:"required_monthly,omitempty"`
+	ProjectedAtDeadline *float64   `json:"projected_at_deadline,omitempty"`
+	Status              string     `json:"status"`
+}
+
+// CheckSavingsGoalsOutput is the MCP tool output.
+type CheckSavingsGoalsOutput struct {
+	At    time.Time    `json:"at"`
+	Goals []GoalOutput `json:"goals"`
+	Error string       `json:"error,omitempty"`
+}
+
+func CheckSavingsGoals(ctx context.Context, _ *mcp.CallToolRequest, input CheckSavingsGoalsInput) (*mcp.CallToolResult, CheckSavingsGoalsOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, CheckSavingsGoalsOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, CheckSavingsGoalsOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	at := time.Now().UTC()
+	if input.At != nil {
+		at = input.At.UTC()
+	}
+
+	all, err := db.ListSavingsGoals(ctx, userID)
+	if err != nil {
+		return nil, CheckSavingsGoalsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	var goals []domain.SavingsGoal
+	for _, g := range all {
+		if input.Name != nil && !strings.EqualFold(g.Name, strings.TrimSpace(*input.Name)) {
+			continue
+		}
+		if input.ActivityID != nil && (g.ActivityID == nil || *g.ActivityID != *input.ActivityID) {
+			continue
+		}
+		goals = append(goals, g)
+	}
+	if input.Name != nil && len(goals) == 0 {
+		return nil, CheckSavingsGoalsOutput{Error: fmt.Sprintf("savings goal %q not found", *input.Name)}, nil
+	}
+
+	out, err := Evaluate(ctx, db, userID, goals, at)
+	if err != nil {
+		return nil, CheckSavingsGoalsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	return nil, CheckSavingsGoalsOutput{At: at, Goals: out}, nil
+}
+
+// Evaluate computes the progress of goals at at. Account-funded goals use the
+// account balance, tag-funded ones the tagged transactions plus the starting
+// amount.
+func Evaluate(ctx context.Context, db gateways.DB, userID int64, goals []domain.SavingsGoal, at time.Time) ([]GoalOutput, error) {
+	result := make([]GoalOutput, 0, len(goals))
+	if len(goals) == 0 {
+		return result, nil
+	}
+	windowStart := at.Add(-domain.SavingsPaceWindow)
+
+	var balances, windowBalances map[string]float64
+	activityNames := map[int64]string{}
+	for _, g := range goals {
+		if g.FundingAccount != nil && balances == nil {
+			var err error
+			if balances, err = accountBalances(ctx, db, userID, at); err != nil {
+				return nil, err
+			}
+			if windowBalances, err = accountBalances(ctx, db, userID, windowStart); err != nil {
+				return nil, err
+			}
+		}
+		if g.ActivityID != nil {
+			if _, ok := activityNames[*g.ActivityID]; !ok {
+				activity, err := db.GetActivity(ctx, *g.ActivityID, userID)
+				if err != nil {
+					return nil, err
+				}
+				if activity != nil {
+					activityNames[*g.ActivityID] = activity.Name
+				}
+			}
+		}
+	}
+
+	for _, g := range goals {
+		var saved, savedWindowStart float64
+		if g.FundingAccount != nil {
+			key := strings.ToLower(*g.FundingAccount)
+			saved, savedWindowStart = balances[key], windowBalances[key]
+		} else {
+			var err error
+			if saved, err = db.GetTagSavings(ctx, userID, *g.FundingTag, at); err != nil {
+				return nil, err
+			}
+			if savedWindowStart, err = db.GetTagSavings(ctx, userID, *g.FundingTag, windowStart); err != nil {
+				return nil, err
+			}
+			saved += g.StartingAmount
+			savedWindowStart += g.StartingAmount
+		}
+
+		p := domain.EvaluateSavingsGoal(g, saved, savedWindowStart, at)
+		o := GoalOutput{
+			ID:                  g.ID,
+			Name:                g.Name,
+			TargetAmount:        g.TargetAmount,
+			Currency:            g.Currency,
+			Deadline:            g.Deadline,
+			FundingAccount:      g.FundingAccount,
+			FundingTag:          g.FundingTag,
+			StartingAmount:      g.StartingAmount,
+			ActivityID:          g.ActivityID,
+			Saved:               p.Saved,
+			Remaining:           p.Remaining,
+			ProgressPct:         p.ProgressPct,
+			MonthlyPace:         p.MonthlyPace,
+			MonthsLeft:          p.MonthsLeft,
+			RequiredMonthly:     p.RequiredMonthly,
+			ProjectedAtDeadline: p.ProjectedAtDeadline,
+			Status:              p.Status,
+		}
+		if g.ActivityID != nil {
+			o.ActivityName = activityNames[*g.ActivityID]
+		}
+		result = append(result, o)
+	}
+	return result, nil
+}
+
+// accountBalances maps lower-cased account names to their balance at at.
+func accountBalances(ctx context.Context, db gateways.DB, userID int64, at time.Time) (map[string]float64, error) {
+	balances, err := db.GetAccountBalances(ctx, userID, at)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]float64, len(balances))
+	for _, b := range balances {
+		result[strings.ToLower(b.Name)] = b.Balance
+	}
+	return result, nil
+}
@@ -0,0 +1,126 @@
+package savings_goals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+const maxTagLength = 100
+
+var SaveSavingsGoalMCPDefinition = mcp.Tool{
+	Name: "save_savings_goal",
+	Description: "Create or update a savings goal by name: a target amount, an optional deadline and one funding source — " +
+		"a registered account (its balance is the saved amount, in the account currency) or a tag (tagged income and transfers add, tagged expenses subtract, in EUR). " +
+		"Optionally link a progress activity so the goal shows up in its reflection stats. Returns the goal with its current progress.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Save savings goal",
+	},
+}
+
+// SaveSavingsGoalInput is the MCP tool input.
+type SaveSavingsGoalInput struct {
+	Name           string     `json:"name" jsonschema:"Goal name, e.g. Emergency fund"`
+	TargetAmount   float64    `json:"target_amount" jsonschema:"Amount to save, in the goal currency"`
+	Currency       string     `json:"currency,omitempty" jsonschema:"ISO 4217 code; defaults to the funding account currency, EUR for tag funding"`
+	Deadline       *time.Time `json:"deadline,omitempty" jsonschema:"Date to reach the target by"`
+	FundingAccount *string    `json:"funding_account,omitempty" jsonschema:"Registered account holding the savings"`
+	FundingTag     *string    `json:"funding_tag,omitempty" jsonschema:"Tag marking contributions, e.g. house deposit"`
+	StartingAmount float64    `json:"starting_amount,omitempty" jsonschema:"Already saved before tagging started (tag funding only)"`
+	ActivityID     *int64     `json:"activity_id,omitempty" jsonschema:"Progress activity to show the goal in"`
+}
+
+// SaveSavingsGoalOutput is the MCP tool output.
+type SaveSavingsGoalOutput struct {
+	Goal  GoalOutput `json:"goal"`
+	Error string     `json:"error,omitempty"`
+}
+
+func SaveSavingsGoal(ctx context.Context, _ *mcp.CallToolRequest, input SaveSavingsGoalInput) (*mcp.CallToolResult, SaveSavingsGoalOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, SaveSavingsGoalOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, SaveSavingsGoalOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	g := domain.SavingsGoal{
+		UserID:         userID,
+		Name:           strings.TrimSpace(input.Name),
+		TargetAmount:   input.TargetAmount,
+		Currency:       strings.ToUpper(strings.TrimSpace(input.Currency)),
+		Deadline:       input.Deadline,
+		StartingAmount: input.StartingAmount,
+		ActivityID:     input.ActivityID,
+	}
+	if g.Name == "" {
+		return nil, SaveSavingsGoalOutput{Error: "name is required"}, nil
+	}
+	if g.TargetAmount <= 0 {
+		return nil, SaveSavingsGoalOutput{Error: "target_amount must be positive"}, nil
+	}
+	if (input.FundingAccount == nil) == (input.FundingTag == nil) {
+		return nil, SaveSavingsGoalOutput{Error: "set exactly one of funding_account and funding_tag"}, nil
+	}
+
+	if input.FundingAccount != nil {
+		if g.StartingAmount != 0 {
+			return nil, SaveSavingsGoalOutput{Error: "starting_amount applies to tag funding only; the account balance already includes it"}, nil
+		}
+		account, err := db.GetAccount(ctx, userID, strings.TrimSpace(*input.FundingAccount))
+		if err != nil {
+			return nil, SaveSavingsGoalOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		if account == nil {
+			return nil, SaveSavingsGoalOutput{Error: fmt.Sprintf("account %q not found; register it with save_account", *input.FundingAccount)}, nil
+		}
+		if g.Currency != "" && g.Currency != account.Currency {
+			return nil, SaveSavingsGoalOutput{Error: fmt.Sprintf("currency must match the %s account currency %s", account.Name, account.Currency)}, nil
+		}
+		g.Currency = account.Currency
+		g.FundingAccount = &account.Name
+	} else {
+		tag := domain.NormalizeTag(*input.FundingTag)
+		if tag == "" {
+			return nil, SaveSavingsGoalOutput{Error: "funding_tag must not be empty"}, nil
+		}
+		if len(tag) > maxTagLength {
+			return nil, SaveSavingsGoalOutput{Error: fmt.Sprintf("funding_tag is longer than %d characters", maxTagLength)}, nil
+		}
+		if g.Currency != "" && g.Currency != "EUR" {
+			return nil, SaveSavingsGoalOutput{Error: "tag-funded goals are tracked in EUR"}, nil
+		}
+		g.Currency = "EUR"
+		g.FundingTag = &tag
+	}
+
+	if g.ActivityID != nil {
+		activity, err := db.GetActivity(ctx, *g.ActivityID, userID)
+		if err != nil {
+			return nil, SaveSavingsGoalOutput{}, fmt.Errorf("database error: %w", err)
+		}
+		if activity == nil {
+			return nil, SaveSavingsGoalOutput{Error: fmt.Sprintf("activity %d not found", *g.ActivityID)}, nil
+		}
+	}
+
+	if _, err := db.SaveSavingsGoal(ctx, &g); err != nil {
+		return nil, SaveSavingsGoalOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	goals, err := Evaluate(ctx, db, userID, []domain.SavingsGoal{g}, time.Now().UTC())
+	if err != nil {
+		return nil, SaveSavingsGoalOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	return nil, SaveSavingsGoalOutput{Goal: goals[0]}, nil
+}
@@ -13,8 +13,10 @@ import (
 )
 
 var MCPDefinition = mcp.Tool{
-	Name:        "set_budget",
-	Description: "Create or update a spending budget for a category over a time period. Upserts by name — calling again with the same name updates the existing budget.",
+	Name: "set_budget",
+	Description: "Create or update a spending budget for a category over a time period. Upserts by name — calling again with the same name updates the existing budget. " +
+		"With period (weekly, monthly, yearly) the budget repeats from starts_at (default: start of the current period) until ends_at (default: open-ended), " +
+		"and with rollover the unused or overspent amount of each period carries into the next one. Updating a recurring budget changes the current and future periods only.",
 	Annotations: &mcp.ToolAnnotations{
 		DestructiveHint: util.Ptr(true),
 		Title:           "Set budget",
@@ -26,18 +28,22 @@ type SetBudgetInput struct {
 	Name      string    `json:"name"`
 	Category  string    `json:"category"`
 	AmountEUR float64   `json:"amount_eur"`
-	StartsAt  time.Time `json:"starts_at"`
-	EndsAt    time.Time `json:"ends_at"`
+	StartsAt  time.Time `json:"starts_at,omitempty"`
+	EndsAt    time.Time `json:"ends_at,omitempty"`
+	Period    string    `json:"period,omitempty" jsonschema:"Repeat every weekly, monthly or yearly period; omit for a one-off budget"`
+	Rollover  bool      `json:"rollover,omitempty" jsonschema:"Carry unused or overspent amounts into the next period (recurring budgets only)"`
 }
 
 // BudgetOutput mirrors domain.Budget for JSON serialization.
 type BudgetOutput struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Category  string    `json:"category"`
-	AmountEUR float64   `json:"amount_eur"`
-	StartsAt  time.Time `json:"starts_at"`
-	EndsAt    time.Time `json:"ends_at"`
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Category  string     `json:"category"`
+	AmountEUR float64    `json:"amount_eur"`
+	StartsAt  time.Time  `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+	Period    string     `json:"period,omitempty"`
+	Rollover  bool       `json:"rollover,omitempty"`
 }
 
 // SetBudgetOutput is the MCP tool output.
@@ -63,6 +69,12 @@ func SetBudget(ctx context.Context, _ *mcp.CallToolRequest, input SetBudgetInput
 	if input.AmountEUR <= 0 {
 		return nil, SetBudgetOutput{Error: "amount_eur must be greater than 0"}, nil
 	}
+	if input.Period != "" {
+		return setRecurringBudget(ctx, db, userID, input)
+	}
+	if input.Rollover {
+		return nil, SetBudgetOutput{Error: "rollover requires period"}, nil
+	}
 	if !input.EndsAt.After(input.StartsAt) {
 		return nil, SetBudgetOutput{Error: "ends_at must be after starts_at"}, nil
 	}
@@ -90,7 +102,57 @@ func SetBudget(ctx context.Context, _ *mcp.CallToolRequest, input SetBudgetInput
 			Category:  b.Category,
 			AmountEUR: b.AmountEUR,
 			StartsAt:  b.StartsAt,
-			EndsAt:    b.EndsAt,
+			EndsAt:    &b.EndsAt,
+		},
+	}, nil
+}
+
+func setRecurringBudget(ctx context.Context, db gateways.DB, userID int64, input SetBudgetInput) (*mcp.CallToolResult, SetBudgetOutput, error) {
+	period := domain.BudgetPeriod(input.Period)
+	if !period.IsValid() {
+		return nil, SetBudgetOutput{Error: "period must be one of: weekly, monthly, yearly"}, nil
+	}
+
+	startsAt := input.StartsAt
+	if startsAt.IsZero() {
+		startsAt = period.Start(time.Now().UTC())
+	}
+	if period != domain.BudgetWeekly && startsAt.Day() > 28 {
+		return nil, SetBudgetOutput{Error: "monthly and yearly budgets must start on day 1-28 so every period starts on the same day"}, nil
+	}
+
+	rb := &domain.RecurringBudget{
+		UserID:    userID,
+		Name:      input.Name,
+		Category:  input.Category,
+		AmountEUR: input.AmountEUR,
+		Period:    period,
+		Rollover:  input.Rollover,
+		StartsAt:  startsAt,
+	}
+	if !input.EndsAt.IsZero() {
+		if !input.EndsAt.After(startsAt) {
+			return nil, SetBudgetOutput{Error: "ends_at must be after starts_at"}, nil
+		}
+		rb.EndsAt = &input.EndsAt
+	}
+
+	id, err := db.SaveRecurringBudget(ctx, rb)
+	if err != nil {
+		return nil, SetBudgetOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	return nil, SetBudgetOutput{
+		ID: id,
+		Budget: BudgetOutput{
+			ID:        id,
+			Name:      rb.Name,
+			Category:  rb.Category,
+			AmountEUR: rb.AmountEUR,
+			StartsAt:  rb.StartsAt,
+			EndsAt:    rb.EndsAt,
+			Period:    string(rb.Period),
+			Rollover:  rb.Rollover,
 		},
 	}, nil
 }
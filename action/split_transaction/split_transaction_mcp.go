@@ -0,0 +1,100 @@
+package split_transaction
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/action/add_transactions"
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+var MCPDefinition = mcp.Tool{
+	Name: "split_transaction",
+	Description: "Split one transaction into line items with their own category, amount and note, e.g. a supermarket receipt covering groceries, home and personal care. " +
+		"Amounts are in the transaction's currency and must sum to its amount_original. Replaces any previous split; an empty splits list removes it. " +
+		"Spending by category, budgets and compare_periods count the lines instead of the parent category.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Split transaction",
+	},
+}
+
+// SplitInput is one line item.
+type SplitInput struct {
+	Category string  `json:"category" jsonschema:"Slash path, e.g. home/cleaning"`
+	Amount   float64 `json:"amount" jsonschema:"Positive amount in the transaction's currency"`
+	Note     *string `json:"note,omitempty"`
+}
+
+// SplitTransactionInput is the MCP tool input.
+type SplitTransactionInput struct {
+	ID     int64        `json:"id"`
+	Splits []SplitInput `json:"splits"`
+}
+
+// SplitTransactionOutput is the MCP tool output.
+type SplitTransactionOutput struct {
+	ID     int64                          `json:"id"`
+	Splits []add_transactions.SplitOutput `json:"splits"`
+	Error  string                         `json:"error,omitempty"`
+}
+
+func SplitTransaction(ctx context.Context, _ *mcp.CallToolRequest, input SplitTransactionInput) (*mcp.CallToolResult, SplitTransactionOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, SplitTransactionOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, SplitTransactionOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	if input.ID == 0 {
+		return nil, SplitTransactionOutput{Error: "id is required"}, nil
+	}
+	if len(input.Splits) == 1 {
+		return nil, SplitTransactionOutput{Error: "a split needs at least 2 lines; change the category instead"}, nil
+	}
+
+	tx, err := db.GetTransaction(ctx, userID, input.ID)
+	if err != nil {
+		return nil, SplitTransactionOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	if tx == nil {
+		return nil, SplitTransactionOutput{Error: "transaction not found"}, nil
+	}
+
+	splits := make([]domain.TransactionSplit, len(input.Splits))
+	sum := 0.0
+	for i, in := range input.Splits {
+		category := strings.TrimSpace(in.Category)
+		if category == "" {
+			return nil, SplitTransactionOutput{Error: fmt.Sprintf("splits[%d]: category is required", i)}, nil
+		}
+		if in.Amount <= 0 {
+			return nil, SplitTransactionOutput{Error: fmt.Sprintf("splits[%d]: amount must be greater than 0", i)}, nil
+		}
+		splits[i] = domain.TransactionSplit{Category: category, Amount: in.Amount, Note: in.Note}
+		sum += in.Amount
+	}
+	if len(splits) > 0 && math.Abs(sum-tx.AmountOriginal) >= 0.005 {
+		return nil, SplitTransactionOutput{Error: fmt.Sprintf(
+			"splits sum to %.2f but the transaction amount is %.2f %s", sum, tx.AmountOriginal, tx.Currency)}, nil
+	}
+
+	if err := db.ReplaceTransactionSplits(ctx, userID, tx.ID, splits); err != nil {
+		return nil, SplitTransactionOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	saved, err := db.ListTransactionSplits(ctx, userID, []int64{tx.ID})
+	if err != nil {
+		return nil, SplitTransactionOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	return nil, SplitTransactionOutput{ID: tx.ID, Splits: add_transactions.SplitsToOutput(saved)}, nil
+}
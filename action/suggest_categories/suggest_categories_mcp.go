@@ -0,0 +1,105 @@
+package suggest_categories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	money_import "personal/action/money_import"
+	"personal/domain"
+	"personal/gateways"
+)
+
+var MCPDefinition = mcp.Tool{
+	Name:        "suggest_categories",
+	Description: "Suggest categories for transactions in a category (default 'uncategorized') using static keyword rules first, then a model learned from past edit_transactions re-categorizations (merchant frequency, then naive Bayes over the original bank description). Each suggestion has a confidence from 0 to 1 and its source. Apply accepted suggestions with edit_transactions.",
+}
+
+// SuggestCategoriesInput is the MCP tool input.
+type SuggestCategoriesInput struct {
+	Category *string    `json:"category,omitempty" jsonschema:"Category prefix of transactions to categorize (default 'uncategorized')"`
+	From     *time.Time `json:"from,omitempty"`
+	To       *time.Time `json:"to,omitempty"`
+	Limit    int        `json:"limit,omitempty" jsonschema:"Max transactions to inspect (default 50, max 200)"`
+}
+
+// SuggestionRow is a category suggestion for one transaction.
+type SuggestionRow struct {
+	TransactionID       int64     `json:"transaction_id"`
+	Merchant            string    `json:"merchant"`
+	OriginalDescription *string   `json:"original_description,omitempty"`
+	CurrentCategory     string    `json:"current_category"`
+	SuggestedCategory   string    `json:"suggested_category"`
+	Confidence          float64   `json:"confidence"`
+	Source              string    `json:"source"`
+	TransactedAt        time.Time `json:"transacted_at"`
+}
+
+// SuggestCategoriesOutput is the MCP tool output.
+type SuggestCategoriesOutput struct {
+	Suggestions  []SuggestionRow `json:"suggestions"`
+	Inspected    int             `json:"inspected"`
+	WithoutGuess int             `json:"without_guess"`
+}
+
+func SuggestCategories(ctx context.Context, _ *mcp.CallToolRequest, input SuggestCategoriesInput) (*mcp.CallToolResult, SuggestCategoriesOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, SuggestCategoriesOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, SuggestCategoriesOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	category := "uncategorized"
+	if input.Category != nil {
+		category = *input.Category
+	}
+
+	txs, _, err := db.GetTransactions(ctx, domain.TransactionFilter{
+		UserID:   userID,
+		From:     input.From,
+		To:       input.To,
+		Category: &category,
+		Limit:    input.Limit,
+	})
+	if err != nil {
+		return nil, SuggestCategoriesOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	corrections, err := db.ListCategoryCorrections(ctx, userID)
+	if err != nil {
+		return nil, SuggestCategoriesOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	model := money_import.NewCategoryModel(corrections)
+
+	out := SuggestCategoriesOutput{Suggestions: []SuggestionRow{}, Inspected: len(txs)}
+	for _, tx := range txs {
+		description := ""
+		if tx.OriginalDescription != nil {
+			description = *tx.OriginalDescription
+		}
+
+		suggestion, ok := money_import.SuggestCategory(model, tx.Merchant, description)
+		if !ok || suggestion.Category == tx.Category {
+			out.WithoutGuess++
+			continue
+		}
+
+		out.Suggestions = append(out.Suggestions, SuggestionRow{
+			TransactionID:       tx.ID,
+			Merchant:            tx.Merchant,
+			OriginalDescription: tx.OriginalDescription,
+			CurrentCategory:     tx.Category,
+			SuggestedCategory:   suggestion.Category,
+			Confidence:          suggestion.Confidence,
+			Source:              string(suggestion.Source),
+			TransactedAt:        tx.TransactedAt,
+		})
+	}
+
+	return nil, out, nil
+}
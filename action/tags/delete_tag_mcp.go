@@ -0,0 +1,56 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+var DeleteTagMCPDefinition = mcp.Tool{
+	Name:        "delete_tag",
+	Description: "Delete a transaction tag and detach it from every transaction. Transactions themselves are kept.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Delete tag",
+	},
+}
+
+// DeleteTagInput is the MCP tool input.
+type DeleteTagInput struct {
+	Name string `json:"name"`
+}
+
+// DeleteTagOutput is the MCP tool output.
+type DeleteTagOutput struct {
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+func DeleteTag(ctx context.Context, _ *mcp.CallToolRequest, input DeleteTagInput) (*mcp.CallToolResult, DeleteTagOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, DeleteTagOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, DeleteTagOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	name := domain.NormalizeTag(input.Name)
+	if name == "" {
+		return nil, DeleteTagOutput{Error: "name is required"}, nil
+	}
+	deleted, err := db.DeleteTag(ctx, userID, name)
+	if err != nil {
+		return nil, DeleteTagOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	if !deleted {
+		return nil, DeleteTagOutput{Error: fmt.Sprintf("tag %q not found", name)}, nil
+	}
+	return nil, DeleteTagOutput{Deleted: true}, nil
+}
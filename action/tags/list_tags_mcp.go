@@ -0,0 +1,51 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+)
+
+var ListTagsMCPDefinition = mcp.Tool{
+	Name:        "list_tags",
+	Description: "List transaction tags with the number of tagged transactions and their expense total in EUR, most used first.",
+}
+
+// ListTagsInput is the MCP tool input.
+type ListTagsInput struct{}
+
+// TagOutput is one tag with usage totals.
+type TagOutput struct {
+	Name             string  `json:"name"`
+	TransactionCount int     `json:"transaction_count"`
+	SpentEUR         float64 `json:"spent_eur"`
+}
+
+// ListTagsOutput is the MCP tool output.
+type ListTagsOutput struct {
+	Tags []TagOutput `json:"tags"`
+}
+
+func ListTags(ctx context.Context, _ *mcp.CallToolRequest, _ ListTagsInput) (*mcp.CallToolResult, ListTagsOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, ListTagsOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, ListTagsOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	summaries, err := db.ListTags(ctx, userID)
+	if err != nil {
+		return nil, ListTagsOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	out := make([]TagOutput, len(summaries))
+	for i, t := range summaries {
+		out[i] = TagOutput{Name: t.Name, TransactionCount: t.TransactionCount, SpentEUR: t.SpentEUR}
+	}
+	return nil, ListTagsOutput{Tags: out}, nil
+}
@@ -0,0 +1,60 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+	"personal/util"
+)
+
+var RenameTagMCPDefinition = mcp.Tool{
+	Name:        "rename_tag",
+	Description: "Rename a transaction tag. Renaming to an existing tag merges the two.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Rename tag",
+	},
+}
+
+// RenameTagInput is the MCP tool input.
+type RenameTagInput struct {
+	Name    string `json:"name"`
+	NewName string `json:"new_name"`
+}
+
+// RenameTagOutput is the MCP tool output.
+type RenameTagOutput struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+func RenameTag(ctx context.Context, _ *mcp.CallToolRequest, input RenameTagInput) (*mcp.CallToolResult, RenameTagOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, RenameTagOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, RenameTagOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	names, errMsg := normalizeTags([]string{input.Name, input.NewName})
+	if errMsg != "" {
+		return nil, RenameTagOutput{Error: errMsg}, nil
+	}
+	if len(names) == 1 {
+		return nil, RenameTagOutput{Error: "new_name must differ from name"}, nil
+	}
+
+	found, err := db.RenameTag(ctx, userID, names[0], names[1])
+	if err != nil {
+		return nil, RenameTagOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	if !found {
+		return nil, RenameTagOutput{Error: fmt.Sprintf("tag %q not found", names[0])}, nil
+	}
+	return nil, RenameTagOutput{Name: names[1]}, nil
+}
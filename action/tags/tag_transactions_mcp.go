@@ -0,0 +1,99 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+const maxTagLength = 100
+
+var TagTransactionsMCPDefinition = mcp.Tool{
+	Name: "tag_transactions",
+	Description: "Add or remove free-form tags on transactions, e.g. \"barcelona trip\" across flights, hotel and restaurants. " +
+		"Tags are created on first use and compared case-insensitively. Filter by them with get_transactions tags.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Tag transactions",
+	},
+}
+
+// TagTransactionsInput is the MCP tool input.
+type TagTransactionsInput struct {
+	IDs    []int64  `json:"ids" jsonschema:"Transaction IDs"`
+	Add    []string `json:"add,omitempty" jsonschema:"Tags to attach"`
+	Remove []string `json:"remove,omitempty" jsonschema:"Tags to detach"`
+}
+
+// TagTransactionsOutput is the MCP tool output.
+type TagTransactionsOutput struct {
+	AddedCount   int    `json:"added_count"`
+	RemovedCount int    `json:"removed_count"`
+	Error        string `json:"error,omitempty"`
+}
+
+func TagTransactions(ctx context.Context, _ *mcp.CallToolRequest, input TagTransactionsInput) (*mcp.CallToolResult, TagTransactionsOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, TagTransactionsOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, TagTransactionsOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	if len(input.IDs) == 0 {
+		return nil, TagTransactionsOutput{Error: "ids is required"}, nil
+	}
+	add, errMsg := normalizeTags(input.Add)
+	if errMsg != "" {
+		return nil, TagTransactionsOutput{Error: errMsg}, nil
+	}
+	remove, errMsg := normalizeTags(input.Remove)
+	if errMsg != "" {
+		return nil, TagTransactionsOutput{Error: errMsg}, nil
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, TagTransactionsOutput{Error: "add or remove is required"}, nil
+	}
+
+	var out TagTransactionsOutput
+	var err error
+	if len(remove) > 0 {
+		if out.RemovedCount, err = db.RemoveTransactionTags(ctx, userID, input.IDs, remove); err != nil {
+			return nil, TagTransactionsOutput{}, fmt.Errorf("database error: %w", err)
+		}
+	}
+	if len(add) > 0 {
+		if out.AddedCount, err = db.AddTransactionTags(ctx, userID, input.IDs, add); err != nil {
+			return nil, TagTransactionsOutput{}, fmt.Errorf("database error: %w", err)
+		}
+	}
+	return nil, out, nil
+}
+
+// normalizeTags normalizes and deduplicates tag names, returning a
+// validation message for empty or overlong ones.
+func normalizeTags(names []string) ([]string, string) {
+	seen := make(map[string]bool, len(names))
+	var result []string
+	for _, name := range names {
+		tag := domain.NormalizeTag(name)
+		if tag == "" {
+			return nil, "tag must not be empty"
+		}
+		if len(tag) > maxTagLength {
+			return nil, fmt.Sprintf("tag %q is longer than %d characters", tag, maxTagLength)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	return result, ""
+}
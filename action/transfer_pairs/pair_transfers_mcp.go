@@ -0,0 +1,162 @@
+package transfer_pairs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+// DefaultOptions pair sides booked up to 3 days apart with up to 1% fee/FX difference.
+var DefaultOptions = domain.TransferMatchOptions{
+	Window:       3 * 24 * time.Hour,
+	AmountTolPct: 1,
+}
+
+var PairTransfersMCPDefinition = mcp.Tool{
+	Name: "pair_transfers",
+	Description: "Find moves between own accounts (e.g. Bank of Cyprus → Revolut top-up) that were recorded as an expense on one side and income on the other, and link them as transfer pairs. " +
+		"Only matches where one side looks like a transfer (type or category transfer, a transfer/top-up description, or the other account named as counterparty) are linked; " +
+		"other same-amount matches are returned as suggestions and left alone — recategorize one side as transfer to have it paired. " +
+		"Paired transactions are excluded from get_spending_by_category, compare_periods, get_top_merchants, get_budget_progress and get_balance. Runs automatically after every import; use dry_run to preview.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Pair transfers",
+	},
+}
+
+// PairTransfersInput is the MCP tool input.
+type PairTransfersInput struct {
+	From               *time.Time `json:"from,omitempty" jsonschema:"Start of the scanned range (default 90 days ago)"`
+	To                 *time.Time `json:"to,omitempty" jsonschema:"End of the scanned range (default now)"`
+	WindowDays         float64    `json:"window_days,omitempty" jsonschema:"Max days between the two sides (default 3)"`
+	AmountTolerancePct float64    `json:"amount_tolerance_pct,omitempty" jsonschema:"Allowed amount difference in percent when one side is already a transfer (default 1)"`
+	DryRun             bool       `json:"dry_run,omitempty" jsonschema:"Only report matches, do not link them"`
+}
+
+// TransferSideOutput is one side of a transfer pair.
+type TransferSideOutput struct {
+	ID             int64     `json:"id"`
+	Account        string    `json:"account"`
+	Type           string    `json:"type"`
+	AmountOriginal float64   `json:"amount_original"`
+	Currency       string    `json:"currency"`
+	Merchant       string    `json:"merchant"`
+	TransactedAt   time.Time `json:"transacted_at"`
+}
+
+// TransferPairOutput is a matched outgoing/incoming pair.
+type TransferPairOutput struct {
+	Out TransferSideOutput `json:"out"`
+	In  TransferSideOutput `json:"in"`
+}
+
+// PairTransfersOutput is the MCP tool output.
+type PairTransfersOutput struct {
+	Pairs       []TransferPairOutput `json:"pairs"`
+	Suggestions []TransferPairOutput `json:"suggestions,omitempty"`
+	PairedCount int                  `json:"paired_count"`
+	DryRun      bool                 `json:"dry_run,omitempty"`
+	Error       string               `json:"error,omitempty"`
+}
+
+func PairTransfers(ctx context.Context, _ *mcp.CallToolRequest, input PairTransfersInput) (*mcp.CallToolResult, PairTransfersOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, PairTransfersOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, PairTransfersOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	if input.WindowDays < 0 || input.AmountTolerancePct < 0 {
+		return nil, PairTransfersOutput{Error: "window_days and amount_tolerance_pct must not be negative"}, nil
+	}
+	opts := DefaultOptions
+	if input.WindowDays > 0 {
+		opts.Window = time.Duration(input.WindowDays * float64(24*time.Hour))
+	}
+	if input.AmountTolerancePct > 0 {
+		opts.AmountTolPct = input.AmountTolerancePct
+	}
+
+	to := time.Now().UTC()
+	if input.To != nil {
+		to = *input.To
+	}
+	from := to.AddDate(0, 0, -90)
+	if input.From != nil {
+		from = *input.From
+	}
+	if from.After(to) {
+		return nil, PairTransfersOutput{Error: "from must not be after to"}, nil
+	}
+
+	pairs, suggestions, txByID, err := match(ctx, db, userID, from, to, opts)
+	if err != nil {
+		return nil, PairTransfersOutput{}, fmt.Errorf("database error: %w", err)
+	}
+
+	out := PairTransfersOutput{
+		Pairs:  make([]TransferPairOutput, len(pairs)),
+		DryRun: input.DryRun,
+	}
+	for i, p := range pairs {
+		out.Pairs[i] = TransferPairOutput{Out: toSide(txByID[p.OutID]), In: toSide(txByID[p.InID])}
+	}
+	for _, p := range suggestions {
+		out.Suggestions = append(out.Suggestions, TransferPairOutput{Out: toSide(txByID[p.OutID]), In: toSide(txByID[p.InID])})
+	}
+	if input.DryRun {
+		return nil, out, nil
+	}
+
+	out.PairedCount, err = db.PairTransfers(ctx, userID, pairs)
+	if err != nil {
+		return nil, PairTransfersOutput{}, fmt.Errorf("database error: %w", err)
+	}
+	return nil, out, nil
+}
+
+// PairRange matches and links transfers among unpaired transactions in
+// [from, to] widened by the match window. Returns the number of new pairs
+// and of suggested matches left unlinked.
+func PairRange(ctx context.Context, db gateways.DB, userID int64, from, to time.Time, opts domain.TransferMatchOptions) (paired, suggested int, err error) {
+	pairs, suggestions, _, err := match(ctx, db, userID, from.Add(-opts.Window), to.Add(opts.Window), opts)
+	if err != nil {
+		return 0, 0, err
+	}
+	paired, err = db.PairTransfers(ctx, userID, pairs)
+	return paired, len(suggestions), err
+}
+
+func match(ctx context.Context, db gateways.DB, userID int64, from, to time.Time, opts domain.TransferMatchOptions) (pairs, suggestions []domain.TransferPair, txByID map[int64]domain.Transaction, err error) {
+	txs, err := db.ListUnpairedTransactions(ctx, userID, from, to)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	txByID = make(map[int64]domain.Transaction, len(txs))
+	for _, tx := range txs {
+		txByID[tx.ID] = tx
+	}
+	pairs, suggestions = domain.MatchTransfers(txs, opts)
+	return pairs, suggestions, txByID, nil
+}
+
+func toSide(tx domain.Transaction) TransferSideOutput {
+	return TransferSideOutput{
+		ID:             tx.ID,
+		Account:        tx.Account,
+		Type:           string(tx.Type),
+		AmountOriginal: tx.AmountOriginal,
+		Currency:       tx.Currency,
+		Merchant:       tx.Merchant,
+		TransactedAt:   tx.TransactedAt,
+	}
+}
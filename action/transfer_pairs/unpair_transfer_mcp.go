@@ -0,0 +1,51 @@
+package transfer_pairs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"personal/gateways"
+	"personal/util"
+)
+
+var UnpairTransferMCPDefinition = mcp.Tool{
+	Name:        "unpair_transfer",
+	Description: "Remove a wrong transfer pairing. Both sides count in spending and balance reports again.",
+	Annotations: &mcp.ToolAnnotations{
+		DestructiveHint: util.Ptr(true),
+		Title:           "Unpair transfer",
+	},
+}
+
+// UnpairTransferInput is the MCP tool input.
+type UnpairTransferInput struct {
+	ID int64 `json:"id" jsonschema:"ID of either side of the pair"`
+}
+
+// UnpairTransferOutput is the MCP tool output.
+type UnpairTransferOutput struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+func UnpairTransfer(ctx context.Context, _ *mcp.CallToolRequest, input UnpairTransferInput) (*mcp.CallToolResult, UnpairTransferOutput, error) {
+	db := gateways.DBFromContext(ctx)
+	if db == nil {
+		return nil, UnpairTransferOutput{}, fmt.Errorf("database not available in context")
+	}
+	userID := gateways.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, UnpairTransferOutput{}, fmt.Errorf("user_id not available in context")
+	}
+
+	if input.ID == 0 {
+		return nil, UnpairTransferOutput{Error: "id is required"}, nil
+	}
+
+	if err := db.UnpairTransfer(ctx, userID, input.ID); err != nil {
+		return nil, UnpairTransferOutput{Error: err.Error()}, nil
+	}
+	return nil, UnpairTransferOutput{Success: true}, nil
+}
@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// CheckInWindow is one frequency window [From, To) of an activity.
+type CheckInWindow struct {
+	From     time.Time
+	To       time.Time
+	CheckIns int
+}
+
+// MonthAdherence counts the windows that started in a calendar month (UTC).
+type MonthAdherence struct {
+	Month   time.Time
+	Windows int
+	OnTime  int
+	Pct     float64
+}
+
+// GapStats describes the skipped days between consecutive check-in days.
+// A gap of 0 means check-ins on consecutive days. Buckets compare the
+// interval (gap + 1) with the check-in frequency: on time within it, late
+// within twice it, very late beyond.
+type GapStats struct {
+	Count    int
+	Avg      float64 // mean of all gaps
+	AvgBreak float64 // mean of non-zero gaps: how long a break lasts
+	Median   float64
+	Max      int
+	OnTime   int
+	Late     int
+	VeryLate int
+}
+
+// Adherence measures check-ins of an activity against its frequency_days.
+// The window in progress counts only once it has a check-in, so it never
+// shows up as missed.
+type Adherence struct {
+	ActivityID    int64
+	FrequencyDays int
+	Windows       int
+	OnTime        int
+	Pct           *float64 // OnTime / Windows; nil without windows
+	CurrentStreak int
+	LongestStreak int
+	Months        []MonthAdherence
+	Missed        []CheckInWindow // oldest first
+	Gaps          GapStats
+}
+
+// AdherenceReport splits [from, now] into the frequency windows of the
+// activity, aligned to the UTC day it started, and checks each for a point.
+// Windows starting before from are left out; a finished activity is
+// evaluated up to its end.
+func AdherenceReport(a Activity, points []ActivityPoint, from, now time.Time) Adherence {
+	report := Adherence{ActivityID: a.ID, FrequencyDays: max(a.FrequencyDays, 1), Months: []MonthAdherence{}, Missed: []CheckInWindow{}}
+	if a.EndedAt != nil && a.EndedAt.Before(now) {
+		now = *a.EndedAt
+	}
+	p := periodsOf(a.StartedAt, report.FrequencyDays, now)
+
+	// Only whole windows inside the range, so none is missed for lack of
+	// points loaded before from.
+	first := 0
+	if from.After(p.start) {
+		first = p.index(from)
+		if p.from(first).Before(from) {
+			first++
+		}
+	}
+	if first > p.current {
+		return report
+	}
+
+	checkIns := make([]int, p.current+1)
+	var inRange []ActivityPoint
+	for _, pt := range points {
+		if pt.ProgressAt.Before(p.from(first)) || pt.ProgressAt.After(now) {
+			continue
+		}
+		checkIns[p.index(pt.ProgressAt)]++
+		inRange = append(inRange, pt)
+	}
+
+	var met []bool
+	months := map[time.Time]int{}
+	for i := first; i <= p.current; i++ {
+		w := CheckInWindow{From: p.from(i), To: p.from(i + 1), CheckIns: checkIns[i]}
+		if i == p.current && w.CheckIns == 0 {
+			met = append(met, false)
+			break
+		}
+		met = append(met, w.CheckIns > 0)
+
+		month := time.Date(w.From.Year(), w.From.Month(), 1, 0, 0, 0, 0, time.UTC)
+		m, ok := months[month]
+		if !ok {
+			m = len(report.Months)
+			months[month] = m
+			report.Months = append(report.Months, MonthAdherence{Month: month})
+		}
+		report.Windows++
+		report.Months[m].Windows++
+		if w.CheckIns > 0 {
+			report.OnTime++
+			report.Months[m].OnTime++
+		} else {
+			report.Missed = append(report.Missed, w)
+		}
+	}
+
+	for i := range report.Months {
+		report.Months[i].Pct = percent(report.Months[i].OnTime, report.Months[i].Windows)
+	}
+	if report.Windows > 0 {
+		pct := percent(report.OnTime, report.Windows)
+		report.Pct = &pct
+	}
+	report.CurrentStreak, report.LongestStreak = streaks(met)
+	report.Gaps = SummarizeGaps(DayGaps(CheckInDays(inRange)), report.FrequencyDays)
+	return report
+}
+
+// CheckInDays returns the distinct UTC days of the points, oldest first.
+func CheckInDays(points []ActivityPoint) []time.Time {
+	seen := map[time.Time]bool{}
+	var days []time.Time
+	for _, p := range points {
+		day := p.ProgressAt.UTC().Truncate(24 * time.Hour)
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days
+}
+
+// DayGaps returns the skipped days between consecutive sorted days.
+func DayGaps(days []time.Time) []int {
+	gaps := make([]int, 0, len(days))
+	for i := 1; i < len(days); i++ {
+		gaps = append(gaps, int(days[i].Sub(days[i-1]).Hours()/24)-1)
+	}
+	return gaps
+}
+
+// SummarizeGaps computes the averages, median and frequency buckets of gaps.
+func SummarizeGaps(gaps []int, frequencyDays int) GapStats {
+	stats := GapStats{Count: len(gaps)}
+	if len(gaps) == 0 {
+		return stats
+	}
+	frequencyDays = max(frequencyDays, 1)
+
+	sorted := append([]int(nil), gaps...)
+	sort.Ints(sorted)
+	sum, breaks, breakSum := 0, 0, 0
+	for _, g := range sorted {
+		sum += g
+		if g > 0 {
+			breaks++
+			breakSum += g
+		}
+		switch interval := g + 1; {
+		case interval <= frequencyDays:
+			stats.OnTime++
+		case interval <= 2*frequencyDays:
+			stats.Late++
+		default:
+			stats.VeryLate++
+		}
+	}
+	stats.Avg = math.Round(float64(sum)/float64(len(gaps))*10) / 10
+	if breaks > 0 {
+		stats.AvgBreak = math.Round(float64(breakSum)/float64(breaks)*10) / 10
+	}
+	if n := len(sorted); n%2 == 1 {
+		stats.Median = float64(sorted[n/2])
+	} else {
+		stats.Median = float64(sorted[n/2-1]+sorted[n/2]) / 2
+	}
+	stats.Max = sorted[len(sorted)-1]
+	return stats
+}
+
+// periods splits time into consecutive windows of a fixed number of days,
+// starting at the UTC day of start. current is the window containing now.
+type periods struct {
+	start   time.Time
+	length  time.Duration
+	current int
+}
+
+func periodsOf(start time.Time, days int, now time.Time) periods {
+	p := periods{start: start.UTC().Truncate(24 * time.Hour), length: time.Duration(max(days, 1)) * 24 * time.Hour}
+	if now.After(p.start) {
+		p.current = p.index(now)
+	}
+	return p
+}
+
+func (p periods) index(t time.Time) int {
+	return int(t.Sub(p.start) / p.length)
+}
+
+func (p periods) from(i int) time.Time {
+	return p.start.Add(time.Duration(i) * p.length)
+}
+
+// streaks returns the run of true values at the end of met and the longest
+// run. The last value is the period in progress: when false it does not
+// break the current run.
+func streaks(met []bool) (current, longest int) {
+	for i, m := range met {
+		if m {
+			current++
+			longest = max(longest, current)
+		} else if i < len(met)-1 {
+			current = 0
+		}
+	}
+	return current, longest
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)/float64(total)*1000) / 10
+}
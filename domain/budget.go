@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// BudgetPeriod is the length of one period of a recurring budget.
+type BudgetPeriod string
+
+const (
+	BudgetWeekly  BudgetPeriod = "weekly"
+	BudgetMonthly BudgetPeriod = "monthly"
+	BudgetYearly  BudgetPeriod = "yearly"
+)
+
+// IsValid reports whether p is a known budget period.
+func (p BudgetPeriod) IsValid() bool {
+	switch p {
+	case BudgetWeekly, BudgetMonthly, BudgetYearly:
+		return true
+	}
+	return false
+}
+
+// add moves t forward by n periods.
+func (p BudgetPeriod) add(t time.Time, n int) time.Time {
+	switch p {
+	case BudgetWeekly:
+		return t.AddDate(0, 0, 7*n)
+	case BudgetYearly:
+		return t.AddDate(n, 0, 0)
+	default:
+		return t.AddDate(0, n, 0)
+	}
+}
+
+// Start returns the beginning of the calendar period containing t:
+// Monday for weekly, the 1st for monthly, January 1st for yearly.
+func (p BudgetPeriod) Start(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch p {
+	case BudgetWeekly:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case BudgetYearly:
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// RecurringBudget is a budget definition that repeats every period from
+// StartsAt until EndsAt (open-ended when nil).
+type RecurringBudget struct {
+	ID        int64        `db:"id"`
+	UserID    int64        `db:"user_id"`
+	Name      string       `db:"name"`
+	Category  string       `db:"category"`
+	AmountEUR float64      `db:"amount_eur"`
+	Period    BudgetPeriod `db:"period"`
+	Rollover  bool         `db:"rollover"` // carry unused or overspent amounts into the next period
+	StartsAt  time.Time    `db:"starts_at"`
+	EndsAt    *time.Time   `db:"ends_at"`
+	CreatedAt time.Time    `db:"created_at"`
+	UpdatedAt time.Time    `db:"updated_at"`
+}
+
+// PeriodsUntil returns every period of the definition that has started by at,
+// oldest first, as budgets ready to be stored. Periods are computed from
+// StartsAt rather than from each other so month lengths do not drift.
+// Each period ends one microsecond (Postgres resolution) before the next one
+// starts, matching the inclusive ends_at of one-off budgets.
+func (rb RecurringBudget) PeriodsUntil(at time.Time) []Budget {
+	var periods []Budget
+	for n := 0; ; n++ {
+		start := rb.Period.add(rb.StartsAt, n)
+		if start.After(at) || (rb.EndsAt != nil && !start.Before(*rb.EndsAt)) {
+			return periods
+		}
+		id := rb.ID
+		periods = append(periods, Budget{
+			UserID:            rb.UserID,
+			Name:              rb.Name + " " + start.Format(time.DateOnly),
+			Category:          rb.Category,
+			AmountEUR:         rb.AmountEUR,
+			StartsAt:          start,
+			EndsAt:            rb.Period.add(rb.StartsAt, n+1).Add(-time.Microsecond),
+			RecurringBudgetID: &id,
+		})
+	}
+}
+
+// ApplyRollover fills CarriedOverEUR and RemainingEUR for consecutive periods
+// of one recurring budget, oldest first. Without rollover every period starts
+// from its own amount; with it the remainder of each period, negative when
+// overspent, is added to the next one.
+func ApplyRollover(periods []BudgetProgress, rollover bool) {
+	carry := 0.0
+	for i := range periods {
+		p := &periods[i]
+		p.CarriedOverEUR = carry
+		p.RemainingEUR = roundCents(p.AmountEUR + carry - p.SpentEUR)
+		if rollover {
+			carry = p.RemainingEUR
+		}
+	}
+}
+
+// Forecast curves.
+const (
+	CurveLinear   = "linear"   // spending spread evenly over the period
+	CurveSeasonal = "seasonal" // average shape of past periods of the same budget
+)
+
+// minSeasonalShare is the lowest expected share of spending at which the
+// seasonal curve is trusted for projection; earlier it amplifies noise.
+const minSeasonalShare = 0.05
+
+// BudgetForecast tells whether a budget is on pace to last its period.
+type BudgetForecast struct {
+	Curve                 string
+	ElapsedFraction       float64  // share of the period that has passed
+	DailyBurnEUR          float64  // spent so far per elapsed day
+	ExpectedSpentEUR      float64  // where spending should be by now according to the curve
+	ProjectedSpendEUR     float64  // expected spend at period end
+	ProjectedRemainingEUR float64  // available minus projected spend, negative when overspending
+	OnPace                bool     // spent does not exceed expected
+	DaysUntilExhausted    *float64 // at the current daily burn; nil when nothing was spent
+}
+
+// ForecastBudget projects end-of-period spending of p as of at.
+// pastShares holds, for each past period of the same recurring budget, the
+// share of that period's total spending that had happened at the same point
+// of the period. With any usable share the average becomes the expected
+// curve, so budgets that spend most on rent day or before holidays are not
+// flagged early; otherwise spending is assumed linear.
+func ForecastBudget(p BudgetProgress, at time.Time, pastShares []float64) BudgetForecast {
+	total := p.EndsAt.Sub(p.StartsAt)
+	elapsed := at.Sub(p.StartsAt)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if elapsed > total {
+		elapsed = total
+	}
+
+	f := BudgetForecast{Curve: CurveLinear}
+	if total > 0 {
+		f.ElapsedFraction = float64(elapsed) / float64(total)
+	}
+	available := p.AmountEUR + p.CarriedOverEUR
+
+	share := f.ElapsedFraction
+	if len(pastShares) > 0 {
+		sum := 0.0
+		for _, s := range pastShares {
+			sum += s
+		}
+		if avg := sum / float64(len(pastShares)); avg >= minSeasonalShare {
+			share = avg
+			f.Curve = CurveSeasonal
+		}
+	}
+
+	elapsedDays := math.Max(elapsed.Hours()/24, 1)
+	f.DailyBurnEUR = roundCents(p.SpentEUR / elapsedDays)
+	f.ExpectedSpentEUR = roundCents(available * share)
+	if f.Curve == CurveSeasonal {
+		f.ProjectedSpendEUR = roundCents(p.SpentEUR / share)
+	} else {
+		// Burn per day of at least one day, so a purchase in the first hour
+		// is not projected a thousand times over.
+		f.ProjectedSpendEUR = roundCents(math.Max(p.SpentEUR, p.SpentEUR/elapsedDays*total.Hours()/24))
+	}
+	f.ProjectedRemainingEUR = roundCents(available - f.ProjectedSpendEUR)
+	f.OnPace = p.SpentEUR <= f.ExpectedSpentEUR
+
+	remaining := available - p.SpentEUR
+	switch {
+	case remaining <= 0:
+		f.DaysUntilExhausted = new(float64)
+	case f.DailyBurnEUR > 0:
+		days := math.Round(remaining/f.DailyBurnEUR*10) / 10
+		f.DaysUntilExhausted = &days
+	}
+	return f
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// BurndownVelocityDays is the recent window the burn velocity is measured
+// over.
+const BurndownVelocityDays = 14
+
+// BurndownPoint is the remaining work at the end of a UTC day.
+type BurndownPoint struct {
+	Day       time.Time
+	HoursLeft float64
+}
+
+// Burndown is the hours_left history of a project and its projection.
+type Burndown struct {
+	Series      []BurndownPoint // days the estimate changed, oldest first
+	HoursLeft   float64
+	Velocity    *float64   // hours burned per day over the velocity window; nil with less than a day of history
+	ProjectedAt *time.Time // when HoursLeft reaches zero at Velocity; nil when done or not burning down
+}
+
+// BurndownReport builds the burndown of the points with hours_left. Points
+// of several activities (a project and its sub-activities) add up: each day
+// sums the latest estimate of every activity that has one. Returns nil when
+// no point carries an estimate.
+func BurndownReport(points []ActivityPoint, now time.Time) *Burndown {
+	var estimates []ActivityPoint
+	for _, p := range points {
+		if p.HoursLeft != nil && !p.ProgressAt.After(now) {
+			estimates = append(estimates, p)
+		}
+	}
+	if len(estimates) == 0 {
+		return nil
+	}
+	sort.SliceStable(estimates, func(i, j int) bool { return estimates[i].ProgressAt.Before(estimates[j].ProgressAt) })
+
+	latest := make(map[int64]float64)
+	var series []BurndownPoint
+	for _, p := range estimates {
+		latest[p.ActivityID] = *p.HoursLeft
+		var total float64
+		for _, h := range latest {
+			total += h
+		}
+		day := p.ProgressAt.UTC().Truncate(24 * time.Hour)
+		point := BurndownPoint{Day: day, HoursLeft: roundHours(total)}
+		if n := len(series); n > 0 && series[n-1].Day.Equal(day) {
+			series[n-1] = point
+		} else {
+			series = append(series, point)
+		}
+	}
+
+	b := &Burndown{Series: series, HoursLeft: series[len(series)-1].HoursLeft}
+
+	// Remaining work at the start of the window, or at the first estimate
+	// when the history is shorter.
+	windowStart := now.AddDate(0, 0, -BurndownVelocityDays)
+	start := series[0]
+	for _, p := range series {
+		if p.Day.After(windowStart) {
+			break
+		}
+		start = p
+	}
+	from := start.Day
+	if from.Before(windowStart) {
+		from = windowStart
+	}
+	days := now.Sub(from).Hours() / 24
+	if days < 1 {
+		return b
+	}
+	velocity := roundHours((start.HoursLeft - b.HoursLeft) / days)
+	b.Velocity = &velocity
+	if velocity > 0 && b.HoursLeft > 0 {
+		projected := now.Add(time.Duration(b.HoursLeft / velocity * 24 * float64(time.Hour)))
+		b.ProjectedAt = &projected
+	}
+	return b
+}
+
+func roundHours(v float64) float64 {
+	return math.Round(v*100) / 100
+}
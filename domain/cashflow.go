@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// MonthlyCashflow is income and expense of one calendar month in EUR.
+type MonthlyCashflow struct {
+	Month      time.Time // first day of the month, UTC
+	IncomeEUR  float64
+	ExpenseEUR float64
+}
+
+// MonthlyCategorySpending is the expense total of one category in one month.
+type MonthlyCategorySpending struct {
+	Month    time.Time
+	Category string
+	TotalEUR float64
+}
+
+// AccountMonthNet is the signed change of one account in one month, in the
+// account currency.
+type AccountMonthNet struct {
+	Account string
+	Month   time.Time
+	Net     float64
+}
+
+// CashflowPoint is one month of a cash-flow series.
+type CashflowPoint struct {
+	Month       time.Time
+	IncomeEUR   float64
+	ExpenseEUR  float64
+	NetEUR      float64
+	SavingsRate *float64 // net as a share of income; nil without income
+}
+
+// CategorySeries is one row of a category-by-month matrix.
+type CategorySeries struct {
+	Category  string
+	MonthsEUR []float64 // aligned with the report months
+	TotalEUR  float64
+}
+
+// NetWorthSeries is the month-end balance of one account.
+type NetWorthSeries struct {
+	Account  string
+	Currency string
+	Balances []float64 // aligned with the report months
+}
+
+// MonthsBetween returns the first day of every month from the month of from
+// through the month of to, in UTC.
+func MonthsBetween(from, to time.Time) []time.Time {
+	from, to = from.UTC(), to.UTC()
+	month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	var months []time.Time
+	for !month.After(to) {
+		months = append(months, month)
+		month = month.AddDate(0, 1, 0)
+	}
+	return months
+}
+
+// SavingsRate is the share of income kept, nil when there was no income.
+func SavingsRate(incomeEUR, expenseEUR float64) *float64 {
+	if incomeEUR <= 0 {
+		return nil
+	}
+	rate := roundCents((incomeEUR - expenseEUR) / incomeEUR * 100)
+	return &rate
+}
+
+// CashflowSeries lays monthly totals onto months, filling gaps with zero.
+func CashflowSeries(months []time.Time, rows []MonthlyCashflow) []CashflowPoint {
+	index := monthIndex(months)
+	points := make([]CashflowPoint, len(months))
+	for i, m := range months {
+		points[i].Month = m
+	}
+	for _, r := range rows {
+		if i, ok := index[r.Month.Unix()]; ok {
+			points[i].IncomeEUR += r.IncomeEUR
+			points[i].ExpenseEUR += r.ExpenseEUR
+		}
+	}
+	for i := range points {
+		p := &points[i]
+		p.IncomeEUR = roundCents(p.IncomeEUR)
+		p.ExpenseEUR = roundCents(p.ExpenseEUR)
+		p.NetEUR = roundCents(p.IncomeEUR - p.ExpenseEUR)
+		p.SavingsRate = SavingsRate(p.IncomeEUR, p.ExpenseEUR)
+	}
+	return points
+}
+
+// CategoryMatrix pivots monthly category totals into one row per category,
+// largest total first, with a value for every month.
+func CategoryMatrix(months []time.Time, rows []MonthlyCategorySpending) []CategorySeries {
+	index := monthIndex(months)
+	byCategory := make(map[string]*CategorySeries)
+	var matrix []*CategorySeries
+	for _, r := range rows {
+		i, ok := index[r.Month.Unix()]
+		if !ok {
+			continue
+		}
+		s := byCategory[r.Category]
+		if s == nil {
+			s = &CategorySeries{Category: r.Category, MonthsEUR: make([]float64, len(months))}
+			byCategory[r.Category] = s
+			matrix = append(matrix, s)
+		}
+		s.MonthsEUR[i] = roundCents(s.MonthsEUR[i] + r.TotalEUR)
+		s.TotalEUR = roundCents(s.TotalEUR + r.TotalEUR)
+	}
+	sort.SliceStable(matrix, func(i, j int) bool {
+		if matrix[i].TotalEUR != matrix[j].TotalEUR {
+			return matrix[i].TotalEUR > matrix[j].TotalEUR
+		}
+		return matrix[i].Category < matrix[j].Category
+	})
+	result := make([]CategorySeries, len(matrix))
+	for i, s := range matrix {
+		result[i] = *s
+	}
+	return result
+}
+
+// NetWorth accumulates month-end balances for each account from its balance
+// just before the first month. An account counts as zero for months that end
+// before it was opened.
+func NetWorth(months []time.Time, start []AccountBalance, nets []AccountMonthNet) []NetWorthSeries {
+	index := monthIndex(months)
+	changes := make(map[string][]float64, len(start))
+	for _, a := range start {
+		changes[a.Name] = make([]float64, len(months))
+	}
+	for _, n := range nets {
+		i, ok := index[n.Month.Unix()]
+		if c := changes[n.Account]; ok && c != nil {
+			c[i] += n.Net
+		}
+	}
+
+	result := make([]NetWorthSeries, len(start))
+	for k, a := range start {
+		s := NetWorthSeries{Account: a.Name, Currency: a.Currency, Balances: make([]float64, len(months))}
+		balance := a.Balance
+		for i, m := range months {
+			balance += changes[a.Name][i]
+			if m.AddDate(0, 1, 0).After(a.OpenedAt) {
+				s.Balances[i] = roundCents(balance)
+			}
+		}
+		result[k] = s
+	}
+	return result
+}
+
+// monthIndex maps month starts, as Unix seconds, to their position.
+func monthIndex(months []time.Time) map[int64]int {
+	index := make(map[int64]int, len(months))
+	for i, m := range months {
+		index[m.Unix()] = i
+	}
+	return index
+}
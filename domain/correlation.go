@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DailySeries is one value per local day. Days missing from Values were not
+// observed and are left out of correlations; a zero is a real zero.
+type DailySeries struct {
+	Key    string
+	Label  string
+	Values map[time.Time]float64 // keyed by local midnight
+}
+
+// NonZeroDays counts the observed days with a non-zero value.
+func (s DailySeries) NonZeroDays() int {
+	n := 0
+	for _, v := range s.Values {
+		if v != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Correlation is the Pearson correlation of a driver series shifted by Lag
+// days against an outcome: the driver on day d-Lag is paired with the
+// outcome on day d.
+type Correlation struct {
+	Driver  string
+	Outcome string
+	Lag     int
+	R       float64
+	N       int     // days with both values
+	PValue  float64 // two-sided, Fisher z approximation
+	NonZero int     // paired days where the driver is non-zero
+}
+
+// Correlate pairs the two series at the given lag. ok is false when fewer
+// than three days pair up or either side is constant.
+func Correlate(driver, outcome DailySeries, lag int) (c Correlation, ok bool) {
+	var xs, ys []float64
+	nonZero := 0
+	for day, y := range outcome.Values {
+		x, found := driver.Values[day.AddDate(0, 0, -lag)]
+		if !found {
+			continue
+		}
+		xs = append(xs, x)
+		ys = append(ys, y)
+		if x != 0 {
+			nonZero++
+		}
+	}
+	r, ok := pearson(xs, ys)
+	if !ok {
+		return Correlation{}, false
+	}
+	return Correlation{
+		Driver:  driver.Key,
+		Outcome: outcome.Key,
+		Lag:     lag,
+		R:       math.Round(r*1000) / 1000,
+		N:       len(xs),
+		PValue:  math.Round(correlationPValue(r, len(xs))*10000) / 10000,
+		NonZero: nonZero,
+	}, true
+}
+
+// FindCorrelations correlates every driver with every outcome at lags
+// 0..maxLag and keeps the pairs observed on at least minDays days, strongest
+// first. A series is never paired with itself at lag 0, and two outcomes
+// used as drivers of each other are only tested once at lag 0.
+func FindCorrelations(drivers, outcomes []DailySeries, maxLag, minDays int) []Correlation {
+	var results []Correlation
+	isOutcome := make(map[string]bool, len(outcomes))
+	for _, o := range outcomes {
+		isOutcome[o.Key] = true
+	}
+	for _, d := range drivers {
+		for _, o := range outcomes {
+			for lag := 0; lag <= maxLag; lag++ {
+				if lag == 0 && (d.Key == o.Key || (isOutcome[d.Key] && d.Key > o.Key)) {
+					continue
+				}
+				if c, ok := Correlate(d, o, lag); ok && c.N >= minDays {
+					results = append(results, c)
+				}
+			}
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		ai, aj := math.Abs(results[i].R), math.Abs(results[j].R)
+		if ai != aj {
+			return ai > aj
+		}
+		return results[i].PValue < results[j].PValue
+	})
+	return results
+}
+
+func pearson(xs, ys []float64) (float64, bool) {
+	n := float64(len(xs))
+	if len(xs) < 3 {
+		return 0, false
+	}
+	var sx, sy float64
+	for i := range xs {
+		sx += xs[i]
+		sy += ys[i]
+	}
+	mx, my := sx/n, sy/n
+	var cov, vx, vy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		cov += dx * dy
+		vx += dx * dx
+		vy += dy * dy
+	}
+	if vx == 0 || vy == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(vx*vy), true
+}
+
+// correlationPValue tests r against zero with the Fisher transform, which is
+// close enough to the exact t-test from about ten days on.
+func correlationPValue(r float64, n int) float64 {
+	if n <= 3 {
+		return 1
+	}
+	if math.Abs(r) >= 1 {
+		return 0
+	}
+	z := math.Atanh(r) * math.Sqrt(float64(n-3))
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// CorrelationStrength labels |r|.
+func CorrelationStrength(r float64) string {
+	switch a := math.Abs(r); {
+	case a < 0.1:
+		return "negligible"
+	case a < 0.3:
+		return "weak"
+	case a < 0.5:
+		return "moderate"
+	default:
+		return "strong"
+	}
+}
+
+// ActivityDailySeries averages the point values of an activity per local day.
+func ActivityDailySeries(key, label string, points []ActivityPoint, loc *time.Location) DailySeries {
+	sums := map[time.Time]float64{}
+	counts := map[time.Time]int{}
+	for _, p := range points {
+		day := localDay(p.ProgressAt, loc)
+		sums[day] += float64(p.Value)
+		counts[day]++
+	}
+	s := DailySeries{Key: key, Label: label, Values: make(map[time.Time]float64, len(sums))}
+	for day, sum := range sums {
+		s.Values[day] = sum / float64(counts[day])
+	}
+	return s
+}
+
+// NutritionDailySeries sums calories, caffeine (mg) and alcohol (g) per local
+// day. Only days with food logged are observed, so an unlogged day does not
+// count as a day without alcohol.
+func NutritionDailySeries(logs []*ConsumptionLog, loc *time.Location) (calories, caffeine, alcohol DailySeries) {
+	calories = DailySeries{Key: "calories", Label: "Calories (kcal)", Values: map[time.Time]float64{}}
+	caffeine = DailySeries{Key: "caffeine", Label: "Caffeine (mg)", Values: map[time.Time]float64{}}
+	alcohol = DailySeries{Key: "alcohol", Label: "Alcohol (g)", Values: map[time.Time]float64{}}
+	for _, l := range logs {
+		day := localDay(l.ConsumedAt, loc)
+		calories.Values[day] += 0
+		caffeine.Values[day] += 0
+		alcohol.Values[day] += 0
+		if l.Nutrients == nil {
+			continue
+		}
+		if v := l.Nutrients.Calories; v != nil {
+			calories.Values[day] += *v
+		}
+		if v := l.Nutrients.CaffeineMg; v != nil {
+			caffeine.Values[day] += *v
+		}
+		if v := l.Nutrients.EthylAlcoholG; v != nil {
+			alcohol.Values[day] += *v
+		}
+	}
+	return calories, caffeine, alcohol
+}
+
+// TrainingVolumeSeries sums reps x weight per local day over [from, to];
+// days without sets are zero.
+func TrainingVolumeSeries(sets []Set, from, to time.Time, loc *time.Location) DailySeries {
+	s := zeroSeries("training_volume", "Training volume (kg)", from, to, loc)
+	for _, set := range sets {
+		day := localDay(set.CreatedAt, loc)
+		if _, ok := s.Values[day]; ok {
+			s.Values[day] += float64(set.Reps) * set.WeightKg
+		}
+	}
+	return s
+}
+
+// SpendSeries sums the EUR expenses under the category prefixes per local
+// day over [from, to]; days without such spending are zero. Split
+// transactions count by their lines' categories, keyed by transaction ID in
+// splits. Transfers are skipped.
+func SpendSeries(txs []*Transaction, splits map[int64][]TransactionSplit, categories []string, from, to time.Time, loc *time.Location) DailySeries {
+	s := zeroSeries("discretionary_spend", "Discretionary spend (EUR)", from, to, loc)
+	for _, t := range txs {
+		if t.Type != TransactionTypeExpense || t.TransferPeerID != nil {
+			continue
+		}
+		day := localDay(t.TransactedAt, loc)
+		if _, ok := s.Values[day]; !ok {
+			continue
+		}
+		lines := splits[t.ID]
+		if len(lines) == 0 {
+			lines = []TransactionSplit{{Category: t.Category, AmountEUR: t.AmountEUR}}
+		}
+		for _, l := range lines {
+			if isTransferCategory(l.Category) || !underCategories(l.Category, categories) {
+				continue
+			}
+			s.Values[day] += l.AmountEUR
+		}
+	}
+	return s
+}
+
+func zeroSeries(key, label string, from, to time.Time, loc *time.Location) DailySeries {
+	s := DailySeries{Key: key, Label: label, Values: map[time.Time]float64{}}
+	for day := localDay(from, loc); !day.After(to); day = day.AddDate(0, 0, 1) {
+		s.Values[day] = 0
+	}
+	return s
+}
+
+func underCategories(category string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if category == p || strings.HasPrefix(category, p+"/") {
+			return true
+		}
+	}
+	return false
+}
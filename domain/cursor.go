@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Cursor kinds, so a cursor from one listing is rejected by another.
+const (
+	CursorTransactions    = "transactions"
+	CursorWorkouts        = "workouts"
+	CursorExerciseHistory = "exercise_history"
+	CursorProgressNotes   = "progress_notes"
+)
+
+// Cursor is a keyset position: the sort key of the last row of a page. The
+// next page starts strictly after it, so rows inserted while paging neither
+// repeat nor shift other rows. Rank is a leading sort key for orderings that
+// sort on something before time, such as a match count and a search rank.
+// Model names the embedding model a rank was computed with, empty for
+// full-text ranks; ranks of different models do not compare.
+type Cursor struct {
+	Kind  string    `json:"k"`
+	Count int       `json:"c,omitempty"`
+	Rank  float64   `json:"r,omitempty"`
+	Model string    `json:"m,omitempty"`
+	At    time.Time `json:"t"`
+	ID    int64     `json:"i"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a string produced by Encode for a listing of kind.
+func DecodeCursor(s, kind string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	var c Cursor
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	if c.Kind != kind {
+		return nil, fmt.Errorf("cursor belongs to another listing")
+	}
+	return &c, nil
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Life part balance statuses.
+const (
+	LifeThriving     = "thriving"
+	LifeSteady       = "steady"
+	LifeStruggling   = "struggling"
+	LifeNeglected    = "neglected"
+	LifeNoActivities = "no_activities"
+)
+
+// Thresholds of the balance status. An area is neglected when fewer than
+// half of the expected check-ins were made, thriving when the average value
+// is at least +1 and struggling when it is below zero.
+const (
+	lifeNeglectedCheckInRate = 0.5
+	lifeThrivingAverage      = 1.0
+)
+
+// LifeBalance is how one life part went over a period. LifePart.ID is zero
+// for activities without a life part.
+type LifeBalance struct {
+	LifePart         LifePart
+	ActivityIDs      []int64
+	Points           int
+	ExpectedCheckIns float64  // sum over activities of active days / frequency
+	CheckInRate      *float64 // Points / ExpectedCheckIns, capped at 1; nil when nothing was expected
+	Average          *float64 // mean normalized point value; nil without points
+	LastPointAt      *time.Time
+	Status           string
+}
+
+// LifeBalanceReport averages the progress points of [from, to] per life part.
+// Values are normalized to -2..+2 by the scale of their activity first.
+// An activity linked to several parts counts in each of them; activities
+// without a part are reported under a trailing part with zero ID when there
+// are any. Parts keep the given order.
+func LifeBalanceReport(parts []LifePart, activities []Activity, scales []ProgressScale, points []ActivityPoint, from, to time.Time) []LifeBalance {
+	balances := make([]LifeBalance, len(parts))
+	index := make(map[int64]int, len(parts))
+	for i, p := range parts {
+		balances[i] = LifeBalance{LifePart: p, ActivityIDs: []int64{}}
+		index[p.ID] = i
+	}
+
+	activityParts := make(map[int64][]int, len(activities))
+	activityScales := make(map[int64]ProgressScale, len(activities))
+	unassigned := -1
+	for _, a := range activities {
+		activityScales[a.ID] = ScaleOf(a, scales)
+
+		var linked []int
+		for _, id := range a.LifePartIDs {
+			if i, ok := index[id]; ok {
+				linked = append(linked, i)
+			}
+		}
+		if len(linked) == 0 {
+			if unassigned < 0 {
+				unassigned = len(balances)
+				balances = append(balances, LifeBalance{LifePart: LifePart{Name: "unassigned"}, ActivityIDs: []int64{}})
+			}
+			linked = []int{unassigned}
+		}
+		activityParts[a.ID] = linked
+
+		expected := expectedCheckIns(a, from, to)
+		for _, i := range linked {
+			balances[i].ActivityIDs = append(balances[i].ActivityIDs, a.ID)
+			balances[i].ExpectedCheckIns += expected
+		}
+	}
+
+	sums := make([]float64, len(balances))
+	for _, p := range points {
+		if p.ProgressAt.Before(from) || p.ProgressAt.After(to) {
+			continue
+		}
+		for _, i := range activityParts[p.ActivityID] {
+			b := &balances[i]
+			b.Points++
+			sums[i] += activityScales[p.ActivityID].Normalize(p.Value)
+			if b.LastPointAt == nil || p.ProgressAt.After(*b.LastPointAt) {
+				at := p.ProgressAt
+				b.LastPointAt = &at
+			}
+		}
+	}
+
+	for i := range balances {
+		b := &balances[i]
+		b.ExpectedCheckIns = math.Round(b.ExpectedCheckIns*10) / 10
+		if b.Points > 0 {
+			avg := math.Round(sums[i]/float64(b.Points)*100) / 100
+			b.Average = &avg
+		}
+		if b.ExpectedCheckIns > 0 {
+			rate := math.Round(math.Min(float64(b.Points)/b.ExpectedCheckIns, 1)*100) / 100
+			b.CheckInRate = &rate
+		}
+		b.Status = lifeBalanceStatus(*b)
+	}
+	return balances
+}
+
+func lifeBalanceStatus(b LifeBalance) string {
+	switch {
+	case len(b.ActivityIDs) == 0:
+		return LifeNoActivities
+	case b.Average == nil || (b.CheckInRate != nil && *b.CheckInRate < lifeNeglectedCheckInRate):
+		return LifeNeglected
+	case *b.Average >= lifeThrivingAverage:
+		return LifeThriving
+	case *b.Average < 0:
+		return LifeStruggling
+	default:
+		return LifeSteady
+	}
+}
+
+// expectedCheckIns is how many points an activity should get in [from, to]
+// given its frequency, counting only the days it was running.
+func expectedCheckIns(a Activity, from, to time.Time) float64 {
+	start, end := from, to
+	if a.StartedAt.After(start) {
+		start = a.StartedAt
+	}
+	if a.EndedAt != nil && a.EndedAt.Before(end) {
+		end = *a.EndedAt
+	}
+	if !end.After(start) || a.FrequencyDays < 1 {
+		return 0
+	}
+	return end.Sub(start).Hours() / 24 / float64(a.FrequencyDays)
+}
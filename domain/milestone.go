@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// Milestone is a checkpoint of an activity, usually a project.
+type Milestone struct {
+	ID         int64      `json:"id" db:"id"`
+	UserID     int64      `json:"user_id" db:"user_id"`
+	ActivityID int64      `json:"activity_id" db:"activity_id"`
+	Name       string     `json:"name" db:"name"`
+	DueAt      *time.Time `json:"due_at,omitempty" db:"due_at"`   // NULL = no due date
+	DoneAt     *time.Time `json:"done_at,omitempty" db:"done_at"` // NULL = open
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Done reports whether the milestone is reached.
+func (m Milestone) Done() bool {
+	return m.DoneAt != nil
+}
+
+// Overdue reports whether an open milestone is past its due date.
+func (m Milestone) Overdue(now time.Time) bool {
+	return !m.Done() && m.DueAt != nil && m.DueAt.Before(now)
+}
+
+// Descendants returns the IDs of all sub-activities of id, at any depth.
+func Descendants(activities []Activity, id int64) []int64 {
+	children := make(map[int64][]int64)
+	for _, a := range activities {
+		if a.ParentID != nil {
+			children[*a.ParentID] = append(children[*a.ParentID], a.ID)
+		}
+	}
+
+	var ids []int64
+	seen := map[int64]bool{id: true}
+	queue := []int64{id}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, c := range children[next] {
+			if !seen[c] {
+				seen[c] = true
+				ids = append(ids, c)
+				queue = append(queue, c)
+			}
+		}
+	}
+	return ids
+}
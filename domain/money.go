@@ -1,6 +1,12 @@
 package domain
 
-import "time"
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
 
 // TransactionType represents the direction of a financial transaction.
 type TransactionType string
@@ -11,6 +17,24 @@ const (
 	TransactionTypeTransfer TransactionType = "transfer"
 )
 
+// Direction tells whether a transaction moved money into or out of its
+// account. Needed for transfers, whose type carries no sign.
+type Direction string
+
+const (
+	DirectionIn  Direction = "in"
+	DirectionOut Direction = "out"
+)
+
+// DefaultDirection derives the direction from the transaction type:
+// income is money in, expenses and transfers are money out.
+func DefaultDirection(t TransactionType) Direction {
+	if t == TransactionTypeIncome {
+		return DirectionIn
+	}
+	return DirectionOut
+}
+
 // Transaction is a single financial record.
 type Transaction struct {
 	ID                  int64           `db:"id"`
@@ -24,10 +48,23 @@ type Transaction struct {
 	Merchant            string          `db:"merchant"`
 	Note                *string         `db:"note"`
 	OriginalDescription *string         `db:"original_description"`
-	TransactedAt        time.Time       `db:"transacted_at"`
+	ExternalID          *string         `db:"external_id"` // bank transaction ID (OFX FITID, CAMT AcctSvcrRef, ...)
+	Direction           Direction       `db:"direction"`
+	BalanceAfter        *float64        `db:"balance_after"`    // account balance reported by the bank export
+	TransferPeerID      *int64          `db:"transfer_peer_id"` // other side of a paired transfer between own accounts
+	TransactedAt        time.Time       `db:"transacted_at"`    // booking date
+	ValueDate           *time.Time      `db:"value_date"`       // value date, when the statement provides one (OFX DTAVAIL, CAMT ValDt)
 	CreatedAt           time.Time       `db:"created_at"`
 }
 
+// SignedAmount is the original-currency amount with the account-balance sign.
+func (t Transaction) SignedAmount() float64 {
+	if t.Direction == DirectionIn {
+		return t.AmountOriginal
+	}
+	return -t.AmountOriginal
+}
+
 // Budget represents a spending limit for a category over a time period.
 type Budget struct {
 	ID        int64     `db:"id"`
@@ -38,26 +75,38 @@ type Budget struct {
 	StartsAt  time.Time `db:"starts_at"`
 	EndsAt    time.Time `db:"ends_at"`
 	CreatedAt time.Time `db:"created_at"`
+
+	RecurringBudgetID *int64 `db:"recurring_budget_id"` // set on periods materialized from a RecurringBudget
 }
 
 // TransactionFilter defines query parameters for listing transactions.
 type TransactionFilter struct {
-	UserID   int64
-	From     *time.Time
-	To       *time.Time
-	Account  *string
-	Category *string
-	Type     *TransactionType
-	Merchant *string
-	Limit    int
-	Offset   int
+	UserID    int64
+	From      *time.Time
+	To        *time.Time
+	Account   *string
+	Category  *string
+	Type      *TransactionType
+	Merchant  *string
+	Tags      []string // transaction must carry every tag
+	Query     *string  // text search over merchant, note and original_description
+	MinEUR    *float64 // inclusive amount_eur bounds
+	MaxEUR    *float64
+	Limit     int
+	Offset    int
+	After     *Cursor // keyset: only rows older than the cursor; Offset is ignored
+	SkipTotal bool    // skip counting all matches
 }
 
+// MaxTransactionsPage is the largest page get_transactions returns.
+const MaxTransactionsPage = 1000
+
 // TransactionUpdate is one item in a bulk edit_transactions call.
 // All fields except ID are optional.
 type TransactionUpdate struct {
 	ID                  int64
 	Type                *TransactionType
+	Direction           *Direction // defaults from Type when only Type changes
 	AmountOriginal      *float64
 	Currency            *string
 	AmountEUR           *float64
@@ -86,8 +135,9 @@ type MerchantSummary struct {
 // BudgetProgress is a budget enriched with spent and remaining amounts.
 type BudgetProgress struct {
 	Budget
-	SpentEUR     float64
-	RemainingEUR float64
+	SpentEUR       float64
+	CarriedOverEUR float64 // rollover from the previous period of a recurring budget
+	RemainingEUR   float64
 }
 
 // BalanceResult is income minus expenses for a period.
@@ -98,3 +148,386 @@ type BalanceResult struct {
 	ExpenseEUR float64
 	BalanceEUR float64
 }
+
+// CategoryCorrection is a manual category fix recorded as a training signal
+// for import-time categorization.
+type CategoryCorrection struct {
+	ID                  int64     `db:"id"`
+	UserID              int64     `db:"user_id"`
+	TransactionID       int64     `db:"transaction_id"`
+	Merchant            string    `db:"merchant"`
+	OriginalDescription string    `db:"original_description"`
+	Category            string    `db:"category"`
+	CreatedAt           time.Time `db:"created_at"`
+}
+
+// CategorySource tells which tier produced a category suggestion.
+type CategorySource string
+
+const (
+	CategorySourceRule        CategorySource = "rule"        // static keyword rules
+	CategorySourceMerchant    CategorySource = "merchant"    // learned merchant→category frequency
+	CategorySourceDescription CategorySource = "description" // learned naive Bayes over description tokens
+)
+
+// CategorySuggestion is an inferred category with a confidence score in [0, 1].
+type CategorySuggestion struct {
+	Category   string
+	Confidence float64
+	Source     CategorySource
+}
+
+// DecimalStyle is the decimal separator used in a bank export.
+type DecimalStyle string
+
+const (
+	DecimalStyleDot   DecimalStyle = "dot"   // 1,234.56
+	DecimalStyleComma DecimalStyle = "comma" // 1.234,56
+)
+
+// SignConvention tells how a single signed amount column maps to direction.
+type SignConvention string
+
+const (
+	SignNegativeIsExpense SignConvention = "negative_is_expense" // -5.00 is money out
+	SignPositiveIsExpense SignConvention = "positive_is_expense" // 5.00 is money out (credit card style)
+)
+
+// ImportProfile is a saved column mapping for the generic CSV parser.
+// Either AmountColumn or DebitColumn/CreditColumn must be set.
+type ImportProfile struct {
+	ID                int64          `db:"id"`
+	UserID            int64          `db:"user_id"`
+	Name              string         `db:"name"`
+	Aliases           []string       `db:"aliases"`
+	Delimiter         string         `db:"delimiter"`
+	DateColumn        string         `db:"date_column"`
+	DateFormat        string         `db:"date_format"` // Go layout or pattern like DD.MM.YYYY; empty = auto
+	DescriptionColumn string         `db:"description_column"`
+	AmountColumn      string         `db:"amount_column"`
+	DebitColumn       string         `db:"debit_column"`
+	CreditColumn      string         `db:"credit_column"`
+	CurrencyColumn    string         `db:"currency_column"`
+	DefaultCurrency   string         `db:"default_currency"`
+	DecimalStyle      DecimalStyle   `db:"decimal_style"`
+	SignConvention    SignConvention `db:"sign_convention"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at"`
+}
+
+// AccountType classifies an account.
+type AccountType string
+
+const (
+	AccountTypeChecking AccountType = "checking"
+	AccountTypeSavings  AccountType = "savings"
+	AccountTypeCredit   AccountType = "credit"
+	AccountTypeCash     AccountType = "cash"
+)
+
+// IsValid reports whether the account type is known.
+func (t AccountType) IsValid() bool {
+	switch t {
+	case AccountTypeChecking, AccountTypeSavings, AccountTypeCredit, AccountTypeCash:
+		return true
+	}
+	return false
+}
+
+// Account is a registered bank account, card or wallet. Transactions refer
+// to it by name; names match case-insensitively.
+type Account struct {
+	ID             int64       `db:"id"`
+	UserID         int64       `db:"user_id"`
+	Name           string      `db:"name"`
+	Currency       string      `db:"currency"`
+	Type           AccountType `db:"type"`
+	OpeningBalance float64     `db:"opening_balance"`
+	OpenedAt       time.Time   `db:"opened_at"` // transactions before this date are not counted
+	CreatedAt      time.Time   `db:"created_at"`
+	UpdatedAt      time.Time   `db:"updated_at"`
+}
+
+// AccountBalance is an account with its computed balance at a point in time.
+type AccountBalance struct {
+	Account
+	Balance                float64 // in the account's currency
+	OtherCurrencies        []CurrencyBalance
+	TransactionCount       int
+	LastTransactionAt      *time.Time
+	LastStatementBalance   *float64 // balance_after of the latest transaction that carries one
+	LastStatementBalanceAt *time.Time
+}
+
+// CurrencyBalance is the net of an account's transactions in a currency
+// other than its own, such as a multi-currency card's USD pocket.
+type CurrencyBalance struct {
+	Currency string
+	Balance  float64
+}
+
+// ReconciliationGap is a statement checkpoint where the computed running
+// balance drifted from the bank-reported balance.
+type ReconciliationGap struct {
+	Date             time.Time
+	TransactionID    int64
+	ComputedBalance  float64
+	StatementBalance float64
+	Difference       float64 // statement minus computed
+	DriftSincePrev   float64 // change in difference since the previous checkpoint
+}
+
+// Reconciliation compares computed running balances with the balances
+// reported in bank exports.
+type Reconciliation struct {
+	Account            Account
+	ComputedBalance    float64
+	StatementBalance   *float64
+	Difference         float64 // at the latest checkpoint
+	CheckpointsChecked int
+	Gaps               []ReconciliationGap
+}
+
+// Reconcile walks txs (ordered by transacted_at) from the opening balance and
+// checks the running balance at the end of every day that has a
+// bank-reported balance. Same-day rows have no reliable order in exports, so
+// the day's reported balance closest to the computed end-of-day balance is
+// used. A gap is reported whenever the difference changes by more than
+// tolerance: the first checkpoint catches a wrong opening balance, later ones
+// catch missing or duplicated transactions in between.
+func Reconcile(account Account, txs []Transaction, tolerance float64) Reconciliation {
+	result := Reconciliation{Account: account}
+	running := account.OpeningBalance
+	prevDiff := 0.0
+
+	for i := 0; i < len(txs); {
+		day := txs[i].TransactedAt.UTC().Truncate(24 * time.Hour)
+		var statement []float64
+		var lastID int64
+		for ; i < len(txs) && txs[i].TransactedAt.UTC().Truncate(24*time.Hour).Equal(day); i++ {
+			if !strings.EqualFold(txs[i].Currency, account.Currency) {
+				continue // another currency pocket, with its own statement balance
+			}
+			running += txs[i].SignedAmount()
+			lastID = txs[i].ID
+			if txs[i].BalanceAfter != nil {
+				statement = append(statement, *txs[i].BalanceAfter)
+			}
+		}
+		running = roundCents(running)
+		if len(statement) == 0 {
+			continue
+		}
+
+		closest := statement[0]
+		for _, b := range statement[1:] {
+			if math.Abs(b-running) < math.Abs(closest-running) {
+				closest = b
+			}
+		}
+
+		result.CheckpointsChecked++
+		diff := roundCents(closest - running)
+		if math.Abs(diff-prevDiff) > tolerance {
+			result.Gaps = append(result.Gaps, ReconciliationGap{
+				Date:             day,
+				TransactionID:    lastID,
+				ComputedBalance:  running,
+				StatementBalance: closest,
+				Difference:       diff,
+				DriftSincePrev:   roundCents(diff - prevDiff),
+			})
+		}
+		prevDiff = diff
+		statementBalance := closest
+		result.StatementBalance = &statementBalance
+	}
+
+	result.ComputedBalance = running
+	result.Difference = prevDiff
+	return result
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// TransferPair links the outgoing and incoming side of a move between two
+// of the user's own accounts.
+type TransferPair struct {
+	OutID int64
+	InID  int64
+}
+
+// TransferMatchOptions tunes MatchTransfers.
+type TransferMatchOptions struct {
+	Window       time.Duration // max time between the two sides
+	AmountTolPct float64       // allowed amount difference in percent, for fees and FX
+}
+
+// IsTransferLike reports whether a transaction already looks like a move
+// between accounts: typed transfer or categorized under transfer/.
+func (t Transaction) IsTransferLike() bool {
+	return t.Type == TransactionTypeTransfer || isTransferCategory(t.Category)
+}
+
+// isTransferCategory reports whether category is transfer or sits under it.
+func isTransferCategory(category string) bool {
+	return category == "transfer" || strings.HasPrefix(category, "transfer/")
+}
+
+// transferKeywords in a description or merchant mark a move between accounts.
+var transferKeywords = []string{"transfer", "trnsfr", "top-up", "topup", "top up"}
+
+// looksLikeTransferTo reports whether t is transfer-like, mentions a
+// transfer, or names the other side's account as its counterparty.
+func (t Transaction) looksLikeTransferTo(peerAccount string) bool {
+	if t.IsTransferLike() {
+		return true
+	}
+	text := strings.ToLower(t.Merchant)
+	if t.OriginalDescription != nil {
+		text += " " + strings.ToLower(*t.OriginalDescription)
+	}
+	for _, kw := range transferKeywords {
+		if strings.Contains(text, kw) {
+			return true
+		}
+	}
+	peer := strings.ToLower(strings.TrimSpace(peerAccount))
+	return peer != "" && strings.Contains(text, peer)
+}
+
+// MatchTransfers pairs opposite-direction transactions on different accounts
+// within the time window when at least one side looks like a transfer: it is
+// transfer-like, its description or merchant mentions a transfer or top-up,
+// or it names the other account. Amounts must be equal to the cent or within
+// AmountTolPct. An expense and an income of exactly the same amount with
+// neither side looking like a transfer are only returned as suggestions,
+// never paired. Amounts are compared in the original currency when both
+// sides share it, in EUR otherwise. Closest matches are paired first; each
+// transaction joins at most one pair or suggestion.
+func MatchTransfers(txs []Transaction, opts TransferMatchOptions) (pairs, suggestions []TransferPair) {
+	type candidate struct {
+		pair        TransferPair
+		transferish bool
+		gap         time.Duration
+		amountDiff  float64
+	}
+
+	var candidates []candidate
+	for _, out := range txs {
+		if out.Direction != DirectionOut || out.TransferPeerID != nil {
+			continue
+		}
+		for _, in := range txs {
+			if in.Direction != DirectionIn || in.TransferPeerID != nil {
+				continue
+			}
+			if strings.EqualFold(out.Account, in.Account) {
+				continue
+			}
+			gap := in.TransactedAt.Sub(out.TransactedAt)
+			if gap < 0 {
+				gap = -gap
+			}
+			if gap > opts.Window {
+				continue
+			}
+
+			a, b := out.AmountEUR, in.AmountEUR
+			if strings.EqualFold(out.Currency, in.Currency) {
+				a, b = out.AmountOriginal, in.AmountOriginal
+			}
+			diff := math.Abs(a - b)
+			transferish := out.looksLikeTransferTo(in.Account) || in.looksLikeTransferTo(out.Account)
+			switch {
+			case roundCents(diff) == 0:
+			case transferish && diff <= math.Max(a, b)*opts.AmountTolPct/100:
+			default:
+				continue
+			}
+
+			candidates = append(candidates, candidate{
+				pair:        TransferPair{OutID: out.ID, InID: in.ID},
+				transferish: transferish,
+				gap:         gap,
+				amountDiff:  diff,
+			})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if ci.transferish != cj.transferish {
+			return ci.transferish
+		}
+		if ci.amountDiff != cj.amountDiff {
+			return ci.amountDiff < cj.amountDiff
+		}
+		if ci.gap != cj.gap {
+			return ci.gap < cj.gap
+		}
+		return ci.pair.OutID < cj.pair.OutID
+	})
+
+	used := make(map[int64]bool)
+	for _, c := range candidates {
+		if used[c.pair.OutID] || used[c.pair.InID] {
+			continue
+		}
+		used[c.pair.OutID], used[c.pair.InID] = true, true
+		if c.transferish {
+			pairs = append(pairs, c.pair)
+		} else {
+			suggestions = append(suggestions, c.pair)
+		}
+	}
+	return pairs, suggestions
+}
+
+// TransactionSplit is one line item of a transaction split across categories.
+// Lines of a split transaction sum to its AmountOriginal.
+type TransactionSplit struct {
+	ID            int64   `db:"id"`
+	TransactionID int64   `db:"transaction_id"`
+	UserID        int64   `db:"user_id"`
+	Category      string  `db:"category"`
+	Amount        float64 `db:"amount"` // in the parent's currency
+	Note          *string `db:"note"`
+	AmountEUR     float64 `db:"-"` // converted at the parent's exchange rate
+}
+
+// Tag is a free-form label grouping transactions across categories, e.g. a
+// trip or a project. Names are stored normalized, see NormalizeTag.
+type Tag struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// TagSummary is a tag with usage totals.
+type TagSummary struct {
+	Name             string  `db:"name"`
+	TransactionCount int     `db:"transaction_count"`
+	SpentEUR         float64 `db:"spent_eur"` // expenses only
+}
+
+// TransactionTag links one transaction to one tag by name.
+type TransactionTag struct {
+	TransactionID int64  `db:"transaction_id"`
+	Name          string `db:"name"`
+}
+
+// HasSearchWords reports whether text contains anything a text search can
+// match: at least one letter or digit.
+func HasSearchWords(text string) bool {
+	return strings.IndexFunc(text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0
+}
+
+// NormalizeTag lowercases a tag and collapses inner whitespace, so
+// "Barcelona  Trip" and "barcelona trip" are the same tag.
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Notification kinds.
+const (
+	NotificationBudgetThreshold = "budget_threshold"
+	NotificationBudgetForecast  = "budget_forecast"
+)
+
+// Notification is an inbox entry. It is stored once per DedupKey, so
+// re-running a check does not alert twice for the same event.
+type Notification struct {
+	ID            int64      `db:"id" json:"id"`
+	UserID        int64      `db:"user_id" json:"-"`
+	Kind          string     `db:"kind" json:"kind"`
+	DedupKey      string     `db:"dedup_key" json:"dedup_key"`
+	Title         string     `db:"title" json:"title"`
+	Body          string     `db:"body" json:"body"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+	ReadAt        *time.Time `db:"read_at" json:"read_at,omitempty"`
+	DeliveredAt   *time.Time `db:"delivered_at" json:"delivered_at,omitempty"`
+	DeliveryError *string    `db:"delivery_error" json:"delivery_error,omitempty"`
+}
+
+// BudgetAlertSettings configures budget alerts for one user.
+type BudgetAlertSettings struct {
+	UserID         int64     `db:"user_id"`
+	Thresholds     []int     `db:"thresholds"`      // percent of the available amount, e.g. 80, 100
+	ForecastAlerts bool      `db:"forecast_alerts"` // alert when projected spend exceeds the budget
+	WebhookURL     *string   `db:"webhook_url"`     // nil keeps notifications in the inbox only
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// DefaultBudgetAlertSettings applies until the user saves their own.
+func DefaultBudgetAlertSettings(userID int64) BudgetAlertSettings {
+	return BudgetAlertSettings{UserID: userID, Thresholds: []int{80, 100}, ForecastAlerts: true}
+}
+
+// BudgetAlerts returns the notifications a budget period has earned: one per
+// crossed threshold and one when the forecast runs over. Keys include the
+// budget period ID, so each fires at most once per period.
+func BudgetAlerts(p BudgetProgress, f BudgetForecast, settings BudgetAlertSettings) []Notification {
+	available := p.AmountEUR + p.CarriedOverEUR
+	if available <= 0 {
+		available = p.AmountEUR
+	}
+	usedPct := p.SpentEUR / available * 100
+
+	var alerts []Notification
+	for _, threshold := range settings.Thresholds {
+		if usedPct < float64(threshold) {
+			continue
+		}
+		alerts = append(alerts, Notification{
+			UserID:   p.UserID,
+			Kind:     NotificationBudgetThreshold,
+			DedupKey: fmt.Sprintf("budget:%d:threshold:%d", p.ID, threshold),
+			Title:    fmt.Sprintf("%s: %d%% of budget used", p.Name, threshold),
+			Body: fmt.Sprintf("Spent %.2f of %.2f EUR (%.0f%%) with %.0f%% of the period gone.",
+				p.SpentEUR, available, usedPct, f.ElapsedFraction*100),
+		})
+	}
+
+	if settings.ForecastAlerts && f.ProjectedRemainingEUR < 0 && usedPct < 100 {
+		body := fmt.Sprintf("Projected %.2f of %.2f EUR by %s (%s curve).",
+			f.ProjectedSpendEUR, available, p.EndsAt.Format(time.DateOnly), f.Curve)
+		if f.DaysUntilExhausted != nil {
+			body += fmt.Sprintf(" At %.2f EUR/day the budget runs out in %.1f days.", f.DailyBurnEUR, *f.DaysUntilExhausted)
+		}
+		alerts = append(alerts, Notification{
+			UserID:   p.UserID,
+			Kind:     NotificationBudgetForecast,
+			DedupKey: fmt.Sprintf("budget:%d:forecast", p.ID),
+			Title:    fmt.Sprintf("%s: on pace to overspend", p.Name),
+			Body:     body,
+		})
+	}
+	return alerts
+}
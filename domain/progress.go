@@ -29,7 +29,12 @@ type Activity struct {
 	Name          string       `json:"name" db:"name" jsonschema:"Activity name"`
 	Description   string       `json:"description,omitempty" db:"description" jsonschema:"Activity description"`
 	ProgressType  ProgressType `json:"progress_type" db:"progress_type" jsonschema:"Progress value scale type (mood|habit_progress|project_progress|promise_state)"`
+	ScaleID       *int64       `json:"scale_id,omitempty" db:"scale_id" jsonschema:"Custom progress scale ID (null = built-in scale of progress_type)"`
+	ParentID      *int64       `json:"parent_id,omitempty" db:"parent_id" jsonschema:"Parent activity ID (null = top level)"`
 	FrequencyDays int          `json:"frequency_days" db:"frequency_days" jsonschema:"Check-in frequency in days (1 = daily, 7 = weekly)"`
+	Unit          string       `json:"unit,omitempty" db:"unit" jsonschema:"Unit of the measured quantity (pages, ml, km)"`
+	Target        *float64     `json:"target,omitempty" db:"target_quantity" jsonschema:"Quantity to reach per target period (null = no target)"`
+	TargetDays    int          `json:"target_days,omitempty" db:"target_period_days" jsonschema:"Target period in days (0 = frequency_days)"`
 	StartedAt     time.Time    `json:"started_at" db:"started_at"`
 	EndedAt       *time.Time   `json:"ended_at,omitempty" db:"ended_at"` // NULL if active
 	LastPointAt   *time.Time   `json:"last_point_at,omitempty" db:"last_point_at"`
@@ -43,6 +48,7 @@ type ActivityPoint struct {
 	UserID     int64     `json:"user_id" db:"user_id"`
 	Value      int       `json:"value" db:"value" jsonschema:"Progress value from -2 to +2"`
 	HoursLeft  *float64  `json:"hours_left,omitempty" db:"hours_left" jsonschema:"Estimated hours remaining for projects (null if not tracking)"`
+	Quantity   *float64  `json:"quantity,omitempty" db:"quantity" jsonschema:"Measured amount in the activity unit (null if not measured)"`
 	Note       string    `json:"note,omitempty" db:"note" jsonschema:"Optional note about this progress point"`
 	ProgressAt time.Time `json:"progress_at" db:"progress_at" jsonschema:"When progress was made (defaults to now if empty)"`
 	CreatedAt  time.Time `json:"created_at" db:"created_at"`
@@ -83,16 +89,38 @@ type ProgressFilter struct {
 // ActivityPointWithActivity is ActivityPoint enriched with the parent activity name
 type ActivityPointWithActivity struct {
 	ActivityPoint
-	ActivityName string `json:"activity_name" db:"activity_name"`
+	ActivityName string   `json:"activity_name" db:"activity_name"`
+	MatchCount   int      `json:"match_count,omitempty" db:"match_count"`
+	Rank         float64  `json:"rank,omitempty" db:"rank"`
+	Similarity   *float64 `json:"similarity,omitempty" db:"similarity"` // best cosine similarity to a query, nil without embeddings
 }
 
-// ProgressNoteSearchFilter defines parameters for a single-variant note ILIKE search
+// ProgressNoteSearchFilter defines parameters for a note search over several
+// variants. A variant matches a note by full-text search (Russian and English
+// stemming), by substring, or, when Embeddings are set, by cosine similarity
+// of at least MinSimilarity. Results are ranked by the summed ts_rank plus
+// the best similarity, then newest first.
 type ProgressNoteSearchFilter struct {
-	UserID     int64     `json:"user_id"`
-	Query      string    `json:"query"`
-	ActivityID int64     `json:"activity_id,omitempty"` // 0 = all activities
-	From       time.Time `json:"from,omitempty"`
-	To         time.Time `json:"to,omitempty"`
-	ValueMin   *int      `json:"value_min,omitempty"`
-	ValueMax   *int      `json:"value_max,omitempty"`
+	UserID         int64       `json:"user_id"`
+	Queries        []string    `json:"queries"`
+	Embeddings     [][]float32 `json:"-"` // one per query, from EmbeddingModel; nil = full-text only
+	EmbeddingModel string      `json:"-"`
+	MinSimilarity  float64     `json:"-"`
+	ActivityID     int64       `json:"activity_id,omitempty"` // 0 = all activities
+	From           time.Time   `json:"from,omitempty"`
+	To             time.Time   `json:"to,omitempty"`
+	ValueMin       *int        `json:"value_min,omitempty"`
+	ValueMax       *int        `json:"value_max,omitempty"`
+	After          *Cursor     `json:"-"` // keyset: only rows ranked after the cursor
+	Limit          int         `json:"limit,omitempty"`
+}
+
+// NoteEmbedding is the vector of a progress note. Note is the text that was
+// embedded, so an edited note is embedded again.
+type NoteEmbedding struct {
+	PointID int64
+	UserID  int64
+	Model   string
+	Note    string
+	Vector  []float32
 }
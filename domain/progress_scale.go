@@ -0,0 +1,290 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Value range of the built-in scales.
+const (
+	DefaultScaleMin = -2
+	DefaultScaleMax = 2
+)
+
+// ScaleLevel names one value of a scale.
+type ScaleLevel struct {
+	Value int    `json:"value"`
+	Word  string `json:"word"`
+	Emoji string `json:"emoji,omitempty"`
+}
+
+// ScaleMetaphor is a set of words for the values of a scale, offered when a
+// user describes progress in images ("mood as weather").
+type ScaleMetaphor struct {
+	Name   string       `json:"name"`
+	Levels []ScaleLevel `json:"levels"`
+}
+
+// ProgressScale is the value range of an activity with its named levels and
+// metaphors. Built-in scales have no ID and are named after a ProgressType;
+// user scales are stored per user and referenced by Activity.ScaleID.
+type ProgressScale struct {
+	ID          int64           `json:"id" db:"id"`
+	UserID      int64           `json:"user_id" db:"user_id"`
+	Name        string          `json:"name" db:"name"`
+	Description string          `json:"description,omitempty" db:"description"`
+	MinValue    int             `json:"min_value" db:"min_value"`
+	MaxValue    int             `json:"max_value" db:"max_value"`
+	Levels      []ScaleLevel    `json:"levels" db:"levels"`       // dashboard emoji and stats labels
+	Metaphors   []ScaleMetaphor `json:"metaphors" db:"metaphors"` // natural language examples
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// Validate checks the range and that every level lies inside it, once.
+func (s ProgressScale) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if s.MinValue >= s.MaxValue {
+		return fmt.Errorf("min_value must be below max_value")
+	}
+	if s.MaxValue-s.MinValue > 100 {
+		return fmt.Errorf("range must span at most 100 values")
+	}
+	if err := checkLevels(s, "levels", s.Levels); err != nil {
+		return err
+	}
+	for _, m := range s.Metaphors {
+		if m.Name == "" {
+			return fmt.Errorf("metaphor name is required")
+		}
+		if err := checkLevels(s, "metaphor "+m.Name, m.Levels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkLevels(s ProgressScale, set string, levels []ScaleLevel) error {
+	seen := make(map[int]bool, len(levels))
+	for _, l := range levels {
+		if !s.Contains(l.Value) {
+			return fmt.Errorf("%s: value %d is outside %d..%d", set, l.Value, s.MinValue, s.MaxValue)
+		}
+		if seen[l.Value] {
+			return fmt.Errorf("%s: value %d is named twice", set, l.Value)
+		}
+		if l.Word == "" {
+			return fmt.Errorf("%s: value %d needs a word", set, l.Value)
+		}
+		seen[l.Value] = true
+	}
+	return nil
+}
+
+// Contains reports whether v is a valid value of the scale.
+func (s ProgressScale) Contains(v int) bool {
+	return v >= s.MinValue && v <= s.MaxValue
+}
+
+// Level returns the named level of v, nil when v has no name.
+func (s ProgressScale) Level(v int) *ScaleLevel {
+	for i := range s.Levels {
+		if s.Levels[i].Value == v {
+			return &s.Levels[i]
+		}
+	}
+	return nil
+}
+
+// Emoji returns the emoji of v, empty when there is none.
+func (s ProgressScale) Emoji(v int) string {
+	if l := s.Level(v); l != nil {
+		return l.Emoji
+	}
+	return ""
+}
+
+// Normalize maps v linearly onto the built-in -2..+2 range, so values of
+// different scales can be averaged together. Values outside the scale are
+// clamped to it first.
+func (s ProgressScale) Normalize(v int) float64 {
+	v = min(max(v, s.MinValue), s.MaxValue)
+	if s.MinValue == DefaultScaleMin && s.MaxValue == DefaultScaleMax {
+		return float64(v)
+	}
+	span := float64(s.MaxValue - s.MinValue)
+	return math.Round((DefaultScaleMin+float64(v-s.MinValue)/span*(DefaultScaleMax-DefaultScaleMin))*100) / 100
+}
+
+// ScaleOf resolves the scale of an activity: its user scale when set and
+// found in scales, otherwise the built-in scale of its progress type.
+func ScaleOf(a Activity, scales []ProgressScale) ProgressScale {
+	if a.ScaleID != nil {
+		for _, s := range scales {
+			if s.ID == *a.ScaleID {
+				return s
+			}
+		}
+	}
+	return BuiltinScale(a.ProgressType)
+}
+
+// BuiltinScale returns the default scale of a progress type.
+func BuiltinScale(t ProgressType) ProgressScale {
+	for _, s := range BuiltinScales() {
+		if s.Name == string(t) {
+			return s
+		}
+	}
+	return ProgressScale{Name: string(t), MinValue: DefaultScaleMin, MaxValue: DefaultScaleMax}
+}
+
+// BuiltinScales returns the scales of the four progress types. All use
+// -2..+2; promise_state only names -1..+1.
+func BuiltinScales() []ProgressScale {
+	return []ProgressScale{
+		{
+			Name:     string(ProgressTypeMood),
+			MinValue: DefaultScaleMin,
+			MaxValue: DefaultScaleMax,
+			Levels: []ScaleLevel{
+				{Value: 2, Word: "sunny", Emoji: "☀️"},
+				{Value: 1, Word: "partly cloudy", Emoji: "⛅"},
+				{Value: 0, Word: "overcast", Emoji: "☁️"},
+				{Value: -1, Word: "rainy", Emoji: "🌧️"},
+				{Value: -2, Word: "stormy", Emoji: "⛈️"},
+			},
+			Metaphors: []ScaleMetaphor{
+				{
+					Name: "mood as weather",
+					Levels: []ScaleLevel{
+						{Value: 2, Word: "sunny", Emoji: "☀️"},
+						{Value: 1, Word: "partly cloudy", Emoji: "⛅"},
+						{Value: 0, Word: "overcast", Emoji: "☁️"},
+						{Value: -1, Word: "rainy", Emoji: "🌧️"},
+						{Value: -2, Word: "stormy", Emoji: "⛈️"},
+					},
+				},
+				{
+					Name: "mood as light",
+					Levels: []ScaleLevel{
+						{Value: 2, Word: "bright", Emoji: "✨"},
+						{Value: 1, Word: "light", Emoji: "💡"},
+						{Value: 0, Word: "dim", Emoji: "🕯️"},
+						{Value: -1, Word: "dark", Emoji: "🌑"},
+						{Value: -2, Word: "pitch black", Emoji: "⚫"},
+					},
+				},
+				{
+					Name: "mood as colors",
+					Levels: []ScaleLevel{
+						{Value: 2, Word: "green", Emoji: "💚"},
+						{Value: 1, Word: "white", Emoji: "🤍"},
+						{Value: 0, Word: "gray", Emoji: "🩶"},
+						{Value: -1, Word: "black", Emoji: "🖤"},
+						{Value: -2, Word: "red", Emoji: "❤️‍🔥"},
+					},
+				},
+			},
+		},
+		{
+			Name:     string(ProgressTypeHabitProgress),
+			MinValue: DefaultScaleMin,
+			MaxValue: DefaultScaleMax,
+			Levels: []ScaleLevel{
+				{Value: 2, Word: "crushing it", Emoji: "💪"},
+				{Value: 1, Word: "mostly doing", Emoji: "👍"},
+				{Value: 0, Word: "trying", Emoji: "🤔"},
+				{Value: -1, Word: "rarely", Emoji: "😔"},
+				{Value: -2, Word: "not doing", Emoji: "❌"},
+			},
+			Metaphors: []ScaleMetaphor{
+				{
+					Name: "habit consistency",
+					Levels: []ScaleLevel{
+						{Value: 2, Word: "crushing it", Emoji: "💪"},
+						{Value: 1, Word: "mostly doing", Emoji: "👍"},
+						{Value: 0, Word: "trying", Emoji: "🤔"},
+						{Value: -1, Word: "rarely", Emoji: "😔"},
+						{Value: -2, Word: "not doing", Emoji: "❌"},
+					},
+				},
+				{
+					Name: "habit as garden",
+					Levels: []ScaleLevel{
+						{Value: 2, Word: "blooming", Emoji: "🌸"},
+						{Value: 1, Word: "growing", Emoji: "🌱"},
+						{Value: 0, Word: "planted", Emoji: "🌰"},
+						{Value: -1, Word: "wilting", Emoji: "🥀"},
+						{Value: -2, Word: "withered", Emoji: "🍂"},
+					},
+				},
+			},
+		},
+		{
+			Name:     string(ProgressTypeProjectProgress),
+			MinValue: DefaultScaleMin,
+			MaxValue: DefaultScaleMax,
+			Levels: []ScaleLevel{
+				{Value: 2, Word: "breakthrough", Emoji: "🚀"},
+				{Value: 1, Word: "moving forward", Emoji: "⮕"},
+				{Value: 0, Word: "stuck", Emoji: "⌛"},
+				{Value: -1, Word: "setback", Emoji: "⬅"},
+				{Value: -2, Word: "changed plans", Emoji: "🔄"},
+			},
+			Metaphors: []ScaleMetaphor{
+				{
+					Name: "project momentum",
+					Levels: []ScaleLevel{
+						{Value: 2, Word: "breakthrough", Emoji: "🚀"},
+						{Value: 1, Word: "moving forward", Emoji: "➡️"},
+						{Value: 0, Word: "stuck", Emoji: "⏸️"},
+						{Value: -1, Word: "setback", Emoji: "↩️"},
+						{Value: -2, Word: "changed plans", Emoji: "🔄"},
+					},
+				},
+				{
+					Name: "project as journey",
+					Levels: []ScaleLevel{
+						{Value: 2, Word: "sprinting", Emoji: "🏃"},
+						{Value: 1, Word: "walking", Emoji: "🚶"},
+						{Value: 0, Word: "resting", Emoji: "🧘"},
+						{Value: -1, Word: "backtracking", Emoji: "🔙"},
+						{Value: -2, Word: "lost", Emoji: "🗺️"},
+					},
+				},
+			},
+		},
+		{
+			Name:     string(ProgressTypePromiseState),
+			MinValue: DefaultScaleMin,
+			MaxValue: DefaultScaleMax,
+			Levels: []ScaleLevel{
+				{Value: 1, Word: "did something", Emoji: "✅"},
+				{Value: 0, Word: "remember", Emoji: "💭"},
+				{Value: -1, Word: "forgot", Emoji: "🤷"},
+			},
+			Metaphors: []ScaleMetaphor{
+				{
+					Name: "promise awareness",
+					Levels: []ScaleLevel{
+						{Value: 1, Word: "did something", Emoji: "✅"},
+						{Value: 0, Word: "remember", Emoji: "💭"},
+						{Value: -1, Word: "forgot", Emoji: "🤷"},
+					},
+				},
+				{
+					Name: "promise as flame",
+					Levels: []ScaleLevel{
+						{Value: 1, Word: "burning", Emoji: "🔥"},
+						{Value: 0, Word: "lit", Emoji: "🕯️"},
+						{Value: -1, Word: "extinguished", Emoji: "💨"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// TargetPeriodDays is the length of the target period of an activity: its
+// own TargetDays, or the check-in frequency when unset.
+func (a Activity) TargetPeriodDays() int {
+	if a.TargetDays > 0 {
+		return a.TargetDays
+	}
+	if a.FrequencyDays > 0 {
+		return a.FrequencyDays
+	}
+	return 1
+}
+
+// Measured reports whether the activity tracks a quantity.
+func (a Activity) Measured() bool {
+	return a.Unit != "" || a.Target != nil
+}
+
+// QuantityPeriod is the summed quantity of one target period [From, To).
+type QuantityPeriod struct {
+	From          time.Time
+	To            time.Time
+	Total         float64
+	AttainmentPct *float64 // Total / target; nil without a target
+	Met           bool     // reached the target, or logged anything when there is none
+}
+
+// QuantityStats summarizes the measured quantities of an activity.
+type QuantityStats struct {
+	Unit       string
+	Target     *float64
+	PeriodDays int
+	Total      float64 // all points with a quantity
+	Count      int     // points with a quantity
+
+	Current          QuantityPeriod
+	PeriodsCompleted int
+	PeriodsMet       int      // of the completed periods
+	AvgAttainmentPct *float64 // mean of completed periods, each capped at 100; nil without target or periods
+	CurrentStreak    int      // met periods up to now; the current one counts once met
+	LongestStreak    int
+}
+
+// QuantityReport splits the life of an activity into target periods starting
+// at the day it started (UTC) and sums the point quantities of each. A
+// finished activity is evaluated up to its end.
+func QuantityReport(a Activity, points []ActivityPoint, now time.Time) QuantityStats {
+	stats := QuantityStats{Unit: a.Unit, Target: a.Target, PeriodDays: a.TargetPeriodDays()}
+	if a.EndedAt != nil && a.EndedAt.Before(now) {
+		now = *a.EndedAt
+	}
+
+	p := periodsOf(a.StartedAt, stats.PeriodDays, now)
+	totals := make([]float64, p.current+1)
+	for _, pt := range points {
+		if pt.Quantity == nil {
+			continue
+		}
+		stats.Total += *pt.Quantity
+		stats.Count++
+		if pt.ProgressAt.Before(p.start) || pt.ProgressAt.After(now) {
+			continue
+		}
+		totals[p.index(pt.ProgressAt)] += *pt.Quantity
+	}
+	stats.Total = roundQuantity(stats.Total)
+
+	met := make([]bool, len(totals))
+	var attainment float64
+	for i, total := range totals {
+		period := quantityPeriod(p.from(i), p.from(i+1), roundQuantity(total), a.Target)
+		met[i] = period.Met
+		if i == p.current {
+			stats.Current = period
+			break
+		}
+		stats.PeriodsCompleted++
+		if period.Met {
+			stats.PeriodsMet++
+		}
+		if period.AttainmentPct != nil {
+			attainment += math.Min(*period.AttainmentPct, 100)
+		}
+	}
+	stats.CurrentStreak, stats.LongestStreak = streaks(met)
+
+	if a.Target != nil && stats.PeriodsCompleted > 0 {
+		avg := math.Round(attainment/float64(stats.PeriodsCompleted)*10) / 10
+		stats.AvgAttainmentPct = &avg
+	}
+	return stats
+}
+
+// QuantityTotal sums the quantities of the points in [from, to].
+func QuantityTotal(points []ActivityPoint, from, to time.Time) float64 {
+	var total float64
+	for _, p := range points {
+		if p.Quantity != nil && !p.ProgressAt.Before(from) && !p.ProgressAt.After(to) {
+			total += *p.Quantity
+		}
+	}
+	return roundQuantity(total)
+}
+
+func quantityPeriod(from, to time.Time, total float64, target *float64) QuantityPeriod {
+	p := QuantityPeriod{From: from, To: to, Total: total, Met: total > 0}
+	if target != nil && *target > 0 {
+		pct := math.Round(total / *target * 1000) / 10
+		p.AttainmentPct = &pct
+		p.Met = total >= *target
+	}
+	return p
+}
+
+func roundQuantity(v float64) float64 {
+	return math.Round(v*100) / 100
+}
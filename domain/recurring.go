@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RecurringCadence is the billing period of a recurring charge.
+type RecurringCadence string
+
+const (
+	CadenceWeekly  RecurringCadence = "weekly"
+	CadenceMonthly RecurringCadence = "monthly"
+	CadenceAnnual  RecurringCadence = "annual"
+)
+
+// RecurringStatus is computed from the predicted next charge and the current time.
+type RecurringStatus string
+
+const (
+	RecurringActive    RecurringStatus = "active"
+	RecurringMissed    RecurringStatus = "missed"    // expected charge is overdue
+	RecurringCancelled RecurringStatus = "cancelled" // two expected charges never came
+)
+
+// cadenceSpec describes how a cadence is recognized and projected.
+type cadenceSpec struct {
+	cadence        RecurringCadence
+	minDays        float64 // accepted interval range between charges
+	maxDays        float64
+	minOccurrences int
+	grace          time.Duration // how late a charge may be before it counts as missed
+}
+
+var cadenceSpecs = []cadenceSpec{
+	{CadenceWeekly, 6, 8, 3, 3 * 24 * time.Hour},
+	{CadenceMonthly, 27, 33, 3, 7 * 24 * time.Hour},
+	{CadenceAnnual, 355, 375, 2, 30 * 24 * time.Hour},
+}
+
+// Next returns the charge date one period after t.
+func (c RecurringCadence) Next(t time.Time) time.Time {
+	switch c {
+	case CadenceWeekly:
+		return t.AddDate(0, 0, 7)
+	case CadenceAnnual:
+		return t.AddDate(1, 0, 0)
+	default:
+		return t.AddDate(0, 1, 0)
+	}
+}
+
+func (c RecurringCadence) grace() time.Duration {
+	for _, spec := range cadenceSpecs {
+		if spec.cadence == c {
+			return spec.grace
+		}
+	}
+	return 7 * 24 * time.Hour
+}
+
+// RecurringItem is a detected subscription or fixed cost.
+type RecurringItem struct {
+	ID             int64            `db:"id"`
+	UserID         int64            `db:"user_id"`
+	Merchant       string           `db:"merchant"`
+	Account        string           `db:"account"`
+	Category       string           `db:"category"`
+	Cadence        RecurringCadence `db:"cadence"`
+	Currency       string           `db:"currency"`
+	TypicalAmount  float64          `db:"typical_amount"`  // median of all charges
+	LastAmount     float64          `db:"last_amount"`     // latest charge
+	PreviousAmount float64          `db:"previous_amount"` // median of charges before the latest
+	Occurrences    int              `db:"occurrences"`
+	FirstChargedAt time.Time        `db:"first_charged_at"`
+	LastChargedAt  time.Time        `db:"last_charged_at"`
+	NextChargeAt   time.Time        `db:"next_charge_at"`
+	DetectedAt     time.Time        `db:"detected_at"`
+}
+
+// PriceIncreased reports whether the latest charge is over 1% above the
+// earlier ones.
+func (r RecurringItem) PriceIncreased() bool {
+	return r.LastAmount > r.PreviousAmount*1.01 && r.LastAmount-r.PreviousAmount >= 0.01
+}
+
+// Status tells whether the next charge is still expected at now.
+func (r RecurringItem) Status(now time.Time) RecurringStatus {
+	grace := r.Cadence.grace()
+	switch {
+	case now.After(r.Cadence.Next(r.NextChargeAt).Add(grace)):
+		return RecurringCancelled
+	case now.After(r.NextChargeAt.Add(grace)):
+		return RecurringMissed
+	default:
+		return RecurringActive
+	}
+}
+
+// RecurringDetectOptions tunes DetectRecurring.
+type RecurringDetectOptions struct {
+	AmountTolPct float64 // charges within this % of the median amount belong to the series
+}
+
+// DetectRecurring finds periodic expense series per merchant and currency.
+// A series is recognized when at least 75% of the intervals between charges
+// fall into one cadence range and at least 60% of the amounts are within
+// AmountTolPct of the median, so a single price change does not break it.
+// Paired transfers and non-expenses are ignored.
+func DetectRecurring(txs []Transaction, opts RecurringDetectOptions) []RecurringItem {
+	type seriesKey struct{ merchant, currency string }
+
+	groups := make(map[seriesKey][]Transaction)
+	var keys []seriesKey
+	for _, tx := range txs {
+		if tx.Type != TransactionTypeExpense || tx.TransferPeerID != nil || strings.TrimSpace(tx.Merchant) == "" {
+			continue
+		}
+		k := seriesKey{strings.ToLower(strings.TrimSpace(tx.Merchant)), strings.ToUpper(tx.Currency)}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], tx)
+	}
+
+	var items []RecurringItem
+	for _, k := range keys {
+		series := groups[k]
+		sort.Slice(series, func(i, j int) bool { return series[i].TransactedAt.Before(series[j].TransactedAt) })
+		if item, ok := detectSeries(series, opts); ok {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].NextChargeAt.Before(items[j].NextChargeAt) })
+	return items
+}
+
+func detectSeries(series []Transaction, opts RecurringDetectOptions) (RecurringItem, bool) {
+	if len(series) < 2 {
+		return RecurringItem{}, false
+	}
+
+	intervals := make([]float64, len(series)-1)
+	for i := 1; i < len(series); i++ {
+		intervals[i-1] = series[i].TransactedAt.Sub(series[i-1].TransactedAt).Hours() / 24
+	}
+
+	var spec *cadenceSpec
+	for i := range cadenceSpecs {
+		s := &cadenceSpecs[i]
+		if len(series) < s.minOccurrences {
+			continue
+		}
+		inRange := 0
+		for _, d := range intervals {
+			if d >= s.minDays && d <= s.maxDays {
+				inRange++
+			}
+		}
+		if float64(inRange) >= 0.75*float64(len(intervals)) {
+			spec = s
+			break
+		}
+	}
+	if spec == nil {
+		return RecurringItem{}, false
+	}
+
+	amounts := make([]float64, len(series))
+	for i, tx := range series {
+		amounts[i] = tx.AmountOriginal
+	}
+	typical := median(amounts)
+	similar := 0
+	for _, a := range amounts {
+		if math.Abs(a-typical) <= typical*opts.AmountTolPct/100 {
+			similar++
+		}
+	}
+	if float64(similar) < 0.6*float64(len(amounts)) {
+		return RecurringItem{}, false
+	}
+
+	first, last := series[0], series[len(series)-1]
+	return RecurringItem{
+		UserID:         last.UserID,
+		Merchant:       last.Merchant,
+		Account:        last.Account,
+		Category:       last.Category,
+		Cadence:        spec.cadence,
+		Currency:       strings.ToUpper(last.Currency),
+		TypicalAmount:  roundCents(typical),
+		LastAmount:     last.AmountOriginal,
+		PreviousAmount: roundCents(median(amounts[:len(amounts)-1])),
+		Occurrences:    len(series),
+		FirstChargedAt: first.TransactedAt,
+		LastChargedAt:  last.TransactedAt,
+		NextChargeAt:   spec.cadence.Next(last.TransactedAt),
+	}, true
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NotificationCheckInDue is the kind of the daily check-in reminder.
+const NotificationCheckInDue = "checkin_due"
+
+// Reminder channels. Reminders always land in the notification inbox; the
+// webhook and email channels deliver a copy as well.
+const (
+	ReminderInbox   = "inbox"
+	ReminderWebhook = "webhook"
+	ReminderEmail   = "email"
+)
+
+// ReminderSettings configures the daily check-in reminder of one user.
+type ReminderSettings struct {
+	UserID    int64     `db:"user_id"`
+	Enabled   bool      `db:"enabled"`
+	RemindAt  string    `db:"remind_at"` // HH:MM in Timezone
+	Timezone  string    `db:"timezone"`  // IANA name
+	Channel   string    `db:"channel"`
+	Target    string    `db:"target"` // webhook URL or email address; empty for inbox
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// DefaultReminderSettings applies until the user saves their own. Reminders
+// are opt-in.
+func DefaultReminderSettings(userID int64) ReminderSettings {
+	return ReminderSettings{UserID: userID, RemindAt: "09:00", Timezone: "UTC", Channel: ReminderInbox}
+}
+
+// Validate checks the time, timezone and that the channel has a target.
+func (s ReminderSettings) Validate() error {
+	if _, err := time.Parse("15:04", s.RemindAt); err != nil {
+		return fmt.Errorf("remind_at must be HH:MM")
+	}
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", s.Timezone)
+	}
+	switch s.Channel {
+	case ReminderInbox:
+	case ReminderWebhook:
+		if !strings.HasPrefix(s.Target, "http://") && !strings.HasPrefix(s.Target, "https://") {
+			return fmt.Errorf("webhook channel needs an http(s) URL as target")
+		}
+	case ReminderEmail:
+		if !strings.Contains(s.Target, "@") {
+			return fmt.Errorf("email channel needs an email address as target")
+		}
+	default:
+		return fmt.Errorf("channel must be one of inbox, webhook, email")
+	}
+	return nil
+}
+
+// Location returns the user's timezone, UTC when it does not load.
+func (s ReminderSettings) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DueActivities returns the active activities whose next check-in day has
+// come in loc: last check-in day + frequency_days, or right away for
+// activities without points. Activities starting later are skipped.
+func DueActivities(activities []Activity, now time.Time, loc *time.Location) []Activity {
+	today := localDay(now, loc)
+	var due []Activity
+	for _, a := range activities {
+		if a.EndedAt != nil || a.StartedAt.After(now) {
+			continue
+		}
+		if a.LastPointAt == nil || !localDay(*a.LastPointAt, loc).AddDate(0, 0, a.FrequencyDays).After(today) {
+			due = append(due, a)
+		}
+	}
+	return due
+}
+
+// CheckInReminder returns the reminder for the activities due on the local
+// day of now, or nil when it is not time yet or nothing is due. The dedup key
+// holds the local date, so a user gets at most one reminder per day.
+func CheckInReminder(s ReminderSettings, due []Activity, now time.Time) *Notification {
+	if !s.Enabled || len(due) == 0 {
+		return nil
+	}
+	local := now.In(s.Location())
+	if local.Format("15:04") < s.RemindAt {
+		return nil
+	}
+
+	names := make([]string, len(due))
+	for i, a := range due {
+		names[i] = a.Name
+	}
+	title := fmt.Sprintf("%d activities due for check-in", len(due))
+	if len(due) == 1 {
+		title = fmt.Sprintf("%s is due for check-in", due[0].Name)
+	}
+	return &Notification{
+		UserID:   s.UserID,
+		Kind:     NotificationCheckInDue,
+		DedupKey: "checkin:" + local.Format(time.DateOnly),
+		Title:    title,
+		Body:     "Time to reflect: " + strings.Join(names, ", ") + ".",
+	}
+}
+
+func localDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
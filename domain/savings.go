@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Savings goal statuses.
+const (
+	SavingsReached    = "reached"
+	SavingsOnTrack    = "on_track"
+	SavingsBehind     = "behind"
+	SavingsOverdue    = "overdue"
+	SavingsNoDeadline = "no_deadline"
+)
+
+// SavingsPaceWindow is how far back the recent saving pace is measured.
+const SavingsPaceWindow = 90 * 24 * time.Hour
+
+// daysPerMonth is the average calendar month length.
+const daysPerMonth = 365.25 / 12
+
+// SavingsGoal is an amount to put aside by an optional deadline. It is funded
+// either by the balance of one registered account or by the transactions
+// carrying one tag; exactly one of FundingAccount and FundingTag is set.
+type SavingsGoal struct {
+	ID             int64      `db:"id"`
+	UserID         int64      `db:"user_id"`
+	Name           string     `db:"name"`
+	TargetAmount   float64    `db:"target_amount"`
+	Currency       string     `db:"currency"` // account currency, EUR for tag funding
+	Deadline       *time.Time `db:"deadline"`
+	FundingAccount *string    `db:"funding_account"`
+	FundingTag     *string    `db:"funding_tag"`
+	StartingAmount float64    `db:"starting_amount"` // saved before tagging started; tag funding only
+	ActivityID     *int64     `db:"activity_id"`     // progress activity the goal is reflected in
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// SavingsProgress is where a goal stands at a moment.
+type SavingsProgress struct {
+	Saved               float64
+	Remaining           float64 // zero once reached
+	ProgressPct         float64
+	MonthlyPace         float64  // saved per month over the pace window
+	MonthsLeft          *float64 // nil without a deadline
+	RequiredMonthly     *float64 // to reach the target by the deadline
+	ProjectedAtDeadline *float64 // saved plus the current pace until the deadline
+	Status              string
+}
+
+// EvaluateSavingsGoal compares saved, the amount saved at at, with the
+// target. savedWindowStart is the amount saved SavingsPaceWindow earlier and
+// sets the pace the projection assumes. Less than a month before the deadline
+// the whole remainder is required in the current month.
+func EvaluateSavingsGoal(g SavingsGoal, saved, savedWindowStart float64, at time.Time) SavingsProgress {
+	p := SavingsProgress{
+		Saved:       roundCents(saved),
+		Remaining:   roundCents(math.Max(g.TargetAmount-saved, 0)),
+		MonthlyPace: roundCents((saved - savedWindowStart) / (SavingsPaceWindow.Hours() / 24 / daysPerMonth)),
+	}
+	if g.TargetAmount > 0 {
+		p.ProgressPct = roundCents(saved / g.TargetAmount * 100)
+	}
+
+	if g.Deadline != nil {
+		months := math.Max(g.Deadline.Sub(at).Hours()/24/daysPerMonth, 0)
+		months = math.Round(months*10) / 10
+		p.MonthsLeft = &months
+	}
+
+	switch {
+	case p.Remaining == 0:
+		p.Status = SavingsReached
+	case g.Deadline == nil:
+		p.Status = SavingsNoDeadline
+	case !g.Deadline.After(at):
+		p.Status = SavingsOverdue
+		required := p.Remaining
+		p.RequiredMonthly = &required
+	default:
+		required := roundCents(p.Remaining / math.Max(*p.MonthsLeft, 1))
+		projected := roundCents(saved + math.Max(p.MonthlyPace, 0)**p.MonthsLeft)
+		p.RequiredMonthly = &required
+		p.ProjectedAtDeadline = &projected
+		p.Status = SavingsBehind
+		if projected >= g.TargetAmount {
+			p.Status = SavingsOnTrack
+		}
+	}
+	return p
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Trend directions of the week-over-week comparison.
+const (
+	TrendUp   = "up"
+	TrendDown = "down"
+	TrendFlat = "flat"
+)
+
+// Points whose normalized value reaches +1 are highs, -1 or below lows.
+const notableNormalizedValue = 1.0
+
+// WeekOf returns the ISO week [from, to) containing t, Monday to Monday in
+// the location of t.
+func WeekOf(t time.Time) (from, to time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	from = day.AddDate(0, 0, -offset)
+	return from, from.AddDate(0, 0, 7)
+}
+
+// ActivityWeek is one activity in a weekly review. Averages are in the
+// activity's own scale; Delta compares them with the previous week.
+type ActivityWeek struct {
+	Activity        Activity
+	Points          []ActivityPoint // of the week, oldest first
+	Average         *float64
+	PreviousCount   int
+	PreviousAverage *float64
+	Delta           *float64
+	Trend           string // up, down or flat; empty when a week has no points
+	QuantityTotal   *float64
+}
+
+// NotablePoint is a progress point that stood out in the week.
+type NotablePoint struct {
+	Point      ActivityPoint
+	Activity   Activity
+	Normalized float64
+}
+
+// WeeklyReview is the summary of one week of progress.
+type WeeklyReview struct {
+	Activities      []ActivityWeek
+	Highs           []NotablePoint // best first
+	Lows            []NotablePoint // worst first
+	Finished        []Activity
+	Average         *float64 // all points normalized to -2..+2
+	PreviousAverage *float64
+	Delta           *float64
+	Trend           string
+}
+
+// WeeklyReviewReport summarizes the points of [from, to) per activity and
+// compares them with the week before. points must cover both weeks.
+// Activities that started after the week or ended before it are skipped;
+// those finished inside it are also listed in Finished.
+func WeeklyReviewReport(activities []Activity, scales []ProgressScale, points []ActivityPoint, from, to time.Time) WeeklyReview {
+	prevFrom := from.AddDate(0, 0, -7)
+
+	sorted := make([]ActivityPoint, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ProgressAt.Before(sorted[j].ProgressAt) })
+
+	review := WeeklyReview{Activities: []ActivityWeek{}, Highs: []NotablePoint{}, Lows: []NotablePoint{}, Finished: []Activity{}}
+	var sum, prevSum float64
+	var count, prevCount int
+	for _, a := range activities {
+		if !a.StartedAt.Before(to) || (a.EndedAt != nil && a.EndedAt.Before(from)) {
+			continue
+		}
+		if a.EndedAt != nil && a.EndedAt.Before(to) {
+			review.Finished = append(review.Finished, a)
+		}
+		scale := ScaleOf(a, scales)
+
+		w := ActivityWeek{Activity: a, Points: []ActivityPoint{}}
+		var weekSum, weekPrevSum float64
+		for _, p := range sorted {
+			if p.ActivityID != a.ID {
+				continue
+			}
+			switch {
+			case !p.ProgressAt.Before(from) && p.ProgressAt.Before(to):
+				w.Points = append(w.Points, p)
+				weekSum += float64(p.Value)
+				normalized := scale.Normalize(p.Value)
+				sum += normalized
+				count++
+				if normalized >= notableNormalizedValue {
+					review.Highs = append(review.Highs, NotablePoint{Point: p, Activity: a, Normalized: normalized})
+				} else if normalized <= -notableNormalizedValue {
+					review.Lows = append(review.Lows, NotablePoint{Point: p, Activity: a, Normalized: normalized})
+				}
+			case !p.ProgressAt.Before(prevFrom) && p.ProgressAt.Before(from):
+				w.PreviousCount++
+				weekPrevSum += float64(p.Value)
+				prevSum += scale.Normalize(p.Value)
+				prevCount++
+			}
+		}
+		w.Average = averageOf(weekSum, len(w.Points))
+		w.PreviousAverage = averageOf(weekPrevSum, w.PreviousCount)
+		w.Delta, w.Trend = weekTrend(w.Average, w.PreviousAverage)
+		if a.Measured() {
+			total := QuantityTotal(w.Points, from, to)
+			w.QuantityTotal = &total
+		}
+		review.Activities = append(review.Activities, w)
+	}
+
+	sort.SliceStable(review.Highs, func(i, j int) bool { return review.Highs[i].Normalized > review.Highs[j].Normalized })
+	sort.SliceStable(review.Lows, func(i, j int) bool { return review.Lows[i].Normalized < review.Lows[j].Normalized })
+
+	review.Average = averageOf(sum, count)
+	review.PreviousAverage = averageOf(prevSum, prevCount)
+	review.Delta, review.Trend = weekTrend(review.Average, review.PreviousAverage)
+	return review
+}
+
+func averageOf(sum float64, n int) *float64 {
+	if n == 0 {
+		return nil
+	}
+	avg := math.Round(sum/float64(n)*100) / 100
+	return &avg
+}
+
+// weekTrend returns current - previous and its direction, nil and empty when
+// either side is missing.
+func weekTrend(current, previous *float64) (*float64, string) {
+	if current == nil || previous == nil {
+		return nil, ""
+	}
+	delta := math.Round((*current-*previous)*100) / 100
+	switch {
+	case delta > 0:
+		return &delta, TrendUp
+	case delta < 0:
+		return &delta, TrendDown
+	default:
+		return &delta, TrendFlat
+	}
+}
@@ -8,8 +8,9 @@ import (
 type contextKey string
 
 const (
-	dbContextKey     contextKey = "database"
-	userIDContextKey contextKey = "user_id"
+	dbContextKey       contextKey = "database"
+	userIDContextKey   contextKey = "user_id"
+	embedderContextKey contextKey = "embedder"
 )
 
 // WithDB adds a database interface to the context
@@ -22,6 +23,11 @@ func WithUserID(ctx context.Context, id int64) context.Context {
 	return context.WithValue(ctx, userIDContextKey, id)
 }
 
+// WithEmbedder adds an embedding provider to the context
+func WithEmbedder(ctx context.Context, e Embedder) context.Context {
+	return context.WithValue(ctx, embedderContextKey, e)
+}
+
 // DBFromContext extracts the database interface from the context
 // Returns nil if no database is found in the context
 func DBFromContext(ctx context.Context) DB {
@@ -42,3 +48,13 @@ func UserIDFromContext(ctx context.Context) int64 {
 
 	return userID
 }
+
+// EmbedderFromContext extracts the embedding provider from the context
+// Returns nil if semantic search is not configured
+func EmbedderFromContext(ctx context.Context) Embedder {
+	e, ok := ctx.Value(embedderContextKey).(Embedder)
+	if !ok {
+		return nil
+	}
+	return e
+}
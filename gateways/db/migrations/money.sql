@@ -37,3 +37,233 @@ CREATE TABLE IF NOT EXISTS budgets (
 );
 
 CREATE INDEX IF NOT EXISTS idx_budgets_user_period ON budgets(user_id, starts_at, ends_at);
+
+-- Manual re-categorizations, used as training signals for import categorization.
+-- transaction_id is informational only: the signal survives transaction deletion.
+CREATE TABLE IF NOT EXISTS category_corrections (
+    id                   BIGSERIAL PRIMARY KEY,
+    user_id              BIGINT NOT NULL,
+    transaction_id       BIGINT,
+    merchant             VARCHAR(255) NOT NULL DEFAULT '',
+    original_description TEXT NOT NULL DEFAULT '',
+    category             VARCHAR(255) NOT NULL,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_corrections_user ON category_corrections(user_id, created_at);
+
+-- Saved column maps for the generic CSV parser, one per bank format.
+CREATE TABLE IF NOT EXISTS import_profiles (
+    id                 BIGSERIAL PRIMARY KEY,
+    user_id            BIGINT NOT NULL,
+    name               VARCHAR(100) NOT NULL,
+    aliases            TEXT[] NOT NULL DEFAULT '{}',
+    delimiter          CHAR(1) NOT NULL DEFAULT ',',
+    date_column        VARCHAR(100) NOT NULL,
+    date_format        VARCHAR(50) NOT NULL DEFAULT '',
+    description_column VARCHAR(100) NOT NULL,
+    amount_column      VARCHAR(100) NOT NULL DEFAULT '',
+    debit_column       VARCHAR(100) NOT NULL DEFAULT '',
+    credit_column      VARCHAR(100) NOT NULL DEFAULT '',
+    currency_column    VARCHAR(100) NOT NULL DEFAULT '',
+    default_currency   CHAR(3) NOT NULL DEFAULT 'EUR',
+    decimal_style      VARCHAR(10) NOT NULL DEFAULT 'dot',
+    sign_convention    VARCHAR(30) NOT NULL DEFAULT 'negative_is_expense',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT check_import_profile_decimal CHECK (decimal_style IN ('dot', 'comma')),
+    CONSTRAINT check_import_profile_sign CHECK (sign_convention IN ('negative_is_expense', 'positive_is_expense')),
+    CONSTRAINT uq_import_profiles_user_name UNIQUE (user_id, name)
+);
+
+-- Bank transaction IDs from OFX/CAMT/QIF statements, used to skip re-imports.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_transactions_external_id
+    ON transactions(user_id, account, external_id)
+    WHERE external_id IS NOT NULL;
+
+-- Money in/out of the account; transfers carry no sign in their type.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS direction VARCHAR(3);
+UPDATE transactions
+SET direction = CASE WHEN type = 'income' THEN 'in' ELSE 'out' END
+WHERE direction IS NULL;
+ALTER TABLE transactions ALTER COLUMN direction SET NOT NULL;
+
+-- Running balance reported by the bank export (Revolut/BOC "Balance" column).
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS balance_after DECIMAL(14,2);
+
+-- Value date from OFX/CAMT statements; transacted_at holds the booking date.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS value_date DATE;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_user_account
+    ON transactions(user_id, LOWER(account), transacted_at);
+
+-- Registered accounts; transactions.account matches name case-insensitively.
+CREATE TABLE IF NOT EXISTS accounts (
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT NOT NULL,
+    name            VARCHAR(100) NOT NULL,
+    currency        CHAR(3) NOT NULL DEFAULT 'EUR',
+    type            VARCHAR(20) NOT NULL DEFAULT 'checking',
+    opening_balance DECIMAL(14,2) NOT NULL DEFAULT 0,
+    opened_at       TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT check_account_type CHECK (type IN ('checking', 'savings', 'credit', 'cash')),
+    CONSTRAINT check_account_currency_length CHECK (char_length(currency) = 3)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_accounts_user_name ON accounts(user_id, LOWER(name));
+
+-- Other side of a transfer between own accounts; both rows point at each other.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS transfer_peer_id BIGINT
+    REFERENCES transactions(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer_peer
+    ON transactions(transfer_peer_id) WHERE transfer_peer_id IS NOT NULL;
+
+-- Detected subscriptions and fixed costs; rebuilt by the recurring detector.
+CREATE TABLE IF NOT EXISTS recurring_items (
+    id               BIGSERIAL PRIMARY KEY,
+    user_id          BIGINT NOT NULL,
+    merchant         VARCHAR(255) NOT NULL,
+    account          VARCHAR(100) NOT NULL DEFAULT '',
+    category         VARCHAR(255) NOT NULL DEFAULT '',
+    cadence          VARCHAR(10) NOT NULL,
+    currency         CHAR(3) NOT NULL,
+    typical_amount   DECIMAL(12,2) NOT NULL,
+    last_amount      DECIMAL(12,2) NOT NULL,
+    previous_amount  DECIMAL(12,2) NOT NULL,
+    occurrences      INT NOT NULL,
+    first_charged_at TIMESTAMPTZ NOT NULL,
+    last_charged_at  TIMESTAMPTZ NOT NULL,
+    next_charge_at   TIMESTAMPTZ NOT NULL,
+    detected_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT check_recurring_cadence CHECK (cadence IN ('weekly', 'monthly', 'annual'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_items_user ON recurring_items(user_id, next_charge_at);
+
+-- Recurring budget definitions. Periods are materialized into budgets on read,
+-- one row per period, linked back through recurring_budget_id.
+CREATE TABLE IF NOT EXISTS recurring_budgets (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT NOT NULL,
+    name        VARCHAR(255) NOT NULL,
+    category    VARCHAR(255) NOT NULL,
+    amount_eur  DECIMAL(12,2) NOT NULL,
+    period      VARCHAR(10) NOT NULL,
+    rollover    BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_at   TIMESTAMPTZ NOT NULL,
+    ends_at     TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT check_recurring_budget_amount CHECK (amount_eur > 0),
+    CONSTRAINT check_recurring_budget_period CHECK (period IN ('weekly', 'monthly', 'yearly')),
+    CONSTRAINT uq_recurring_budgets_user_name UNIQUE (user_id, name)
+);
+
+ALTER TABLE budgets ADD COLUMN IF NOT EXISTS recurring_budget_id BIGINT
+    REFERENCES recurring_budgets(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_budgets_recurring_period
+    ON budgets(recurring_budget_id, starts_at) WHERE recurring_budget_id IS NOT NULL;
+
+-- Budget alert configuration, one row per user; defaults apply when missing.
+CREATE TABLE IF NOT EXISTS budget_alert_settings (
+    user_id         BIGINT PRIMARY KEY,
+    thresholds      INT[] NOT NULL DEFAULT '{80,100}',
+    forecast_alerts BOOLEAN NOT NULL DEFAULT TRUE,
+    webhook_url     TEXT,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Notification inbox polled by the MCP client; dedup_key makes alerts fire once.
+CREATE TABLE IF NOT EXISTS notifications (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT NOT NULL,
+    kind           VARCHAR(50) NOT NULL,
+    dedup_key      VARCHAR(255) NOT NULL,
+    title          TEXT NOT NULL,
+    body           TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    read_at        TIMESTAMPTZ,
+    delivered_at   TIMESTAMPTZ,
+    delivery_error TEXT,
+
+    CONSTRAINT uq_notifications_user_key UNIQUE (user_id, dedup_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC);
+
+-- Line items of a transaction split across categories. Amounts are in the
+-- parent's currency and sum to its amount_original; aggregations use the lines
+-- instead of the parent when present.
+CREATE TABLE IF NOT EXISTS transaction_splits (
+    id             BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    user_id        BIGINT NOT NULL,
+    position       INT NOT NULL DEFAULT 0,
+    category       VARCHAR(255) NOT NULL,
+    amount         DECIMAL(12,2) NOT NULL,
+    note           TEXT,
+
+    CONSTRAINT check_split_amount CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_splits_tx ON transaction_splits(transaction_id, position);
+
+-- Free-form tags, many-to-many with transactions.
+CREATE TABLE IF NOT EXISTS tags (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_tags_user_name UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS transaction_tags (
+    transaction_id BIGINT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    tag_id         BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+
+    PRIMARY KEY (transaction_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_tags_tag ON transaction_tags(tag_id);
+
+-- Text search over merchant, note and original_description. The 'simple'
+-- configuration does not stem, so bank descriptions in any language match;
+-- queries use prefix terms instead.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple',
+        coalesce(merchant, '') || ' ' || coalesce(note, '') || ' ' || coalesce(original_description, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_search ON transactions USING GIN (search_vector);
+
+-- Savings goals, funded by one account balance or by tagged transactions.
+-- activity_id points at a progress activity; it has no foreign key because
+-- progress tables are created after this file.
+CREATE TABLE IF NOT EXISTS savings_goals (
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT NOT NULL,
+    name            VARCHAR(255) NOT NULL,
+    target_amount   DECIMAL(12,2) NOT NULL,
+    currency        CHAR(3) NOT NULL DEFAULT 'EUR',
+    deadline        TIMESTAMPTZ,
+    funding_account VARCHAR(100),
+    funding_tag     VARCHAR(100),
+    starting_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    activity_id     BIGINT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_savings_goals_user_name UNIQUE (user_id, name),
+    CONSTRAINT check_savings_goal_target CHECK (target_amount > 0),
+    CONSTRAINT check_savings_goal_funding CHECK ((funding_account IS NULL) <> (funding_tag IS NULL))
+);
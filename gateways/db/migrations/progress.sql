@@ -7,7 +7,7 @@ CREATE TABLE IF NOT EXISTS life_parts (
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
-CREATE INDEX idx_life_parts_user_id ON life_parts(user_id);
+CREATE INDEX IF NOT EXISTS idx_life_parts_user_id ON life_parts(user_id);
 
 -- Activities table
 CREATE TABLE IF NOT EXISTS activities (
@@ -24,10 +24,10 @@ CREATE TABLE IF NOT EXISTS activities (
     last_point_at TIMESTAMP -- NULL means no points
 );
 
-CREATE INDEX idx_activities_user_id ON activities(user_id);
-CREATE INDEX idx_activities_ended_at ON activities(ended_at) WHERE ended_at IS NULL;
-CREATE INDEX idx_activities_frequency ON activities(frequency_days);
-CREATE INDEX idx_activities_life_part_ids ON activities USING GIN(life_part_ids);
+CREATE INDEX IF NOT EXISTS idx_activities_user_id ON activities(user_id);
+CREATE INDEX IF NOT EXISTS idx_activities_ended_at ON activities(ended_at) WHERE ended_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_activities_frequency ON activities(frequency_days);
+CREATE INDEX IF NOT EXISTS idx_activities_life_part_ids ON activities USING GIN(life_part_ids);
 
 -- Activity progress table
 CREATE TABLE IF NOT EXISTS activity_progress (
@@ -43,7 +43,94 @@ CREATE TABLE IF NOT EXISTS activity_progress (
     CONSTRAINT fk_progress_activity FOREIGN KEY (activity_id) REFERENCES activities(id) ON DELETE CASCADE
 );
 
-CREATE INDEX idx_progress_activity_id ON activity_progress(activity_id);
-CREATE INDEX idx_progress_user_id ON activity_progress(user_id);
-CREATE INDEX idx_progress_progress_at ON activity_progress(progress_at DESC);
-CREATE INDEX idx_progress_created_at ON activity_progress(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_progress_activity_id ON activity_progress(activity_id);
+CREATE INDEX IF NOT EXISTS idx_progress_user_id ON activity_progress(user_id);
+CREATE INDEX IF NOT EXISTS idx_progress_progress_at ON activity_progress(progress_at DESC);
+CREATE INDEX IF NOT EXISTS idx_progress_created_at ON activity_progress(created_at DESC);
+
+-- User-defined progress scales: value range, named levels and metaphors.
+-- Activities without scale_id use the built-in scale of their progress_type.
+CREATE TABLE IF NOT EXISTS progress_scales (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    min_value INT NOT NULL,
+    max_value INT NOT NULL,
+    levels JSONB NOT NULL DEFAULT '[]',
+    metaphors JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT progress_scales_range CHECK (min_value < max_value),
+    CONSTRAINT progress_scales_user_name UNIQUE (user_id, name)
+);
+
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS scale_id BIGINT REFERENCES progress_scales(id);
+
+-- Point values are checked against the activity scale by the application.
+ALTER TABLE activity_progress DROP CONSTRAINT IF EXISTS activity_progress_value_check;
+
+-- Measured quantities: a unit and per-period target on the activity, an
+-- amount on the point. target_period_days = 0 follows frequency_days.
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS unit VARCHAR(30) NOT NULL DEFAULT '';
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS target_quantity DOUBLE PRECISION;
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS target_period_days INT NOT NULL DEFAULT 0;
+ALTER TABLE activity_progress ADD COLUMN IF NOT EXISTS quantity DOUBLE PRECISION;
+
+-- Daily check-in reminder per user. Sent reminders are notifications with a
+-- per-day dedup_key, so restarts never send one twice.
+CREATE TABLE IF NOT EXISTS reminder_settings (
+    user_id    BIGINT PRIMARY KEY,
+    enabled    BOOLEAN NOT NULL DEFAULT FALSE,
+    remind_at  VARCHAR(5) NOT NULL DEFAULT '09:00',
+    timezone   VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    channel    VARCHAR(20) NOT NULL DEFAULT 'inbox' CHECK (channel IN ('inbox', 'webhook', 'email')),
+    target     TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Failed reminder deliveries are retried until this many attempts.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS delivery_attempts INT NOT NULL DEFAULT 0;
+
+-- Activity hierarchy and project milestones
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS parent_id BIGINT REFERENCES activities(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_activities_parent_id ON activities(parent_id);
+
+CREATE TABLE IF NOT EXISTS activity_milestones (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    activity_id BIGINT NOT NULL REFERENCES activities(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    due_at TIMESTAMP,
+    done_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_milestones_activity_id ON activity_milestones(activity_id);
+
+-- Full-text search over notes. The russian configuration stems Cyrillic words
+-- with the Russian stemmer and Latin words with the English one.
+ALTER TABLE activity_progress ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('russian', COALESCE(note, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_progress_search ON activity_progress USING GIN (search_vector);
+
+-- Optional semantic index of notes. Needs the pgvector extension; without it
+-- nothing is created and note search stays full-text only. Rows go away
+-- with their points.
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS vector;
+    CREATE TABLE IF NOT EXISTS progress_note_embeddings (
+        point_id BIGINT PRIMARY KEY REFERENCES activity_progress(id) ON DELETE CASCADE,
+        user_id BIGINT NOT NULL,
+        model TEXT NOT NULL,
+        note TEXT NOT NULL,
+        embedding vector NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    CREATE INDEX IF NOT EXISTS idx_note_embeddings_user_id ON progress_note_embeddings(user_id);
+EXCEPTION WHEN OTHERS THEN
+    RAISE NOTICE 'pgvector is not available, semantic note search is off: %', SQLERRM;
+END $$;
@@ -3,11 +3,14 @@ package db
 import (
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
@@ -27,6 +30,7 @@ type postgres interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 type repository struct {
@@ -42,6 +46,20 @@ func NewRepository(db postgres) (gateways.DB, gateways.DBMaintainer) {
 	return r, r
 }
 
+// inTx runs fn in a transaction, committing when it returns nil.
+func (r *repository) inTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
 func (r *repository) CreateFood(ctx context.Context, food *domain.Food) (int64, error) {
 	query := `
 		INSERT INTO food (name, user_id, description, barcode, food_type, is_archived,
@@ -169,6 +187,11 @@ func (r *repository) TruncateUserData(ctx context.Context, userID int64) error {
 		return err
 	}
 
+	_, err = r.db.Exec(ctx, `DELETE FROM activity_milestones WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
 	_, err = r.db.Exec(ctx, `DELETE FROM activities WHERE user_id = $1`, userID)
 	if err != nil {
 		return err
@@ -179,16 +202,76 @@ func (r *repository) TruncateUserData(ctx context.Context, userID int64) error {
 		return err
 	}
 
+	_, err = r.db.Exec(ctx, `DELETE FROM progress_scales WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM transaction_splits WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
 	_, err = r.db.Exec(ctx, `DELETE FROM transactions WHERE user_id = $1`, userID)
 	if err != nil {
 		return err
 	}
 
+	_, err = r.db.Exec(ctx, `DELETE FROM tags WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM savings_goals WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
 	_, err = r.db.Exec(ctx, `DELETE FROM budgets WHERE user_id = $1`, userID)
 	if err != nil {
 		return err
 	}
 
+	_, err = r.db.Exec(ctx, `DELETE FROM category_corrections WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM import_profiles WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM accounts WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM recurring_items WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM recurring_budgets WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM budget_alert_settings WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM reminder_settings WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `DELETE FROM notifications WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -196,34 +279,54 @@ func (r *repository) TruncateUserData(ctx context.Context, userID int64) error {
 // Money tracking
 // ---------------------------------------------------------------------------
 
+// AddTransactions inserts the transactions in one transaction, so a failed
+// batch (an import, a multi-row add) stores nothing.
 func (r *repository) AddTransactions(ctx context.Context, txs []*domain.Transaction) ([]*domain.Transaction, error) {
 	now := time.Now().UTC()
-	for _, tx := range txs {
-		tx.CreatedAt = now
-		var id int64
-		err := r.db.QueryRow(ctx, `
-			INSERT INTO transactions
-				(user_id, type, amount_original, currency, amount_eur, account, category,
-				 merchant, note, original_description, transacted_at, created_at)
-			VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
-			RETURNING id`,
-			tx.UserID, tx.Type, tx.AmountOriginal, tx.Currency, tx.AmountEUR,
-			tx.Account, tx.Category, tx.Merchant, tx.Note, tx.OriginalDescription,
-			tx.TransactedAt, tx.CreatedAt,
-		).Scan(&id)
-		if err != nil {
-			return nil, err
+	err := r.inTx(ctx, func(dbTx pgx.Tx) error {
+		for _, tx := range txs {
+			tx.CreatedAt = now
+			if tx.Direction == "" {
+				tx.Direction = domain.DefaultDirection(tx.Type)
+			}
+			// Account names are stored in the spelling of the registered account.
+			err := dbTx.QueryRow(ctx, `
+				INSERT INTO transactions
+					(user_id, type, amount_original, currency, amount_eur, account, category,
+					 merchant, note, original_description, external_id, direction, balance_after,
+					 transacted_at, value_date, created_at)
+				VALUES ($1,$2,$3,$4,$5,
+					COALESCE((SELECT name FROM accounts WHERE user_id = $1 AND LOWER(name) = LOWER($6)), $6),
+					$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)
+				RETURNING id, account`,
+				tx.UserID, tx.Type, tx.AmountOriginal, tx.Currency, tx.AmountEUR,
+				tx.Account, tx.Category, tx.Merchant, tx.Note, tx.OriginalDescription,
+				tx.ExternalID, tx.Direction, tx.BalanceAfter, tx.TransactedAt, tx.ValueDate, tx.CreatedAt,
+			).Scan(&tx.ID, &tx.Account)
+			if err != nil {
+				return err
+			}
 		}
-		tx.ID = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return txs, nil
 }
 
+// EditTransactions applies the updates in one transaction. Category changes
+// are recorded as training signals for the learned category model in the
+// same transaction, so an edit and its correction land together.
 func (r *repository) EditTransactions(ctx context.Context, userID int64, updates []domain.TransactionUpdate) (int, error) {
 	// Verify all IDs belong to userID first — atomicity guarantee.
 	ids := make([]int64, len(updates))
+	var recategorized []int64
 	for i, u := range updates {
 		ids[i] = u.ID
+		if u.Category != nil {
+			recategorized = append(recategorized, u.ID)
+		}
 	}
 
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
@@ -236,62 +339,88 @@ func (r *repository) EditTransactions(ctx context.Context, userID int64, updates
 		return 0, err
 	}
 
-	rows, err := r.db.Query(ctx, checkSQL, checkArgs...)
-	if err != nil {
-		return 0, err
-	}
-	found := 0
-	for rows.Next() {
-		found++
-	}
-	rows.Close()
-	if rows.Err() != nil {
-		return 0, rows.Err()
-	}
-	if found != len(ids) {
-		return 0, fmt.Errorf("one or more transaction IDs not found or not owned by user")
-	}
-
-	// Apply each update individually.
-	for _, u := range updates {
-		q := psql.Update("transactions").Where(squirrel.Eq{"id": u.ID, "user_id": userID})
-		if u.Type != nil {
-			q = q.Set("type", *u.Type)
-		}
-		if u.AmountOriginal != nil {
-			q = q.Set("amount_original", *u.AmountOriginal)
-		}
-		if u.Currency != nil {
-			q = q.Set("currency", *u.Currency)
-		}
-		if u.AmountEUR != nil {
-			q = q.Set("amount_eur", *u.AmountEUR)
-		}
-		if u.Account != nil {
-			q = q.Set("account", *u.Account)
-		}
-		if u.Category != nil {
-			q = q.Set("category", *u.Category)
-		}
-		if u.Merchant != nil {
-			q = q.Set("merchant", *u.Merchant)
-		}
-		if u.Note != nil {
-			q = q.Set("note", *u.Note)
+	err = r.inTx(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, checkSQL, checkArgs...)
+		if err != nil {
+			return err
 		}
-		if u.OriginalDescription != nil {
-			q = q.Set("original_description", *u.OriginalDescription)
+		found := 0
+		for rows.Next() {
+			found++
 		}
-		if u.TransactedAt != nil {
-			q = q.Set("transacted_at", *u.TransactedAt)
+		rows.Close()
+		if rows.Err() != nil {
+			return rows.Err()
 		}
-		sql, args, err := q.ToSql()
-		if err != nil {
-			return 0, err
+		if found != len(ids) {
+			return fmt.Errorf("one or more transaction IDs not found or not owned by user")
 		}
-		if _, err = r.db.Exec(ctx, sql, args...); err != nil {
-			return 0, err
+
+		// Apply each update individually.
+		for _, u := range updates {
+			q := psql.Update("transactions").Where(squirrel.Eq{"id": u.ID, "user_id": userID})
+			if u.Type != nil {
+				q = q.Set("type", *u.Type)
+			}
+			if u.Direction != nil {
+				q = q.Set("direction", *u.Direction)
+			} else if u.Type != nil {
+				q = q.Set("direction", domain.DefaultDirection(*u.Type))
+			}
+			if u.AmountOriginal != nil {
+				q = q.Set("amount_original", *u.AmountOriginal)
+			}
+			if u.Currency != nil {
+				q = q.Set("currency", *u.Currency)
+			}
+			if u.AmountEUR != nil {
+				q = q.Set("amount_eur", *u.AmountEUR)
+			}
+			if u.Account != nil {
+				// Same spelling rule as AddTransactions.
+				q = q.Set("account", squirrel.Expr(
+					"COALESCE((SELECT name FROM accounts WHERE user_id = ? AND LOWER(name) = LOWER(?)), ?)",
+					userID, *u.Account, *u.Account))
+			}
+			if u.Category != nil {
+				q = q.Set("category", *u.Category)
+			}
+			if u.Merchant != nil {
+				q = q.Set("merchant", *u.Merchant)
+			}
+			if u.Note != nil {
+				q = q.Set("note", *u.Note)
+			}
+			if u.OriginalDescription != nil {
+				q = q.Set("original_description", *u.OriginalDescription)
+			}
+			if u.TransactedAt != nil {
+				q = q.Set("transacted_at", *u.TransactedAt)
+			}
+			sql, args, err := q.ToSql()
+			if err != nil {
+				return err
+			}
+			if _, err = tx.Exec(ctx, sql, args...); err != nil {
+				return err
+			}
+			if u.AmountOriginal != nil {
+				// Splits must sum to the parent; a new amount invalidates them.
+				_, err = tx.Exec(ctx, `
+					DELETE FROM transaction_splits
+					WHERE transaction_id = $1
+					  AND (SELECT SUM(amount) FROM transaction_splits WHERE transaction_id = $1) <> $2`,
+					u.ID, *u.AmountOriginal)
+				if err != nil {
+					return err
+				}
+			}
 		}
+
+		return recordCategoryCorrections(ctx, tx, userID, recategorized)
+	})
+	if err != nil {
+		return 0, err
 	}
 	return len(updates), nil
 }
@@ -326,12 +455,84 @@ func (r *repository) SetBudget(ctx context.Context, b *domain.Budget) (int64, er
 }
 
 func (r *repository) GetTransactions(ctx context.Context, filter domain.TransactionFilter) ([]*domain.Transaction, int, error) {
+	base := transactionsQuery(filter)
+
+	// Count query, over all matches regardless of the page
+	var total int
+	if !filter.SkipTotal {
+		countQ := base.RemoveColumns().Column("COUNT(*)")
+		countSQL, countArgs, err := countQ.ToSql()
+		if err != nil {
+			return nil, 0, err
+		}
+		if err = r.db.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Data query. One row over the page size is allowed so callers can tell
+	// whether another page follows.
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > domain.MaxTransactionsPage+1 {
+		limit = domain.MaxTransactionsPage + 1
+	}
+	dataQ := base.OrderBy("transacted_at DESC", "id DESC").Limit(uint64(limit))
+	if filter.After != nil {
+		dataQ = dataQ.Where("(transacted_at, id) < (?, ?)", filter.After.At, filter.After.ID)
+	} else {
+		dataQ = dataQ.Offset(uint64(filter.Offset))
+	}
+	result, err := r.queryTransactions(ctx, dataQ)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result, total, nil
+}
+
+// StreamTransactions passes every transaction matching filter to fn in
+// batches of batchSize, oldest first; Limit and Offset are ignored. A batch is
+// read completely before fn runs, so fn may query the database.
+func (r *repository) StreamTransactions(ctx context.Context, filter domain.TransactionFilter, batchSize int, fn func([]*domain.Transaction) error) error {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	base := transactionsQuery(filter).OrderBy("transacted_at", "id").Limit(uint64(batchSize))
+
+	var last *domain.Transaction
+	for {
+		q := base
+		if last != nil {
+			q = q.Where("(transacted_at, id) > (?, ?)", last.TransactedAt, last.ID)
+		}
+		batch, err := r.queryTransactions(ctx, q)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err = fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		last = batch[len(batch)-1]
+	}
+}
+
+// transactionsQuery selects the transactions matching filter, without order
+// or paging.
+func transactionsQuery(filter domain.TransactionFilter) squirrel.SelectBuilder {
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 	base := psql.Select(
 		"id", "user_id", "type", "amount_original", "currency", "amount_eur",
 		"account", "category", "merchant", "note", "original_description",
-		"transacted_at", "created_at",
+		"external_id", "direction", "balance_after", "transfer_peer_id", "transacted_at", "value_date", "created_at",
 	).From("transactions").Where(squirrel.Eq{"user_id": filter.UserID})
 
 	if filter.From != nil {
@@ -341,10 +542,12 @@ func (r *repository) GetTransactions(ctx context.Context, filter domain.Transact
 		base = base.Where(squirrel.LtOrEq{"transacted_at": *filter.To})
 	}
 	if filter.Account != nil {
-		base = base.Where(squirrel.Eq{"account": *filter.Account})
+		base = base.Where("LOWER(account) = LOWER(?)", *filter.Account)
 	}
 	if filter.Category != nil {
-		base = base.Where("category LIKE ?", *filter.Category+"%")
+		base = base.Where(`(category LIKE ? OR EXISTS (
+			SELECT 1 FROM transaction_splits s WHERE s.transaction_id = transactions.id AND s.category LIKE ?))`,
+			*filter.Category+"%", *filter.Category+"%")
 	}
 	if filter.Type != nil {
 		base = base.Where(squirrel.Eq{"type": *filter.Type})
@@ -352,188 +555,1409 @@ func (r *repository) GetTransactions(ctx context.Context, filter domain.Transact
 	if filter.Merchant != nil {
 		base = base.Where(squirrel.Eq{"merchant": *filter.Merchant})
 	}
+	for _, tag := range filter.Tags {
+		base = base.Where(`EXISTS (
+			SELECT 1 FROM transaction_tags tt JOIN tags g ON g.id = tt.tag_id
+			WHERE tt.transaction_id = transactions.id AND g.name = ?)`, tag)
+	}
+	if filter.Query != nil {
+		base = base.Where("search_vector @@ to_tsquery('simple', ?)", prefixTSQuery(*filter.Query))
+	}
+	if filter.MinEUR != nil {
+		base = base.Where(squirrel.GtOrEq{"amount_eur": *filter.MinEUR})
+	}
+	if filter.MaxEUR != nil {
+		base = base.Where(squirrel.LtOrEq{"amount_eur": *filter.MaxEUR})
+	}
+	return base
+}
+
+func (r *repository) queryTransactions(ctx context.Context, q squirrel.SelectBuilder) ([]*domain.Transaction, error) {
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*domain.Transaction
+	for rows.Next() {
+		tx := &domain.Transaction{}
+		if err = rows.Scan(
+			&tx.ID, &tx.UserID, &tx.Type, &tx.AmountOriginal, &tx.Currency, &tx.AmountEUR,
+			&tx.Account, &tx.Category, &tx.Merchant, &tx.Note, &tx.OriginalDescription,
+			&tx.ExternalID, &tx.Direction, &tx.BalanceAfter, &tx.TransferPeerID, &tx.TransactedAt, &tx.ValueDate, &tx.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, tx)
+	}
+	return result, rows.Err()
+}
+
+// spendingLines yields one row per split line for split transactions and one
+// row per transaction otherwise, so category aggregations count split lines.
+// A line's amount_eur uses its parent's exchange rate.
+const spendingLines = `(
+	SELECT t.id, t.user_id, t.type, t.transfer_peer_id, t.transacted_at, t.merchant,
+	       COALESCE(s.category, t.category) AS category,
+	       COALESCE(s.amount * t.amount_eur / NULLIF(t.amount_original, 0), t.amount_eur) AS amount_eur
+	FROM transactions t
+	LEFT JOIN transaction_splits s ON s.transaction_id = t.id
+)`
+
+func (r *repository) GetSpendingByCategory(ctx context.Context, userID int64, from, to time.Time, depth int) ([]domain.SpendingByCategory, error) {
+	catExpr := categoryPrefix(depth)
+
+	sql := fmt.Sprintf(`
+		SELECT %s AS cat, SUM(amount_eur) AS total_eur, COUNT(*) AS cnt
+		FROM %s lines
+		WHERE user_id = $1
+		  AND type = 'expense'
+		  AND transfer_peer_id IS NULL
+		  AND transacted_at >= $2
+		  AND transacted_at <= $3
+		GROUP BY cat
+		ORDER BY total_eur DESC`, catExpr, spendingLines)
+
+	rows, err := r.db.Query(ctx, sql, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.SpendingByCategory
+	for rows.Next() {
+		var s domain.SpendingByCategory
+		if err = rows.Scan(&s.Category, &s.TotalEUR, &s.Count); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, rows.Err()
+}
+
+// categoryPrefix builds an SQL expression cutting category to its first
+// depth levels, e.g. food/cafe/coffee at depth 2 becomes food/cafe.
+func categoryPrefix(depth int) string {
+	if depth < 1 {
+		depth = 1
+	}
+	// Build category prefix expression: array_to_string(ARRAY[...split_parts...], '/')
+	parts := make([]string, depth)
+	for i := range parts {
+		parts[i] = fmt.Sprintf("split_part(category, '/', %d)", i+1)
+	}
+	catExpr := fmt.Sprintf("array_to_string(ARRAY[%s], '/', '')", join(parts, ", "))
+	// Trim trailing slashes that appear when there are fewer depth levels than requested
+	return fmt.Sprintf("TRIM(TRAILING '/' FROM %s)", catExpr)
+}
+
+func (r *repository) GetTopMerchants(ctx context.Context, userID int64, from, to time.Time, limit int) ([]domain.MerchantSummary, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT merchant, SUM(amount_eur) AS total_eur, COUNT(*) AS cnt
+		FROM transactions
+		WHERE user_id = $1
+		  AND type = 'expense'
+		  AND transfer_peer_id IS NULL
+		  AND transacted_at >= $2
+		  AND transacted_at <= $3
+		GROUP BY merchant
+		ORDER BY total_eur DESC
+		LIMIT $4`, userID, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.MerchantSummary
+	for rows.Next() {
+		var m domain.MerchantSummary
+		if err = rows.Scan(&m.Merchant, &m.TotalEUR, &m.Count); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, rows.Err()
+}
+
+func (r *repository) GetSpendingForPeriod(ctx context.Context, userID int64, from, to time.Time) ([]domain.SpendingByCategory, error) {
+	return r.GetSpendingByCategory(ctx, userID, from, to, 1)
+}
+
+func (r *repository) GetBudgetProgress(ctx context.Context, userID int64, at time.Time) ([]domain.BudgetProgress, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT b.id, b.user_id, b.name, b.category, b.amount_eur, b.starts_at, b.ends_at, b.created_at,
+		       b.recurring_budget_id, COALESCE(SUM(t.amount_eur), 0) AS spent_eur
+		FROM budgets b
+		LEFT JOIN `+spendingLines+` t
+		       ON t.user_id = b.user_id
+		      AND t.type = 'expense'
+		      AND t.transfer_peer_id IS NULL
+		      AND t.category LIKE b.category || '%'
+		      AND t.transacted_at >= b.starts_at
+		      AND t.transacted_at <= b.ends_at
+		WHERE b.user_id = $1
+		  AND b.starts_at <= $2
+		  AND b.ends_at >= $2
+		GROUP BY b.id
+		ORDER BY b.starts_at`, userID, at)
+	if err != nil {
+		return nil, err
+	}
+	return scanBudgetProgress(rows)
+}
+
+func scanBudgetProgress(rows pgx.Rows) ([]domain.BudgetProgress, error) {
+	defer rows.Close()
+
+	var result []domain.BudgetProgress
+	for rows.Next() {
+		var bp domain.BudgetProgress
+		if err := rows.Scan(
+			&bp.ID, &bp.UserID, &bp.Name, &bp.Category, &bp.AmountEUR,
+			&bp.StartsAt, &bp.EndsAt, &bp.CreatedAt, &bp.RecurringBudgetID, &bp.SpentEUR,
+		); err != nil {
+			return nil, err
+		}
+		bp.RemainingEUR = bp.AmountEUR - bp.SpentEUR
+		result = append(result, bp)
+	}
+	return result, rows.Err()
+}
+
+func (r *repository) GetBalance(ctx context.Context, userID int64, from, to time.Time) (domain.BalanceResult, error) {
+	var income, expense float64
+	err := r.db.QueryRow(ctx, `
+		SELECT
+			COALESCE(SUM(CASE WHEN type = 'income'  THEN amount_eur ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN type = 'expense' THEN amount_eur ELSE 0 END), 0)
+		FROM transactions
+		WHERE user_id = $1
+		  AND type IN ('income', 'expense')
+		  AND transfer_peer_id IS NULL
+		  AND transacted_at >= $2
+		  AND transacted_at <= $3`, userID, from, to,
+	).Scan(&income, &expense)
+	if err != nil {
+		return domain.BalanceResult{}, err
+	}
+	return domain.BalanceResult{
+		From:       from,
+		To:         to,
+		IncomeEUR:  income,
+		ExpenseEUR: expense,
+		BalanceEUR: income - expense,
+	}, nil
+}
+
+// GetMonthlyCashflow is GetBalance per calendar month (UTC). Months without
+// income or expenses are omitted.
+func (r *repository) GetMonthlyCashflow(ctx context.Context, userID int64, from, to time.Time) ([]domain.MonthlyCashflow, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT date_trunc('month', transacted_at AT TIME ZONE 'UTC') AS month,
+			COALESCE(SUM(CASE WHEN type = 'income'  THEN amount_eur ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN type = 'expense' THEN amount_eur ELSE 0 END), 0)
+		FROM transactions
+		WHERE user_id = $1
+		  AND type IN ('income', 'expense')
+		  AND transfer_peer_id IS NULL
+		  AND transacted_at >= $2
+		  AND transacted_at <= $3
+		GROUP BY month
+		ORDER BY month`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.MonthlyCashflow
+	for rows.Next() {
+		var m domain.MonthlyCashflow
+		if err = rows.Scan(&m.Month, &m.IncomeEUR, &m.ExpenseEUR); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, rows.Err()
+}
+
+// GetMonthlyCategorySpending is GetSpendingByCategory per calendar month
+// (UTC), counting split lines by their own category.
+func (r *repository) GetMonthlyCategorySpending(ctx context.Context, userID int64, from, to time.Time, depth int) ([]domain.MonthlyCategorySpending, error) {
+	sql := fmt.Sprintf(`
+		SELECT date_trunc('month', transacted_at AT TIME ZONE 'UTC') AS month, %s AS cat, SUM(amount_eur)
+		FROM %s lines
+		WHERE user_id = $1
+		  AND type = 'expense'
+		  AND transfer_peer_id IS NULL
+		  AND transacted_at >= $2
+		  AND transacted_at <= $3
+		GROUP BY month, cat
+		ORDER BY month, cat`, categoryPrefix(depth), spendingLines)
+
+	rows, err := r.db.Query(ctx, sql, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.MonthlyCategorySpending
+	for rows.Next() {
+		var m domain.MonthlyCategorySpending
+		if err = rows.Scan(&m.Month, &m.Category, &m.TotalEUR); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, rows.Err()
+}
+
+// GetMonthlyAccountNets returns the signed monthly change of every registered
+// account in its own currency, counting only transactions since opened_at.
+// Rows in other currencies are left out, as in GetAccountBalances.
+func (r *repository) GetMonthlyAccountNets(ctx context.Context, userID int64, from, to time.Time) ([]domain.AccountMonthNet, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT a.name, date_trunc('month', t.transacted_at AT TIME ZONE 'UTC') AS month,
+		       SUM(CASE WHEN t.direction = 'in' THEN t.amount_original ELSE -t.amount_original END)
+		FROM accounts a
+		JOIN transactions t
+		  ON t.user_id = a.user_id AND LOWER(t.account) = LOWER(a.name) AND t.transacted_at >= a.opened_at
+		 AND t.currency = a.currency
+		WHERE a.user_id = $1
+		  AND t.transacted_at >= $2
+		  AND t.transacted_at <= $3
+		GROUP BY a.name, month
+		ORDER BY a.name, month`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.AccountMonthNet
+	for rows.Next() {
+		var n domain.AccountMonthNet
+		if err = rows.Scan(&n.Account, &n.Month, &n.Net); err != nil {
+			return nil, err
+		}
+		result = append(result, n)
+	}
+	return result, rows.Err()
+}
+
+// recordCategoryCorrections snapshots the current merchant, description and
+// category of the given transactions as training signals. Rows left
+// uncategorized carry no signal and are skipped.
+func recordCategoryCorrections(ctx context.Context, tx pgx.Tx, userID int64, txIDs []int64) error {
+	if len(txIDs) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO category_corrections
+			(user_id, transaction_id, merchant, original_description, category, created_at)
+		SELECT user_id, id, merchant, COALESCE(original_description, ''), category, $3
+		FROM transactions
+		WHERE user_id = $1
+		  AND id = ANY($2)
+		  AND category NOT IN ('', 'uncategorized')`,
+		userID, txIDs, time.Now().UTC())
+	return err
+}
+
+func (r *repository) ListCategoryCorrections(ctx context.Context, userID int64) ([]domain.CategoryCorrection, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, COALESCE(transaction_id, 0), merchant, original_description, category, created_at
+		FROM category_corrections
+		WHERE user_id = $1
+		ORDER BY created_at, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.CategoryCorrection
+	for rows.Next() {
+		var c domain.CategoryCorrection
+		if err = rows.Scan(
+			&c.ID, &c.UserID, &c.TransactionID, &c.Merchant,
+			&c.OriginalDescription, &c.Category, &c.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+// ListExternalIDs returns which of ids are already stored for the account,
+// so statement re-imports can skip transactions seen before.
+func (r *repository) ListExternalIDs(ctx context.Context, userID int64, account string, ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT external_id
+		FROM transactions
+		WHERE user_id = $1 AND LOWER(account) = LOWER($2) AND external_id = ANY($3)`,
+		userID, account, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+	return result, rows.Err()
+}
+
+func (r *repository) SaveImportProfile(ctx context.Context, p *domain.ImportProfile) (int64, error) {
+	now := time.Now().UTC()
+	p.CreatedAt = now
+	p.UpdatedAt = now
+	if p.Aliases == nil {
+		p.Aliases = []string{}
+	}
+	var id int64
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO import_profiles
+			(user_id, name, aliases, delimiter, date_column, date_format, description_column,
+			 amount_column, debit_column, credit_column, currency_column, default_currency,
+			 decimal_style, sign_convention, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)
+		ON CONFLICT (user_id, name) DO UPDATE
+			SET aliases            = EXCLUDED.aliases,
+			    delimiter          = EXCLUDED.delimiter,
+			    date_column        = EXCLUDED.date_column,
+			    date_format        = EXCLUDED.date_format,
+			    description_column = EXCLUDED.description_column,
+			    amount_column      = EXCLUDED.amount_column,
+			    debit_column       = EXCLUDED.debit_column,
+			    credit_column      = EXCLUDED.credit_column,
+			    currency_column    = EXCLUDED.currency_column,
+			    default_currency   = EXCLUDED.default_currency,
+			    decimal_style      = EXCLUDED.decimal_style,
+			    sign_convention    = EXCLUDED.sign_convention,
+			    updated_at         = EXCLUDED.updated_at
+		RETURNING id`,
+		p.UserID, p.Name, p.Aliases, p.Delimiter, p.DateColumn, p.DateFormat, p.DescriptionColumn,
+		p.AmountColumn, p.DebitColumn, p.CreditColumn, p.CurrencyColumn, p.DefaultCurrency,
+		p.DecimalStyle, p.SignConvention, p.CreatedAt, p.UpdatedAt,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *repository) ListImportProfiles(ctx context.Context, userID int64) ([]domain.ImportProfile, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, name, aliases, delimiter, date_column, date_format, description_column,
+		       amount_column, debit_column, credit_column, currency_column, default_currency,
+		       decimal_style, sign_convention, created_at, updated_at
+		FROM import_profiles
+		WHERE user_id = $1
+		ORDER BY name`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.ImportProfile
+	for rows.Next() {
+		var p domain.ImportProfile
+		if err = rows.Scan(
+			&p.ID, &p.UserID, &p.Name, &p.Aliases, &p.Delimiter, &p.DateColumn, &p.DateFormat,
+			&p.DescriptionColumn, &p.AmountColumn, &p.DebitColumn, &p.CreditColumn,
+			&p.CurrencyColumn, &p.DefaultCurrency, &p.DecimalStyle, &p.SignConvention,
+			&p.CreatedAt, &p.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, rows.Err()
+}
+
+// SaveAccount upserts an account by case-insensitive name and renames
+// transactions spelled differently ("revolut") to the account's name.
+func (r *repository) SaveAccount(ctx context.Context, a *domain.Account) (int64, error) {
+	now := time.Now().UTC()
+	a.CreatedAt = now
+	a.UpdatedAt = now
+	var id int64
+	err := r.inTx(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO accounts (user_id, name, currency, type, opening_balance, opened_at, created_at, updated_at)
+			VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+			ON CONFLICT (user_id, LOWER(name)) DO UPDATE
+				SET name            = EXCLUDED.name,
+				    currency        = EXCLUDED.currency,
+				    type            = EXCLUDED.type,
+				    opening_balance = EXCLUDED.opening_balance,
+				    opened_at       = EXCLUDED.opened_at,
+				    updated_at      = EXCLUDED.updated_at
+			RETURNING id`,
+			a.UserID, a.Name, a.Currency, a.Type, a.OpeningBalance, a.OpenedAt, a.CreatedAt, a.UpdatedAt,
+		).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE transactions SET account = $2
+			WHERE user_id = $1 AND LOWER(account) = LOWER($2) AND account <> $2`,
+			a.UserID, a.Name)
+		return err
+	})
+	return id, err
+}
+
+// GetAccount finds an account by case-insensitive name. Returns nil if absent.
+func (r *repository) GetAccount(ctx context.Context, userID int64, name string) (*domain.Account, error) {
+	var a domain.Account
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, name, currency, type, opening_balance, opened_at, created_at, updated_at
+		FROM accounts
+		WHERE user_id = $1 AND LOWER(name) = LOWER($2)`, userID, name,
+	).Scan(&a.ID, &a.UserID, &a.Name, &a.Currency, &a.Type, &a.OpeningBalance, &a.OpenedAt, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+// GetAccountBalances returns every registered account with its opening
+// balance plus signed original-currency amounts from opened_at up to at.
+// Only transactions in the account's currency count towards Balance; the
+// others are summed per currency into OtherCurrencies.
+func (r *repository) GetAccountBalances(ctx context.Context, userID int64, at time.Time) ([]domain.AccountBalance, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT a.id, a.user_id, a.name, a.currency, a.type, a.opening_balance, a.opened_at,
+		       a.created_at, a.updated_at,
+		       a.opening_balance + COALESCE(t.net, 0), COALESCE(t.cnt, 0), t.last_at,
+		       sb.balance_after, sb.transacted_at,
+		       fx.currencies, fx.nets
+		FROM accounts a
+		LEFT JOIN LATERAL (
+			SELECT SUM(CASE WHEN currency <> a.currency THEN 0
+			                WHEN direction = 'in' THEN amount_original
+			                ELSE -amount_original END) AS net,
+			       COUNT(*) AS cnt,
+			       MAX(transacted_at) AS last_at
+			FROM transactions
+			WHERE user_id = a.user_id AND LOWER(account) = LOWER(a.name)
+			  AND transacted_at >= a.opened_at AND transacted_at <= $2
+		) t ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT balance_after, transacted_at
+			FROM transactions
+			WHERE user_id = a.user_id AND LOWER(account) = LOWER(a.name) AND currency = a.currency
+			  AND balance_after IS NOT NULL AND transacted_at <= $2
+			ORDER BY transacted_at DESC, id DESC
+			LIMIT 1
+		) sb ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT array_agg(g.currency ORDER BY g.currency) AS currencies,
+			       array_agg(g.net::float8 ORDER BY g.currency) AS nets
+			FROM (
+				SELECT currency,
+				       SUM(CASE WHEN direction = 'in' THEN amount_original ELSE -amount_original END) AS net
+				FROM transactions
+				WHERE user_id = a.user_id AND LOWER(account) = LOWER(a.name) AND currency <> a.currency
+				  AND transacted_at >= a.opened_at AND transacted_at <= $2
+				GROUP BY currency
+			) g
+		) fx ON TRUE
+		WHERE a.user_id = $1
+		ORDER BY a.name`, userID, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.AccountBalance
+	for rows.Next() {
+		var b domain.AccountBalance
+		var currencies []string
+		var nets []float64
+		if err = rows.Scan(
+			&b.ID, &b.UserID, &b.Name, &b.Currency, &b.Type, &b.OpeningBalance, &b.OpenedAt,
+			&b.CreatedAt, &b.UpdatedAt,
+			&b.Balance, &b.TransactionCount, &b.LastTransactionAt,
+			&b.LastStatementBalance, &b.LastStatementBalanceAt,
+			&currencies, &nets,
+		); err != nil {
+			return nil, err
+		}
+		for i, cur := range currencies {
+			b.OtherCurrencies = append(b.OtherCurrencies, domain.CurrencyBalance{Currency: cur, Balance: nets[i]})
+		}
+		result = append(result, b)
+	}
+	return result, rows.Err()
+}
+
+// ListUnregisteredAccounts returns account names used by transactions that
+// match no registered account.
+func (r *repository) ListUnregisteredAccounts(ctx context.Context, userID int64) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT t.account
+		FROM transactions t
+		WHERE t.user_id = $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM accounts a
+			WHERE a.user_id = t.user_id AND LOWER(a.name) = LOWER(t.account))
+		ORDER BY t.account`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		result = append(result, name)
+	}
+	return result, rows.Err()
+}
+
+// ListAccountTransactions returns an account's transactions in [from, to],
+// oldest first, for running-balance computations.
+func (r *repository) ListAccountTransactions(ctx context.Context, userID int64, account string, from, to time.Time) ([]domain.Transaction, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, type, amount_original, currency, amount_eur, account, category,
+		       merchant, note, original_description, external_id, direction, balance_after,
+		       transfer_peer_id, transacted_at, value_date, created_at
+		FROM transactions
+		WHERE user_id = $1 AND LOWER(account) = LOWER($2)
+		  AND transacted_at >= $3 AND transacted_at <= $4
+		ORDER BY transacted_at, id`, userID, account, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.Transaction
+	for rows.Next() {
+		var tx domain.Transaction
+		if err = rows.Scan(
+			&tx.ID, &tx.UserID, &tx.Type, &tx.AmountOriginal, &tx.Currency, &tx.AmountEUR,
+			&tx.Account, &tx.Category, &tx.Merchant, &tx.Note, &tx.OriginalDescription,
+			&tx.ExternalID, &tx.Direction, &tx.BalanceAfter, &tx.TransferPeerID, &tx.TransactedAt, &tx.ValueDate, &tx.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, tx)
+	}
+	return result, rows.Err()
+}
+
+// ListUnpairedTransactions returns transactions in [from, to] that are not
+// part of a transfer pair, candidates for transfer matching.
+func (r *repository) ListUnpairedTransactions(ctx context.Context, userID int64, from, to time.Time) ([]domain.Transaction, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, type, amount_original, currency, amount_eur, account, category,
+		       merchant, note, original_description, external_id, direction, balance_after,
+		       transfer_peer_id, transacted_at, value_date, created_at
+		FROM transactions
+		WHERE user_id = $1 AND transfer_peer_id IS NULL
+		  AND transacted_at >= $2 AND transacted_at <= $3
+		ORDER BY transacted_at, id`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.Transaction
+	for rows.Next() {
+		var tx domain.Transaction
+		if err = rows.Scan(
+			&tx.ID, &tx.UserID, &tx.Type, &tx.AmountOriginal, &tx.Currency, &tx.AmountEUR,
+			&tx.Account, &tx.Category, &tx.Merchant, &tx.Note, &tx.OriginalDescription,
+			&tx.ExternalID, &tx.Direction, &tx.BalanceAfter, &tx.TransferPeerID, &tx.TransactedAt, &tx.ValueDate, &tx.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, tx)
+	}
+	return result, rows.Err()
+}
+
+// PairTransfers links both sides of each pair. Pairs where either side is
+// already paired or not owned by the user are skipped; returns how many
+// pairs were linked.
+func (r *repository) PairTransfers(ctx context.Context, userID int64, pairs []domain.TransferPair) (int, error) {
+	linked := 0
+	for _, p := range pairs {
+		tag, err := r.db.Exec(ctx, `
+			UPDATE transactions
+			SET transfer_peer_id = CASE WHEN id = $2 THEN $3::BIGINT ELSE $2::BIGINT END
+			WHERE user_id = $1
+			  AND id IN ($2, $3)
+			  AND transfer_peer_id IS NULL
+			  AND (SELECT COUNT(*) FROM transactions
+			       WHERE user_id = $1 AND id IN ($2, $3) AND transfer_peer_id IS NULL) = 2`,
+			userID, p.OutID, p.InID)
+		if err != nil {
+			return linked, err
+		}
+		if tag.RowsAffected() == 2 {
+			linked++
+		}
+	}
+	return linked, nil
+}
+
+// UnpairTransfer clears the transfer link on a transaction and its peer.
+func (r *repository) UnpairTransfer(ctx context.Context, userID int64, id int64) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE transactions
+		SET transfer_peer_id = NULL
+		WHERE user_id = $1 AND (id = $2 OR transfer_peer_id = $2) AND transfer_peer_id IS NOT NULL`,
+		userID, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("transaction not found or not paired")
+	}
+	return nil
+}
+
+// ReplaceRecurringItems swaps the user's detected recurring items for a new
+// detection result.
+func (r *repository) ReplaceRecurringItems(ctx context.Context, userID int64, items []domain.RecurringItem) error {
+	now := time.Now().UTC()
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM recurring_items WHERE user_id = $1`, userID); err != nil {
+			return err
+		}
+		for i := range items {
+			it := &items[i]
+			it.UserID = userID
+			it.DetectedAt = now
+			err := tx.QueryRow(ctx, `
+				INSERT INTO recurring_items
+					(user_id, merchant, account, category, cadence, currency, typical_amount,
+					 last_amount, previous_amount, occurrences, first_charged_at, last_charged_at,
+					 next_charge_at, detected_at)
+				VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)
+				RETURNING id`,
+				it.UserID, it.Merchant, it.Account, it.Category, it.Cadence, it.Currency, it.TypicalAmount,
+				it.LastAmount, it.PreviousAmount, it.Occurrences, it.FirstChargedAt, it.LastChargedAt,
+				it.NextChargeAt, it.DetectedAt,
+			).Scan(&it.ID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) ListRecurringItems(ctx context.Context, userID int64) ([]domain.RecurringItem, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, merchant, account, category, cadence, currency, typical_amount,
+		       last_amount, previous_amount, occurrences, first_charged_at, last_charged_at,
+		       next_charge_at, detected_at
+		FROM recurring_items
+		WHERE user_id = $1
+		ORDER BY next_charge_at, merchant`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.RecurringItem
+	for rows.Next() {
+		var it domain.RecurringItem
+		if err = rows.Scan(
+			&it.ID, &it.UserID, &it.Merchant, &it.Account, &it.Category, &it.Cadence, &it.Currency,
+			&it.TypicalAmount, &it.LastAmount, &it.PreviousAmount, &it.Occurrences,
+			&it.FirstChargedAt, &it.LastChargedAt, &it.NextChargeAt, &it.DetectedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, it)
+	}
+	return result, rows.Err()
+}
+
+// SaveRecurringBudget upserts a definition by name. Periods that have not
+// ended yet, or that start after the new end, are dropped so they are
+// materialized again with the new settings; finished periods keep the amount
+// they had. A new schedule (period or start) or category makes the finished
+// periods wrong too, so the whole history is rebuilt.
+func (r *repository) SaveRecurringBudget(ctx context.Context, rb *domain.RecurringBudget) (int64, error) {
+	now := time.Now().UTC()
+	rb.UpdatedAt = now
+	err := r.inTx(ctx, func(tx pgx.Tx) error {
+		var (
+			oldPeriod   domain.BudgetPeriod
+			oldStartsAt time.Time
+			oldCategory string
+		)
+		err := tx.QueryRow(ctx, `
+			SELECT period, starts_at, category
+			FROM recurring_budgets
+			WHERE user_id = $1 AND name = $2
+			FOR UPDATE`,
+			rb.UserID, rb.Name,
+		).Scan(&oldPeriod, &oldStartsAt, &oldCategory)
+		if err != nil && err != pgx.ErrNoRows {
+			return err
+		}
+		rebuild := err == nil && (oldPeriod != rb.Period || !oldStartsAt.Equal(rb.StartsAt) || oldCategory != rb.Category)
+
+		err = tx.QueryRow(ctx, `
+			INSERT INTO recurring_budgets (user_id, name, category, amount_eur, period, rollover, starts_at, ends_at, created_at, updated_at)
+			VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$9)
+			ON CONFLICT (user_id, name) DO UPDATE
+				SET category   = EXCLUDED.category,
+				    amount_eur = EXCLUDED.amount_eur,
+				    period     = EXCLUDED.period,
+				    rollover   = EXCLUDED.rollover,
+				    starts_at  = EXCLUDED.starts_at,
+				    ends_at    = EXCLUDED.ends_at,
+				    updated_at = EXCLUDED.updated_at
+			RETURNING id, created_at`,
+			rb.UserID, rb.Name, rb.Category, rb.AmountEUR, rb.Period, rb.Rollover, rb.StartsAt, rb.EndsAt, now,
+		).Scan(&rb.ID, &rb.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		if rebuild {
+			_, err = tx.Exec(ctx, `
+				DELETE FROM budgets
+				WHERE user_id = $1 AND recurring_budget_id = $2`,
+				rb.UserID, rb.ID)
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			DELETE FROM budgets
+			WHERE user_id = $1 AND recurring_budget_id = $2
+			  AND (ends_at >= $3 OR ($4::timestamptz IS NOT NULL AND starts_at >= $4))`,
+			rb.UserID, rb.ID, now, rb.EndsAt)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return rb.ID, nil
+}
+
+func (r *repository) ListRecurringBudgets(ctx context.Context, userID int64) ([]domain.RecurringBudget, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, name, category, amount_eur, period, rollover, starts_at, ends_at, created_at, updated_at
+		FROM recurring_budgets
+		WHERE user_id = $1
+		ORDER BY name`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.RecurringBudget
+	for rows.Next() {
+		var rb domain.RecurringBudget
+		if err = rows.Scan(
+			&rb.ID, &rb.UserID, &rb.Name, &rb.Category, &rb.AmountEUR, &rb.Period, &rb.Rollover,
+			&rb.StartsAt, &rb.EndsAt, &rb.CreatedAt, &rb.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, rb)
+	}
+	return result, rows.Err()
+}
+
+// AddBudgetPeriods stores materialized periods in one transaction, skipping
+// ones that already exist. A period whose name is taken by another budget
+// fails the batch.
+func (r *repository) AddBudgetPeriods(ctx context.Context, periods []domain.Budget) error {
+	now := time.Now().UTC()
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		for _, b := range periods {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO budgets (user_id, name, category, amount_eur, starts_at, ends_at, created_at, recurring_budget_id)
+				VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+				ON CONFLICT (recurring_budget_id, starts_at) WHERE recurring_budget_id IS NOT NULL DO NOTHING`,
+				b.UserID, b.Name, b.Category, b.AmountEUR, b.StartsAt, b.EndsAt, now, b.RecurringBudgetID,
+			)
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.ConstraintName == "uq_budgets_user_name" {
+				return fmt.Errorf("budget %q already exists; rename it so the recurring period can be stored", b.Name)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListBudgetPeriods returns the materialized periods of a recurring budget
+// that started by to, oldest first, with spending.
+func (r *repository) ListBudgetPeriods(ctx context.Context, userID, recurringBudgetID int64, to time.Time) ([]domain.BudgetProgress, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT b.id, b.user_id, b.name, b.category, b.amount_eur, b.starts_at, b.ends_at, b.created_at,
+		       b.recurring_budget_id, COALESCE(SUM(t.amount_eur), 0) AS spent_eur
+		FROM budgets b
+		LEFT JOIN `+spendingLines+` t
+		       ON t.user_id = b.user_id
+		      AND t.type = 'expense'
+		      AND t.transfer_peer_id IS NULL
+		      AND t.category LIKE b.category || '%'
+		      AND t.transacted_at >= b.starts_at
+		      AND t.transacted_at <= b.ends_at
+		WHERE b.user_id = $1
+		  AND b.recurring_budget_id = $2
+		  AND b.starts_at <= $3
+		GROUP BY b.id
+		ORDER BY b.starts_at`, userID, recurringBudgetID, to)
+	if err != nil {
+		return nil, err
+	}
+	return scanBudgetProgress(rows)
+}
+
+// GetCategorySpending sums unpaired expenses under a category prefix.
+func (r *repository) GetCategorySpending(ctx context.Context, userID int64, category string, from, to time.Time) (float64, error) {
+	var spent float64
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount_eur), 0)
+		FROM `+spendingLines+` lines
+		WHERE user_id = $1
+		  AND type = 'expense'
+		  AND transfer_peer_id IS NULL
+		  AND category LIKE $2 || '%'
+		  AND transacted_at >= $3
+		  AND transacted_at <= $4`, userID, category, from, to,
+	).Scan(&spent)
+	return spent, err
+}
+
+// GetBudgetAlertSettings returns nil when the user has not saved settings.
+func (r *repository) GetBudgetAlertSettings(ctx context.Context, userID int64) (*domain.BudgetAlertSettings, error) {
+	var s domain.BudgetAlertSettings
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id, thresholds, forecast_alerts, webhook_url, updated_at
+		FROM budget_alert_settings
+		WHERE user_id = $1`, userID,
+	).Scan(&s.UserID, &s.Thresholds, &s.ForecastAlerts, &s.WebhookURL, &s.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *repository) SaveBudgetAlertSettings(ctx context.Context, s *domain.BudgetAlertSettings) error {
+	s.UpdatedAt = time.Now().UTC()
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO budget_alert_settings (user_id, thresholds, forecast_alerts, webhook_url, updated_at)
+		VALUES ($1,$2,$3,$4,$5)
+		ON CONFLICT (user_id) DO UPDATE
+			SET thresholds      = EXCLUDED.thresholds,
+			    forecast_alerts = EXCLUDED.forecast_alerts,
+			    webhook_url     = EXCLUDED.webhook_url,
+			    updated_at      = EXCLUDED.updated_at`,
+		s.UserID, s.Thresholds, s.ForecastAlerts, s.WebhookURL, s.UpdatedAt,
+	)
+	return err
+}
+
+// GetReminderSettings returns nil when the user has not saved settings.
+func (r *repository) GetReminderSettings(ctx context.Context, userID int64) (*domain.ReminderSettings, error) {
+	var s domain.ReminderSettings
+	err := r.db.QueryRow(ctx, `
+		SELECT user_id, enabled, remind_at, timezone, channel, target, updated_at
+		FROM reminder_settings
+		WHERE user_id = $1`, userID,
+	).Scan(&s.UserID, &s.Enabled, &s.RemindAt, &s.Timezone, &s.Channel, &s.Target, &s.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *repository) SaveReminderSettings(ctx context.Context, s *domain.ReminderSettings) error {
+	s.UpdatedAt = time.Now().UTC()
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO reminder_settings (user_id, enabled, remind_at, timezone, channel, target, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+		ON CONFLICT (user_id) DO UPDATE
+			SET enabled    = EXCLUDED.enabled,
+			    remind_at  = EXCLUDED.remind_at,
+			    timezone   = EXCLUDED.timezone,
+			    channel    = EXCLUDED.channel,
+			    target     = EXCLUDED.target,
+			    updated_at = EXCLUDED.updated_at`,
+		s.UserID, s.Enabled, s.RemindAt, s.Timezone, s.Channel, s.Target, s.UpdatedAt,
+	)
+	return err
+}
+
+// ListEnabledReminderSettings returns the settings of every user with
+// reminders on; the scheduler walks them on each tick.
+func (r *repository) ListEnabledReminderSettings(ctx context.Context) ([]domain.ReminderSettings, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id, enabled, remind_at, timezone, channel, target, updated_at
+		FROM reminder_settings
+		WHERE enabled
+		ORDER BY user_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.ReminderSettings
+	for rows.Next() {
+		var s domain.ReminderSettings
+		if err = rows.Scan(&s.UserID, &s.Enabled, &s.RemindAt, &s.Timezone, &s.Channel, &s.Target, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, rows.Err()
+}
+
+// AddNotification stores n unless one with the same dedup key exists.
+// Reports whether it was new; n.ID and n.CreatedAt are set when it was.
+func (r *repository) AddNotification(ctx context.Context, n *domain.Notification) (bool, error) {
+	n.CreatedAt = time.Now().UTC()
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO notifications (user_id, kind, dedup_key, title, body, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6)
+		ON CONFLICT (user_id, dedup_key) DO NOTHING
+		RETURNING id`,
+		n.UserID, n.Kind, n.DedupKey, n.Title, n.Body, n.CreatedAt,
+	).Scan(&n.ID)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
 
-	// Count query
-	countQ := base.RemoveColumns().Column("COUNT(*)")
-	countSQL, countArgs, err := countQ.ToSql()
+func (r *repository) ListNotifications(ctx context.Context, userID int64, unreadOnly bool, limit int) ([]domain.Notification, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, kind, dedup_key, title, body, created_at, read_at, delivered_at, delivery_error
+		FROM notifications
+		WHERE user_id = $1
+		  AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3`, userID, unreadOnly, limit)
 	if err != nil {
-		return nil, 0, err
-	}
-	var total int
-	if err = r.db.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
-		return nil, 0, err
+		return nil, err
 	}
+	defer rows.Close()
 
-	// Data query
-	limit := filter.Limit
-	if limit <= 0 {
-		limit = 50
-	}
-	if limit > 200 {
-		limit = 200
+	var result []domain.Notification
+	for rows.Next() {
+		var n domain.Notification
+		if err = rows.Scan(
+			&n.ID, &n.UserID, &n.Kind, &n.DedupKey, &n.Title, &n.Body,
+			&n.CreatedAt, &n.ReadAt, &n.DeliveredAt, &n.DeliveryError,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, n)
 	}
-	dataQ := base.OrderBy("transacted_at DESC").Limit(uint64(limit)).Offset(uint64(filter.Offset))
-	dataSQL, dataArgs, err := dataQ.ToSql()
+	return result, rows.Err()
+}
+
+// MarkNotificationsRead marks the given notifications, or all unread ones
+// when ids is empty, and returns how many changed.
+func (r *repository) MarkNotificationsRead(ctx context.Context, userID int64, ids []int64) (int, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE notifications SET read_at = $3
+		WHERE user_id = $1
+		  AND read_at IS NULL
+		  AND (cardinality($2::bigint[]) = 0 OR id = ANY($2))`,
+		userID, ids, time.Now().UTC())
 	if err != nil {
-		return nil, 0, err
+		return 0, err
 	}
+	return int(tag.RowsAffected()), nil
+}
+
+// SetNotificationDelivery records the outcome of an outbound delivery.
+func (r *repository) SetNotificationDelivery(ctx context.Context, id int64, deliveredAt *time.Time, deliveryError *string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notifications SET delivered_at = $2, delivery_error = $3, delivery_attempts = delivery_attempts + 1
+		WHERE id = $1`, id, deliveredAt, deliveryError)
+	return err
+}
 
-	rows, err := r.db.Query(ctx, dataSQL, dataArgs...)
+// ListUndeliveredNotifications returns notifications of the kind that were
+// never delivered and had fewer than maxAttempts delivery attempts, oldest first.
+func (r *repository) ListUndeliveredNotifications(ctx context.Context, userID int64, kind string, maxAttempts int) ([]domain.Notification, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, kind, dedup_key, title, body, created_at, read_at, delivered_at, delivery_error
+		FROM notifications
+		WHERE user_id = $1 AND kind = $2
+		  AND delivered_at IS NULL AND delivery_attempts < $3
+		ORDER BY created_at, id`, userID, kind, maxAttempts)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer rows.Close()
 
-	var result []*domain.Transaction
+	var result []domain.Notification
 	for rows.Next() {
-		tx := &domain.Transaction{}
+		var n domain.Notification
 		if err = rows.Scan(
-			&tx.ID, &tx.UserID, &tx.Type, &tx.AmountOriginal, &tx.Currency, &tx.AmountEUR,
-			&tx.Account, &tx.Category, &tx.Merchant, &tx.Note, &tx.OriginalDescription,
-			&tx.TransactedAt, &tx.CreatedAt,
+			&n.ID, &n.UserID, &n.Kind, &n.DedupKey, &n.Title, &n.Body,
+			&n.CreatedAt, &n.ReadAt, &n.DeliveredAt, &n.DeliveryError,
 		); err != nil {
-			return nil, 0, err
+			return nil, err
 		}
-		result = append(result, tx)
-	}
-	if rows.Err() != nil {
-		return nil, 0, rows.Err()
+		result = append(result, n)
 	}
-	return result, total, nil
+	return result, rows.Err()
 }
 
-func (r *repository) GetSpendingByCategory(ctx context.Context, userID int64, from, to time.Time, depth int) ([]domain.SpendingByCategory, error) {
-	if depth < 1 {
-		depth = 1
+// GetTransaction returns nil when the transaction does not exist or belongs
+// to another user.
+func (r *repository) GetTransaction(ctx context.Context, userID, id int64) (*domain.Transaction, error) {
+	tx := &domain.Transaction{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, type, amount_original, currency, amount_eur, account, category, merchant,
+		       note, original_description, external_id, direction, balance_after, transfer_peer_id,
+		       transacted_at, value_date, created_at
+		FROM transactions
+		WHERE id = $1 AND user_id = $2`, id, userID,
+	).Scan(
+		&tx.ID, &tx.UserID, &tx.Type, &tx.AmountOriginal, &tx.Currency, &tx.AmountEUR,
+		&tx.Account, &tx.Category, &tx.Merchant, &tx.Note, &tx.OriginalDescription,
+		&tx.ExternalID, &tx.Direction, &tx.BalanceAfter, &tx.TransferPeerID, &tx.TransactedAt, &tx.ValueDate, &tx.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
 	}
-	// Build category prefix expression: array_to_string(ARRAY[...split_parts...], '/')
-	parts := make([]string, depth)
-	for i := range parts {
-		parts[i] = fmt.Sprintf("split_part(category, '/', %d)", i+1)
+	if err != nil {
+		return nil, err
 	}
-	catExpr := fmt.Sprintf("array_to_string(ARRAY[%s], '/', '')", join(parts, ", "))
-	// Trim trailing slashes that appear when there are fewer depth levels than requested
-	catExpr = fmt.Sprintf("TRIM(TRAILING '/' FROM %s)", catExpr)
+	return tx, nil
+}
 
-	sql := fmt.Sprintf(`
-		SELECT %s AS cat, SUM(amount_eur) AS total_eur, COUNT(*) AS cnt
-		FROM transactions
-		WHERE user_id = $1
-		  AND type = 'expense'
-		  AND transacted_at >= $2
-		  AND transacted_at <= $3
-		GROUP BY cat
-		ORDER BY total_eur DESC`, catExpr)
+// ReplaceTransactionSplits swaps the split lines of a transaction; an empty
+// slice removes the split.
+func (r *repository) ReplaceTransactionSplits(ctx context.Context, userID, transactionID int64, splits []domain.TransactionSplit) error {
+	// One transaction, so the stored lines always sum to the parent.
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx,
+			`DELETE FROM transaction_splits WHERE transaction_id = $1 AND user_id = $2`, transactionID, userID)
+		if err != nil {
+			return err
+		}
+		for i := range splits {
+			sp := &splits[i]
+			sp.TransactionID = transactionID
+			sp.UserID = userID
+			err := tx.QueryRow(ctx, `
+				INSERT INTO transaction_splits (transaction_id, user_id, position, category, amount, note)
+				VALUES ($1,$2,$3,$4,$5,$6)
+				RETURNING id`,
+				transactionID, userID, i, sp.Category, sp.Amount, sp.Note,
+			).Scan(&sp.ID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
-	rows, err := r.db.Query(ctx, sql, userID, from, to)
+// ListTransactionSplits returns the split lines of the given transactions in
+// entry order, with amount_eur at the parent's exchange rate.
+func (r *repository) ListTransactionSplits(ctx context.Context, userID int64, transactionIDs []int64) ([]domain.TransactionSplit, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT s.id, s.transaction_id, s.user_id, s.category, s.amount, s.note,
+		       ROUND(COALESCE(s.amount * t.amount_eur / NULLIF(t.amount_original, 0), 0), 2)
+		FROM transaction_splits s
+		JOIN transactions t ON t.id = s.transaction_id
+		WHERE s.user_id = $1 AND s.transaction_id = ANY($2)
+		ORDER BY s.transaction_id, s.position`, userID, transactionIDs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var result []domain.SpendingByCategory
+	var result []domain.TransactionSplit
 	for rows.Next() {
-		var s domain.SpendingByCategory
-		if err = rows.Scan(&s.Category, &s.TotalEUR, &s.Count); err != nil {
+		var sp domain.TransactionSplit
+		if err = rows.Scan(&sp.ID, &sp.TransactionID, &sp.UserID, &sp.Category, &sp.Amount, &sp.Note, &sp.AmountEUR); err != nil {
 			return nil, err
 		}
-		result = append(result, s)
+		result = append(result, sp)
 	}
 	return result, rows.Err()
 }
 
-func (r *repository) GetTopMerchants(ctx context.Context, userID int64, from, to time.Time, limit int) ([]domain.MerchantSummary, error) {
-	if limit <= 0 {
-		limit = 10
+// prefixTSQuery turns free text into a tsquery matching every word as a
+// prefix, so "gift" finds "gifts" and "giftcard". Punctuation is dropped
+// rather than passed to to_tsquery, which rejects unbalanced operators.
+func prefixTSQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// AddTransactionTags creates missing tags and attaches them to the user's
+// transactions. Returns the number of new links.
+func (r *repository) AddTransactionTags(ctx context.Context, userID int64, transactionIDs []int64, names []string) (int, error) {
+	added := 0
+	err := r.inTx(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO tags (user_id, name)
+			SELECT $1, unnest($2::text[])
+			ON CONFLICT (user_id, name) DO NOTHING`, userID, names)
+		if err != nil {
+			return err
+		}
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO transaction_tags (transaction_id, tag_id)
+			SELECT t.id, g.id
+			FROM transactions t
+			JOIN tags g ON g.user_id = t.user_id AND g.name = ANY($3)
+			WHERE t.user_id = $1 AND t.id = ANY($2)
+			ON CONFLICT DO NOTHING`, userID, transactionIDs, names)
+		if err != nil {
+			return err
+		}
+		added = int(tag.RowsAffected())
+		return nil
+	})
+	return added, err
+}
+
+// RemoveTransactionTags detaches tags from the user's transactions. Tags
+// stay defined even when no transaction uses them any more.
+func (r *repository) RemoveTransactionTags(ctx context.Context, userID int64, transactionIDs []int64, names []string) (int, error) {
+	tag, err := r.db.Exec(ctx, `
+		DELETE FROM transaction_tags tt
+		USING tags g
+		WHERE g.id = tt.tag_id AND g.user_id = $1 AND g.name = ANY($3) AND tt.transaction_id = ANY($2)`,
+		userID, transactionIDs, names)
+	if err != nil {
+		return 0, err
 	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ListTransactionTags returns tag names of the given transactions, sorted
+// by name within each transaction.
+func (r *repository) ListTransactionTags(ctx context.Context, userID int64, transactionIDs []int64) ([]domain.TransactionTag, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT merchant, SUM(amount_eur) AS total_eur, COUNT(*) AS cnt
-		FROM transactions
-		WHERE user_id = $1
-		  AND type = 'expense'
-		  AND transacted_at >= $2
-		  AND transacted_at <= $3
-		GROUP BY merchant
-		ORDER BY total_eur DESC
-		LIMIT $4`, userID, from, to, limit)
+		SELECT tt.transaction_id, g.name
+		FROM transaction_tags tt
+		JOIN tags g ON g.id = tt.tag_id
+		WHERE g.user_id = $1 AND tt.transaction_id = ANY($2)
+		ORDER BY tt.transaction_id, g.name`, userID, transactionIDs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var result []domain.MerchantSummary
+	var result []domain.TransactionTag
 	for rows.Next() {
-		var m domain.MerchantSummary
-		if err = rows.Scan(&m.Merchant, &m.TotalEUR, &m.Count); err != nil {
+		var t domain.TransactionTag
+		if err = rows.Scan(&t.TransactionID, &t.Name); err != nil {
 			return nil, err
 		}
-		result = append(result, m)
+		result = append(result, t)
 	}
 	return result, rows.Err()
 }
 
-func (r *repository) GetSpendingForPeriod(ctx context.Context, userID int64, from, to time.Time) ([]domain.SpendingByCategory, error) {
-	return r.GetSpendingByCategory(ctx, userID, from, to, 1)
+// ListTags returns every tag of the user with the number of tagged
+// transactions and their expense total, most used first.
+func (r *repository) ListTags(ctx context.Context, userID int64) ([]domain.TagSummary, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT g.name, COUNT(t.id),
+		       COALESCE(SUM(t.amount_eur) FILTER (WHERE t.type = 'expense'), 0)
+		FROM tags g
+		LEFT JOIN transaction_tags tt ON tt.tag_id = g.id
+		LEFT JOIN transactions t ON t.id = tt.transaction_id
+		WHERE g.user_id = $1
+		GROUP BY g.id, g.name
+		ORDER BY COUNT(t.id) DESC, g.name`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.TagSummary
+	for rows.Next() {
+		var t domain.TagSummary
+		if err = rows.Scan(&t.Name, &t.TransactionCount, &t.SpentEUR); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
 }
 
-func (r *repository) GetBudgetProgress(ctx context.Context, userID int64, at time.Time) ([]domain.BudgetProgress, error) {
+// RenameTag renames a tag. When newName already exists the two tags are
+// merged. Savings goals funded by the tag follow the new name. Returns false
+// when the tag does not exist.
+func (r *repository) RenameTag(ctx context.Context, userID int64, name, newName string) (bool, error) {
+	renamed := false
+	err := r.inTx(ctx, func(tx pgx.Tx) error {
+		var id int64
+		err := tx.QueryRow(ctx, `SELECT id FROM tags WHERE user_id = $1 AND name = $2`, userID, name).Scan(&id)
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE savings_goals SET funding_tag = $3 WHERE user_id = $1 AND funding_tag = $2`, userID, name, newName)
+		if err != nil {
+			return err
+		}
+
+		var targetID int64
+		err = tx.QueryRow(ctx, `SELECT id FROM tags WHERE user_id = $1 AND name = $2`, userID, newName).Scan(&targetID)
+		if err == pgx.ErrNoRows {
+			_, err = tx.Exec(ctx, `UPDATE tags SET name = $2 WHERE id = $1`, id, newName)
+			renamed = err == nil
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO transaction_tags (transaction_id, tag_id)
+			SELECT transaction_id, $2 FROM transaction_tags WHERE tag_id = $1
+			ON CONFLICT DO NOTHING`, id, targetID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM tags WHERE id = $1`, id)
+		renamed = err == nil
+		return err
+	})
+	return renamed, err
+}
+
+// DeleteTag removes a tag from every transaction. Returns false when the
+// tag does not exist.
+func (r *repository) DeleteTag(ctx context.Context, userID int64, name string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM tags WHERE user_id = $1 AND name = $2`, userID, name)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SaveSavingsGoal upserts a goal by name.
+func (r *repository) SaveSavingsGoal(ctx context.Context, g *domain.SavingsGoal) (int64, error) {
+	now := time.Now().UTC()
+	g.UpdatedAt = now
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO savings_goals
+			(user_id, name, target_amount, currency, deadline, funding_account, funding_tag,
+			 starting_amount, activity_id, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$10)
+		ON CONFLICT (user_id, name) DO UPDATE
+			SET target_amount   = EXCLUDED.target_amount,
+			    currency        = EXCLUDED.currency,
+			    deadline        = EXCLUDED.deadline,
+			    funding_account = EXCLUDED.funding_account,
+			    funding_tag     = EXCLUDED.funding_tag,
+			    starting_amount = EXCLUDED.starting_amount,
+			    activity_id     = EXCLUDED.activity_id,
+			    updated_at      = EXCLUDED.updated_at
+		RETURNING id, created_at`,
+		g.UserID, g.Name, g.TargetAmount, g.Currency, g.Deadline, g.FundingAccount, g.FundingTag,
+		g.StartingAmount, g.ActivityID, now,
+	).Scan(&g.ID, &g.CreatedAt)
+	return g.ID, err
+}
+
+func (r *repository) ListSavingsGoals(ctx context.Context, userID int64) ([]domain.SavingsGoal, error) {
 	rows, err := r.db.Query(ctx, `
-		SELECT b.id, b.user_id, b.name, b.category, b.amount_eur, b.starts_at, b.ends_at, b.created_at,
-		       COALESCE(SUM(t.amount_eur), 0) AS spent_eur
-		FROM budgets b
-		LEFT JOIN transactions t
-		       ON t.user_id = b.user_id
-		      AND t.type = 'expense'
-		      AND t.category LIKE b.category || '%'
-		      AND t.transacted_at >= b.starts_at
-		      AND t.transacted_at <= b.ends_at
-		WHERE b.user_id = $1
-		  AND b.starts_at <= $2
-		  AND b.ends_at >= $2
-		GROUP BY b.id, b.user_id, b.name, b.category, b.amount_eur, b.starts_at, b.ends_at, b.created_at
-		ORDER BY b.starts_at`, userID, at)
+		SELECT id, user_id, name, target_amount, currency, deadline, funding_account, funding_tag,
+		       starting_amount, activity_id, created_at, updated_at
+		FROM savings_goals
+		WHERE user_id = $1
+		ORDER BY deadline NULLS LAST, name`, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var result []domain.BudgetProgress
+	var result []domain.SavingsGoal
 	for rows.Next() {
-		var bp domain.BudgetProgress
+		var g domain.SavingsGoal
 		if err = rows.Scan(
-			&bp.ID, &bp.UserID, &bp.Name, &bp.Category, &bp.AmountEUR,
-			&bp.StartsAt, &bp.EndsAt, &bp.CreatedAt, &bp.SpentEUR,
+			&g.ID, &g.UserID, &g.Name, &g.TargetAmount, &g.Currency, &g.Deadline, &g.FundingAccount, &g.FundingTag,
+			&g.StartingAmount, &g.ActivityID, &g.CreatedAt, &g.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
-		bp.RemainingEUR = bp.AmountEUR - bp.SpentEUR
-		result = append(result, bp)
+		result = append(result, g)
 	}
 	return result, rows.Err()
 }
 
-func (r *repository) GetBalance(ctx context.Context, userID int64, from, to time.Time) (domain.BalanceResult, error) {
-	var income, expense float64
+// GetTagSavings returns what transactions tagged with tag put aside up to at,
+// in EUR: income adds and expenses take away. Transfers, including expense
+// and income rows paired between own accounts, count by direction: money
+// going out is moved into savings, money coming in is taken out of them.
+// When both legs of a paired transfer carry the tag only the outgoing one
+// counts.
+func (r *repository) GetTagSavings(ctx context.Context, userID int64, tag string, at time.Time) (float64, error) {
+	var saved float64
 	err := r.db.QueryRow(ctx, `
-		SELECT
-			COALESCE(SUM(CASE WHEN type = 'income'  THEN amount_eur ELSE 0 END), 0),
-			COALESCE(SUM(CASE WHEN type = 'expense' THEN amount_eur ELSE 0 END), 0)
-		FROM transactions
-		WHERE user_id = $1
-		  AND type IN ('income', 'expense')
-		  AND transacted_at >= $2
-		  AND transacted_at <= $3`, userID, from, to,
-	).Scan(&income, &expense)
-	if err != nil {
-		return domain.BalanceResult{}, err
-	}
-	return domain.BalanceResult{
-		From:       from,
-		To:         to,
-		IncomeEUR:  income,
-		ExpenseEUR: expense,
-		BalanceEUR: income - expense,
-	}, nil
+		SELECT COALESCE(SUM(CASE
+			WHEN t.type = 'transfer' OR t.transfer_peer_id IS NOT NULL THEN CASE
+				WHEN t.direction = 'out' THEN t.amount_eur
+				WHEN EXISTS (
+					SELECT 1 FROM transaction_tags pt
+					WHERE pt.transaction_id = t.transfer_peer_id AND pt.tag_id = g.id) THEN 0
+				ELSE -t.amount_eur
+			END
+			WHEN t.type = 'expense' THEN -t.amount_eur
+			ELSE t.amount_eur
+		END), 0)
+		FROM tags g
+		JOIN transaction_tags tt ON tt.tag_id = g.id
+		JOIN transactions t ON t.id = tt.transaction_id
+		WHERE g.user_id = $1 AND g.name = $2 AND t.transacted_at <= $3`,
+		userID, tag, at,
+	).Scan(&saved)
+	return saved, err
 }
 
 // join is a local helper because strings.Join is not in scope here.
@@ -703,6 +2127,47 @@ func (r *repository) GetConsumptionLogsByUser(ctx context.Context, userID int64)
 	return logs, nil
 }
 
+func (r *repository) ListConsumptionLogs(ctx context.Context, userID int64, from time.Time, to time.Time) ([]*domain.ConsumptionLog, error) {
+	query := `
+		SELECT user_id, consumed_at, food_id, food_name, amount_g, meal_type, note, nutrients
+		FROM consumption_log
+		WHERE user_id = $1
+		  AND consumed_at >= $2
+		  AND consumed_at <= $3
+		ORDER BY consumed_at ASC`
+
+	rows, err := r.db.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []*domain.ConsumptionLog
+	for rows.Next() {
+		log := &domain.ConsumptionLog{}
+		err := rows.Scan(
+			&log.UserID,
+			&log.ConsumedAt,
+			&log.FoodID,
+			&log.FoodName,
+			&log.AmountG,
+			&log.MealType,
+			&log.Note,
+			&log.Nutrients,
+		)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return logs, nil
+}
+
 func (r *repository) GetLastConsumptionTime(ctx context.Context, userID int64) (*time.Time, error) {
 	query := `
 		SELECT consumed_at
@@ -1071,7 +2536,52 @@ func (r *repository) ListWorkouts(ctx context.Context, userID int64) ([]domain.W
 		WHERE user_id = $1
 		ORDER BY started_at DESC`
 
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workouts: %w", err)
+	}
+	defer rows.Close()
+
+	var workouts []domain.Workout
+	for rows.Next() {
+		var w domain.Workout
+		err := rows.Scan(
+			&w.ID,
+			&w.UserID,
+			&w.StartedAt,
+			&w.CompletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workout: %w", err)
+		}
+		workouts = append(workouts, w)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return workouts, nil
+}
+
+// ListWorkoutsPage returns up to limit workouts, newest first, starting
+// after the cursor when one is given.
+func (r *repository) ListWorkoutsPage(ctx context.Context, userID int64, after *domain.Cursor, limit int) ([]domain.Workout, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	q := psql.Select("id", "user_id", "started_at", "completed_at").
+		From("workouts").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("started_at DESC", "id DESC").
+		Limit(uint64(limit))
+	if after != nil {
+		q = q.Where("(started_at, id) < (?, ?)", after.At, after.ID)
+	}
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query workouts: %w", err)
 	}
@@ -1080,23 +2590,49 @@ func (r *repository) ListWorkouts(ctx context.Context, userID int64) ([]domain.W
 	var workouts []domain.Workout
 	for rows.Next() {
 		var w domain.Workout
-		err := rows.Scan(
-			&w.ID,
-			&w.UserID,
-			&w.StartedAt,
-			&w.CompletedAt,
-		)
-		if err != nil {
+		if err := rows.Scan(&w.ID, &w.UserID, &w.StartedAt, &w.CompletedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan workout: %w", err)
 		}
 		workouts = append(workouts, w)
 	}
 
-	if rows.Err() != nil {
-		return nil, rows.Err()
+	return workouts, rows.Err()
+}
+
+// ListSetsByWorkouts returns every set of the given workouts, oldest first.
+func (r *repository) ListSetsByWorkouts(ctx context.Context, userID int64, workoutIDs []int64) ([]domain.Set, error) {
+	if len(workoutIDs) == 0 {
+		return []domain.Set{}, nil
 	}
 
-	return workouts, nil
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	q, args, err := psql.Select(
+		"id", "user_id", "workout_id", "exercise_id",
+		"COALESCE(reps, 0)", "COALESCE(duration_seconds, 0)", "COALESCE(weight_kg, 0)", "created_at",
+	).From("sets").
+		Where(squirrel.Eq{"user_id": userID, "workout_id": workoutIDs}).
+		OrderBy("created_at ASC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sets: %w", err)
+	}
+	defer rows.Close()
+
+	var sets []domain.Set
+	for rows.Next() {
+		var s domain.Set
+		if err := rows.Scan(&s.ID, &s.UserID, &s.WorkoutID, &s.ExerciseID, &s.Reps, &s.DurationSeconds, &s.WeightKg, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan set: %w", err)
+		}
+		sets = append(sets, s)
+	}
+
+	return sets, rows.Err()
 }
 
 func (r *repository) GetWorkoutByDate(ctx context.Context, userID int64, date time.Time) (*domain.Workout, error) {
@@ -1362,16 +2898,28 @@ func (r *repository) GetWorkoutsByIDs(ctx context.Context, userID int64, workout
 	return workouts, nil
 }
 
-func (r *repository) GetExerciseHistory(ctx context.Context, userID int64, exerciseID int64, limit int, offset int) ([]domain.Workout, error) {
-	query := `
-		SELECT DISTINCT w.id, w.user_id, w.started_at, w.completed_at
-		FROM workouts w
-		JOIN sets s ON s.workout_id = w.id
-		WHERE s.user_id = $1 AND s.exercise_id = $2
-		ORDER BY w.started_at DESC
-		LIMIT $3 OFFSET $4`
+// GetExerciseHistory returns workouts containing the exercise, newest first.
+// With a cursor only workouts after it are returned and offset is ignored.
+func (r *repository) GetExerciseHistory(ctx context.Context, userID int64, exerciseID int64, after *domain.Cursor, limit int, offset int) ([]domain.Workout, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	q := psql.Select("w.id", "w.user_id", "w.started_at", "w.completed_at").
+		Distinct().
+		From("workouts w").
+		Join("sets s ON s.workout_id = w.id").
+		Where(squirrel.Eq{"s.user_id": userID, "s.exercise_id": exerciseID}).
+		OrderBy("w.started_at DESC", "w.id DESC").
+		Limit(uint64(limit))
+	if after != nil {
+		q = q.Where("(w.started_at, w.id) < (?, ?)", after.At, after.ID)
+	} else {
+		q = q.Offset(uint64(offset))
+	}
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
 
-	rows, err := r.db.Query(ctx, query, userID, exerciseID, limit, offset)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query exercise history: %w", err)
 	}
@@ -1472,10 +3020,253 @@ func (r *repository) ListSetsByExerciseAndWorkouts(ctx context.Context, userID i
 	return sets, rows.Err()
 }
 
+// SaveProgressScale creates a scale, or replaces the definition of the
+// user's scale with the same name. ID and timestamps are set on return.
+// A new range must still hold every point of the activities using the scale.
+func (r *repository) SaveProgressScale(ctx context.Context, scale *domain.ProgressScale) error {
+	if scale.Levels == nil {
+		scale.Levels = []domain.ScaleLevel{}
+	}
+	if scale.Metaphors == nil {
+		scale.Metaphors = []domain.ScaleMetaphor{}
+	}
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		var outside int
+		err := tx.QueryRow(ctx, `
+			SELECT COUNT(*)
+			FROM activity_progress p
+			JOIN activities a ON a.id = p.activity_id
+			JOIN progress_scales s ON s.id = a.scale_id
+			WHERE s.user_id = $1 AND s.name = $2
+			  AND (p.value < $3 OR p.value > $4)`,
+			scale.UserID, scale.Name, scale.MinValue, scale.MaxValue,
+		).Scan(&outside)
+		if err != nil {
+			return err
+		}
+		if outside > 0 {
+			return fmt.Errorf("%d progress points of activities using the scale are outside %+d..%+d; edit them first", outside, scale.MinValue, scale.MaxValue)
+		}
+
+		return tx.QueryRow(ctx, `
+			INSERT INTO progress_scales (user_id, name, description, min_value, max_value, levels, metaphors)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (user_id, name) DO UPDATE SET
+				description = EXCLUDED.description,
+				min_value = EXCLUDED.min_value,
+				max_value = EXCLUDED.max_value,
+				levels = EXCLUDED.levels,
+				metaphors = EXCLUDED.metaphors,
+				updated_at = CURRENT_TIMESTAMP
+			RETURNING id, created_at, updated_at`,
+			scale.UserID, scale.Name, scale.Description, scale.MinValue, scale.MaxValue, scale.Levels, scale.Metaphors,
+		).Scan(&scale.ID, &scale.CreatedAt, &scale.UpdatedAt)
+	})
+}
+
+// ListProgressScales returns the user's scales ordered by name.
+func (r *repository) ListProgressScales(ctx context.Context, userID int64) ([]domain.ProgressScale, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, name, description, min_value, max_value, levels, metaphors, created_at, updated_at
+		FROM progress_scales
+		WHERE user_id = $1
+		ORDER BY name`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scales []domain.ProgressScale
+	for rows.Next() {
+		var s domain.ProgressScale
+		err := rows.Scan(&s.ID, &s.UserID, &s.Name, &s.Description, &s.MinValue, &s.MaxValue,
+			&s.Levels, &s.Metaphors, &s.CreatedAt, &s.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		scales = append(scales, s)
+	}
+	return scales, rows.Err()
+}
+
+// DeleteProgressScale removes a scale no activity uses. Returns false when
+// the scale does not exist.
+func (r *repository) DeleteProgressScale(ctx context.Context, userID, id int64) (bool, error) {
+	var used int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM activities WHERE user_id = $1 AND scale_id = $2`, userID, id).Scan(&used)
+	if err != nil {
+		return false, err
+	}
+	if used > 0 {
+		return false, fmt.Errorf("progress scale is used by %d activities", used)
+	}
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM progress_scales WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SaveLifePart creates a life part, or updates name and description when
+// ID is set. Returns false when an existing part is not found.
+func (r *repository) SaveLifePart(ctx context.Context, part *domain.LifePart) (bool, error) {
+	if part.ID == 0 {
+		err := r.db.QueryRow(ctx, `
+			INSERT INTO life_parts (user_id, name, description)
+			VALUES ($1, $2, $3)
+			RETURNING id, created_at`,
+			part.UserID, part.Name, part.Description,
+		).Scan(&part.ID, &part.CreatedAt)
+		return err == nil, err
+	}
+
+	err := r.db.QueryRow(ctx, `
+		UPDATE life_parts SET name = $3, description = $4
+		WHERE id = $1 AND user_id = $2
+		RETURNING created_at`,
+		part.ID, part.UserID, part.Name, part.Description,
+	).Scan(&part.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ListLifeParts returns the user's life parts ordered by name.
+func (r *repository) ListLifeParts(ctx context.Context, userID int64) ([]domain.LifePart, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, name, COALESCE(description, ''), created_at
+		FROM life_parts
+		WHERE user_id = $1
+		ORDER BY lower(name), id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var parts []domain.LifePart
+	for rows.Next() {
+		var p domain.LifePart
+		if err := rows.Scan(&p.ID, &p.UserID, &p.Name, &p.Description, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		parts = append(parts, p)
+	}
+	return parts, rows.Err()
+}
+
+// DeleteLifePart removes a life part and unlinks it from every activity.
+// Returns false when the part does not exist.
+func (r *repository) DeleteLifePart(ctx context.Context, userID, id int64) (bool, error) {
+	deleted := false
+	err := r.inTx(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `DELETE FROM life_parts WHERE id = $1 AND user_id = $2`, id, userID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE activities SET life_part_ids = array_remove(life_part_ids, $1)
+			WHERE user_id = $2 AND $1 = ANY(life_part_ids)`, id, userID)
+		deleted = err == nil
+		return err
+	})
+	return deleted, err
+}
+
+// SaveMilestone creates a milestone, or updates name, due and done dates
+// when ID is set. Returns false when an existing milestone is not found.
+func (r *repository) SaveMilestone(ctx context.Context, m *domain.Milestone) (bool, error) {
+	if m.ID == 0 {
+		err := r.db.QueryRow(ctx, `
+			INSERT INTO activity_milestones (user_id, activity_id, name, due_at, done_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at`,
+			m.UserID, m.ActivityID, m.Name, m.DueAt, m.DoneAt,
+		).Scan(&m.ID, &m.CreatedAt)
+		return err == nil, err
+	}
+
+	err := r.db.QueryRow(ctx, `
+		UPDATE activity_milestones SET name = $3, due_at = $4, done_at = $5
+		WHERE id = $1 AND user_id = $2
+		RETURNING activity_id, created_at`,
+		m.ID, m.UserID, m.Name, m.DueAt, m.DoneAt,
+	).Scan(&m.ActivityID, &m.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetMilestone returns nil when the milestone does not exist.
+func (r *repository) GetMilestone(ctx context.Context, userID, id int64) (*domain.Milestone, error) {
+	var m domain.Milestone
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, activity_id, name, due_at, done_at, created_at
+		FROM activity_milestones
+		WHERE id = $1 AND user_id = $2`, id, userID,
+	).Scan(&m.ID, &m.UserID, &m.ActivityID, &m.Name, &m.DueAt, &m.DoneAt, &m.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ListMilestones returns the milestones of the given activities, or of all
+// activities when none are given, ordered by due date with undated last.
+func (r *repository) ListMilestones(ctx context.Context, userID int64, activityIDs []int64) ([]domain.Milestone, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	q := psql.Select("id", "user_id", "activity_id", "name", "due_at", "done_at", "created_at").
+		From("activity_milestones").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("due_at ASC NULLS LAST", "id")
+	if len(activityIDs) > 0 {
+		q = q.Where(squirrel.Eq{"activity_id": activityIDs})
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var milestones []domain.Milestone
+	for rows.Next() {
+		var m domain.Milestone
+		if err := rows.Scan(&m.ID, &m.UserID, &m.ActivityID, &m.Name, &m.DueAt, &m.DoneAt, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		milestones = append(milestones, m)
+	}
+	return milestones, rows.Err()
+}
+
+// DeleteMilestone returns false when the milestone does not exist.
+func (r *repository) DeleteMilestone(ctx context.Context, userID, id int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM activity_milestones WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
 func (r *repository) CreateActivity(ctx context.Context, activity *domain.Activity) (int64, error) {
 	query := `
-		INSERT INTO activities (user_id, life_part_ids, name, description, progress_type, frequency_days, started_at, ended_at, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO activities (user_id, life_part_ids, name, description, progress_type, frequency_days, started_at, ended_at, created_at, scale_id,
+		                        unit, target_quantity, target_period_days, parent_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING id`
 
 	now := time.Now()
@@ -1492,6 +3283,11 @@ func (r *repository) CreateActivity(ctx context.Context, activity *domain.Activi
 		activity.StartedAt,
 		activity.EndedAt,
 		activity.CreatedAt,
+		activity.ScaleID,
+		activity.Unit,
+		activity.Target,
+		activity.TargetDays,
+		activity.ParentID,
 	).Scan(&id)
 
 	return id, err
@@ -1502,7 +3298,8 @@ func (r *repository) ListActivities(ctx context.Context, filter domain.ActivityF
 
 	query := psql.Select(
 		"id", "user_id", "life_part_ids", "name", "description",
-		"progress_type", "frequency_days", "started_at", "ended_at", "created_at", "last_point_at",
+		"progress_type", "frequency_days", "started_at", "ended_at", "created_at", "last_point_at", "scale_id",
+		"unit", "target_quantity", "target_period_days", "parent_id",
 	).From("activities").
 		Where(squirrel.Eq{"user_id": filter.UserID})
 
@@ -1547,6 +3344,11 @@ func (r *repository) ListActivities(ctx context.Context, filter domain.ActivityF
 			&a.EndedAt,
 			&a.CreatedAt,
 			&a.LastPointAt,
+			&a.ScaleID,
+			&a.Unit,
+			&a.Target,
+			&a.TargetDays,
+			&a.ParentID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan activity: %w", err)
@@ -1564,7 +3366,8 @@ func (r *repository) ListActivities(ctx context.Context, filter domain.ActivityF
 func (r *repository) GetActivity(ctx context.Context, activityID int64, userID int64) (*domain.Activity, error) {
 	query := `
 		SELECT id, user_id, life_part_ids, name, description,
-		       progress_type, frequency_days, started_at, ended_at, created_at
+		       progress_type, frequency_days, started_at, ended_at, created_at, last_point_at, scale_id,
+		       unit, target_quantity, target_period_days, parent_id
 		FROM activities
 		WHERE id = $1 AND user_id = $2`
 
@@ -1580,6 +3383,12 @@ func (r *repository) GetActivity(ctx context.Context, activityID int64, userID i
 		&a.StartedAt,
 		&a.EndedAt,
 		&a.CreatedAt,
+		&a.LastPointAt,
+		&a.ScaleID,
+		&a.Unit,
+		&a.Target,
+		&a.TargetDays,
+		&a.ParentID,
 	)
 	if err != nil {
 		if err.Error() == "no rows in result set" {
@@ -1594,7 +3403,8 @@ func (r *repository) GetActivity(ctx context.Context, activityID int64, userID i
 func (r *repository) UpdateActivity(ctx context.Context, activity *domain.Activity) error {
 	query := `
 		UPDATE activities
-		SET name = $1, description = $2, frequency_days = $3, life_part_ids = $4, started_at = $5, ended_at = $6
+		SET name = $1, description = $2, frequency_days = $3, life_part_ids = $4, started_at = $5, ended_at = $6, scale_id = $9,
+		    unit = $10, target_quantity = $11, target_period_days = $12, parent_id = $13
 		WHERE id = $7 AND user_id = $8`
 
 	result, err := r.db.Exec(ctx, query,
@@ -1606,6 +3416,11 @@ func (r *repository) UpdateActivity(ctx context.Context, activity *domain.Activi
 		activity.EndedAt,
 		activity.ID,
 		activity.UserID,
+		activity.ScaleID,
+		activity.Unit,
+		activity.Target,
+		activity.TargetDays,
+		activity.ParentID,
 	)
 	if err != nil {
 		return err
@@ -1638,43 +3453,158 @@ func (r *repository) FinishActivity(ctx context.Context, activityID int64, userI
 
 func (r *repository) CreateProgress(ctx context.Context, progress *domain.ActivityPoint) (int64, error) {
 	query := `
-		INSERT INTO activity_progress (activity_id, user_id, value, hours_left, note, progress_at, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO activity_progress (activity_id, user_id, value, hours_left, note, progress_at, created_at, quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id`
 
 	now := time.Now()
 	progress.CreatedAt = now
 
 	var id int64
-	err := r.db.QueryRow(ctx, query,
-		progress.ActivityID,
-		progress.UserID,
-		progress.Value,
-		progress.HoursLeft,
-		progress.Note,
-		progress.ProgressAt,
-		progress.CreatedAt,
-	).Scan(&id)
+	err := r.inTx(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, query,
+			progress.ActivityID,
+			progress.UserID,
+			progress.Value,
+			progress.HoursLeft,
+			progress.Note,
+			progress.ProgressAt,
+			progress.CreatedAt,
+			progress.Quantity,
+		).Scan(&id)
+		if err != nil {
+			return err
+		}
 
+		// Update activity's last_point_at
+		return refreshLastPointAt(ctx, tx, progress.ActivityID)
+	})
 	if err != nil {
 		return 0, err
 	}
 
-	// Update activity's last_point_at
-	updateQuery := `UPDATE activities SET last_point_at = $1 WHERE id = $2`
-	_, err = r.db.Exec(ctx, updateQuery, progress.ProgressAt, progress.ActivityID)
+	return id, nil
+}
+
+// GetProgress returns a progress point of the user, or nil when not found.
+func (r *repository) GetProgress(ctx context.Context, id int64, userID int64) (*domain.ActivityPoint, error) {
+	var p domain.ActivityPoint
+	err := r.db.QueryRow(ctx, `
+		SELECT id, activity_id, user_id, value, hours_left, note, progress_at, created_at, quantity
+		FROM activity_progress
+		WHERE id = $1 AND user_id = $2`, id, userID).Scan(
+		&p.ID,
+		&p.ActivityID,
+		&p.UserID,
+		&p.Value,
+		&p.HoursLeft,
+		&p.Note,
+		&p.ProgressAt,
+		&p.CreatedAt,
+		&p.Quantity,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return id, fmt.Errorf("failed to update last_point_at: %w", err)
+		return nil, err
 	}
+	return &p, nil
+}
 
-	return id, nil
+// UpdateProgress overwrites a progress point, possibly moving it to another
+// activity, and refreshes last_point_at of the activities involved in the
+// same transaction.
+func (r *repository) UpdateProgress(ctx context.Context, progress *domain.ActivityPoint) error {
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		var previousActivityID int64
+		err := tx.QueryRow(ctx, `
+			UPDATE activity_progress p
+			SET activity_id = $3, value = $4, hours_left = $5, note = $6, progress_at = $7, quantity = $8
+			FROM activity_progress old
+			WHERE p.id = $1 AND p.user_id = $2 AND old.id = p.id
+			RETURNING old.activity_id`,
+			progress.ID,
+			progress.UserID,
+			progress.ActivityID,
+			progress.Value,
+			progress.HoursLeft,
+			progress.Note,
+			progress.ProgressAt,
+			progress.Quantity,
+		).Scan(&previousActivityID)
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("progress point not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		if previousActivityID != progress.ActivityID {
+			if err = refreshLastPointAt(ctx, tx, previousActivityID); err != nil {
+				return err
+			}
+		}
+		return refreshLastPointAt(ctx, tx, progress.ActivityID)
+	})
+}
+
+// DeleteProgress removes a progress point and refreshes last_point_at of its
+// activity in the same transaction. Returns false when the point does not
+// exist.
+func (r *repository) DeleteProgress(ctx context.Context, id int64, userID int64) (bool, error) {
+	deleted := false
+	err := r.inTx(ctx, func(tx pgx.Tx) error {
+		var activityID int64
+		err := tx.QueryRow(ctx, `
+			DELETE FROM activity_progress
+			WHERE id = $1 AND user_id = $2
+			RETURNING activity_id`, id, userID).Scan(&activityID)
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = refreshLastPointAt(ctx, tx, activityID); err != nil {
+			return err
+		}
+		deleted = true
+		return nil
+	})
+	return deleted, err
+}
+
+// CountProgressOutsideRange counts the activity's progress points with a
+// value outside min..max, so a scale change cannot strand existing points.
+func (r *repository) CountProgressOutsideRange(ctx context.Context, userID, activityID int64, min, max int) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM activity_progress
+		WHERE user_id = $1 AND activity_id = $2 AND (value < $3 OR value > $4)`,
+		userID, activityID, min, max,
+	).Scan(&n)
+	return n, err
+}
+
+// refreshLastPointAt sets last_point_at to the latest progress_at of the
+// activity, or NULL when it has no points left.
+func refreshLastPointAt(ctx context.Context, tx pgx.Tx, activityID int64) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE activities
+		SET last_point_at = (SELECT MAX(progress_at) FROM activity_progress WHERE activity_id = $1)
+		WHERE id = $1`, activityID)
+	if err != nil {
+		return fmt.Errorf("failed to update last_point_at: %w", err)
+	}
+	return nil
 }
 
 func (r *repository) ListProgress(ctx context.Context, filter domain.ProgressFilter) ([]domain.ActivityPoint, error) {
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 	query := psql.Select(
-		"id", "activity_id", "user_id", "value", "hours_left", "note", "progress_at", "created_at",
+		"id", "activity_id", "user_id", "value", "hours_left", "note", "progress_at", "created_at", "quantity",
 	).From("activity_progress").
 		Where(squirrel.Eq{"user_id": filter.UserID}).
 		OrderBy("progress_at DESC")
@@ -1718,6 +3648,7 @@ func (r *repository) ListProgress(ctx context.Context, filter domain.ProgressFil
 			&p.Note,
 			&p.ProgressAt,
 			&p.CreatedAt,
+			&p.Quantity,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan progress point: %w", err)
@@ -1732,37 +3663,150 @@ func (r *repository) ListProgress(ctx context.Context, filter domain.ProgressFil
 	return points, nil
 }
 
+// ListActivitySubtrees returns the given activities of the user with all their
+// sub-activities at any depth, whatever their start or end date, and the
+// progress points of all of them, in one query. Points are newest first.
+func (r *repository) ListActivitySubtrees(ctx context.Context, userID int64, rootIDs []int64) ([]domain.Activity, []domain.ActivityPoint, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM activities WHERE user_id = $1 AND id = ANY($2)
+			UNION
+			SELECT a.id FROM activities a JOIN tree t ON a.parent_id = t.id WHERE a.user_id = $1
+		)
+		SELECT a.id, a.user_id, a.life_part_ids, a.name, a.description,
+		       a.progress_type, a.frequency_days, a.started_at, a.ended_at, a.created_at, a.last_point_at, a.scale_id,
+		       a.unit, a.target_quantity, a.target_period_days, a.parent_id,
+		       p.id, p.value, p.hours_left, p.note, p.progress_at, p.created_at, p.quantity
+		FROM tree
+		JOIN activities a ON a.id = tree.id
+		LEFT JOIN activity_progress p ON p.activity_id = a.id
+		ORDER BY a.id, p.progress_at DESC, p.id DESC`, userID, rootIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query activity subtrees: %w", err)
+	}
+	defer rows.Close()
+
+	var activities []domain.Activity
+	var points []domain.ActivityPoint
+	for rows.Next() {
+		var a domain.Activity
+		var pointID *int64
+		var value *int
+		var note *string
+		var progressAt, createdAt *time.Time
+		var p domain.ActivityPoint
+		err := rows.Scan(
+			&a.ID, &a.UserID, &a.LifePartIDs, &a.Name, &a.Description,
+			&a.ProgressType, &a.FrequencyDays, &a.StartedAt, &a.EndedAt, &a.CreatedAt, &a.LastPointAt, &a.ScaleID,
+			&a.Unit, &a.Target, &a.TargetDays, &a.ParentID,
+			&pointID, &value, &p.HoursLeft, &note, &progressAt, &createdAt, &p.Quantity,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan activity subtree: %w", err)
+		}
+		if len(activities) == 0 || activities[len(activities)-1].ID != a.ID {
+			activities = append(activities, a)
+		}
+		if pointID == nil {
+			continue
+		}
+		p.ID, p.ActivityID, p.UserID, p.Value = *pointID, a.ID, a.UserID, *value
+		p.ProgressAt, p.CreatedAt = *progressAt, *createdAt
+		if note != nil {
+			p.Note = *note
+		}
+		points = append(points, p)
+	}
+	return activities, points, rows.Err()
+}
+
+// SearchProgressNotes runs one query for all variants: a point matches when
+// its note matches any of them, and match_count says how many. A variant
+// matches by full-text search, by substring so partial words still hit, or
+// by embedding similarity when filter.Embeddings is set. Notes matching more
+// variants come first, then by rank.
 func (r *repository) SearchProgressNotes(ctx context.Context, filter domain.ProgressNoteSearchFilter) ([]domain.ActivityPointWithActivity, error) {
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	semantic := len(filter.Embeddings) > 0
+
+	matchCount := make([]string, len(filter.Queries))
+	lexicalRank := make([]string, len(filter.Queries))
+	var countArgs, rankArgs []interface{}
+	for i, q := range filter.Queries {
+		match := "ap.search_vector @@ websearch_to_tsquery('russian', ?) OR ap.note ILIKE ?"
+		countArgs = append(countArgs, q, "%"+q+"%")
+		if semantic {
+			match += " OR 1 - (e.embedding <=> ?::vector) >= ?"
+			countArgs = append(countArgs, vectorLiteral(filter.Embeddings[i]), filter.MinSimilarity)
+		}
+		matchCount[i] = "(CASE WHEN " + match + " THEN 1 ELSE 0 END)"
+		lexicalRank[i] = "ts_rank(ap.search_vector, websearch_to_tsquery('russian', ?))"
+		rankArgs = append(rankArgs, q)
+	}
+
+	similarity := squirrel.Expr("NULL::float8 AS similarity")
+	if semantic {
+		distances := make([]string, len(filter.Embeddings))
+		var simArgs []interface{}
+		for i, v := range filter.Embeddings {
+			distances[i] = "e.embedding <=> ?::vector"
+			simArgs = append(simArgs, vectorLiteral(v))
+		}
+		similarity = squirrel.Expr("(1 - LEAST("+join(distances, ", ")+"))::float8 AS similarity", simArgs...)
+	}
 
-	query := psql.Select(
+	inner := psql.Select(
 		"ap.id", "ap.activity_id", "ap.user_id", "ap.value", "ap.hours_left", "ap.note", "ap.progress_at", "ap.created_at",
 		"a.name AS activity_name",
 	).
+		Column(squirrel.Expr(join(matchCount, " + ")+" AS match_count", countArgs...)).
+		Column(squirrel.Expr("("+join(lexicalRank, " + ")+")::float8 AS lexical_rank", rankArgs...)).
+		Column(similarity).
 		From("activity_progress ap").
 		Join("activities a ON a.id = ap.activity_id").
-		Where(squirrel.Eq{"ap.user_id": filter.UserID}).
-		Where(squirrel.ILike{"ap.note": "%" + filter.Query + "%"}).
-		OrderBy("ap.progress_at DESC")
+		Where(squirrel.Eq{"ap.user_id": filter.UserID})
+
+	if semantic {
+		// Embeddings of another model or of an older note text do not count
+		inner = inner.LeftJoin("progress_note_embeddings e ON e.point_id = ap.id AND e.model = ? AND e.note = ap.note", filter.EmbeddingModel)
+	}
 
 	if filter.ActivityID != 0 {
-		query = query.Where(squirrel.Eq{"ap.activity_id": filter.ActivityID})
+		inner = inner.Where(squirrel.Eq{"ap.activity_id": filter.ActivityID})
 	}
 
 	if !filter.From.IsZero() {
-		query = query.Where(squirrel.GtOrEq{"ap.progress_at": filter.From})
+		inner = inner.Where(squirrel.GtOrEq{"ap.progress_at": filter.From})
 	}
 
 	if !filter.To.IsZero() {
-		query = query.Where(squirrel.LtOrEq{"ap.progress_at": filter.To})
+		inner = inner.Where(squirrel.LtOrEq{"ap.progress_at": filter.To})
 	}
 
 	if filter.ValueMin != nil {
-		query = query.Where(squirrel.GtOrEq{"ap.value": *filter.ValueMin})
+		inner = inner.Where(squirrel.GtOrEq{"ap.value": *filter.ValueMin})
 	}
 
 	if filter.ValueMax != nil {
-		query = query.Where(squirrel.LtOrEq{"ap.value": *filter.ValueMax})
+		inner = inner.Where(squirrel.LtOrEq{"ap.value": *filter.ValueMax})
+	}
+
+	scored := psql.Select("*", "lexical_rank + COALESCE(similarity, 0) AS rank").
+		FromSelect(inner, "matched")
+
+	query := psql.Select(
+		"id", "activity_id", "user_id", "value", "hours_left", "note", "progress_at", "created_at",
+		"activity_name", "match_count", "similarity", "rank",
+	).
+		FromSelect(scored, "notes").
+		Where("match_count > 0").
+		OrderBy("match_count DESC", "rank DESC", "progress_at DESC", "id DESC")
+	if filter.After != nil {
+		query = query.Where("(match_count, rank, progress_at, id) < (?, ?, ?, ?)",
+			filter.After.Count, filter.After.Rank, filter.After.At, filter.After.ID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(uint64(filter.Limit))
 	}
 
 	sql, args, err := query.ToSql()
@@ -1789,6 +3833,9 @@ func (r *repository) SearchProgressNotes(ctx context.Context, filter domain.Prog
 			&p.ProgressAt,
 			&p.CreatedAt,
 			&p.ActivityName,
+			&p.MatchCount,
+			&p.Similarity,
+			&p.Rank,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan progress note: %w", err)
@@ -1803,6 +3850,71 @@ func (r *repository) SearchProgressNotes(ctx context.Context, filter domain.Prog
 	return results, nil
 }
 
+// NoteEmbeddingsAvailable reports whether the semantic note index exists; the
+// migration only creates it when pgvector is installed.
+func (r *repository) NoteEmbeddingsAvailable(ctx context.Context) (bool, error) {
+	var ok bool
+	err := r.db.QueryRow(ctx, `SELECT to_regclass('progress_note_embeddings') IS NOT NULL`).Scan(&ok)
+	return ok, err
+}
+
+// ListNotesToEmbed returns points of the user with a note that has no
+// embedding from model yet, or one of an older text, oldest first.
+func (r *repository) ListNotesToEmbed(ctx context.Context, userID int64, model string, limit int) ([]domain.ActivityPoint, error) {
+	query := `
+		SELECT ap.id, ap.user_id, ap.note
+		FROM activity_progress ap
+		LEFT JOIN progress_note_embeddings e ON e.point_id = ap.id AND e.model = $2
+		WHERE ap.user_id = $1
+		  AND COALESCE(ap.note, '') <> ''
+		  AND (e.point_id IS NULL OR e.note <> ap.note)
+		ORDER BY ap.id
+		LIMIT $3`
+
+	rows, err := r.db.Query(ctx, query, userID, model, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []domain.ActivityPoint
+	for rows.Next() {
+		var p domain.ActivityPoint
+		if err := rows.Scan(&p.ID, &p.UserID, &p.Note); err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return points, nil
+}
+
+// SaveNoteEmbedding stores the vector of a note, replacing the previous one.
+func (r *repository) SaveNoteEmbedding(ctx context.Context, e domain.NoteEmbedding) error {
+	query := `
+		INSERT INTO progress_note_embeddings (point_id, user_id, model, note, embedding)
+		VALUES ($1, $2, $3, $4, $5::vector)
+		ON CONFLICT (point_id) DO UPDATE
+		SET model = EXCLUDED.model, note = EXCLUDED.note, embedding = EXCLUDED.embedding, created_at = NOW()`
+
+	_, err := r.db.Exec(ctx, query, e.PointID, e.UserID, e.Model, e.Note, vectorLiteral(e.Vector))
+	return err
+}
+
+// vectorLiteral formats v in the pgvector text format, so no driver type is
+// needed.
+func vectorLiteral(v []float32) string {
+	parts := make([]string, len(v))
+	for i, x := range v {
+		parts[i] = strconv.FormatFloat(float64(x), 'f', -1, 32)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
 func (r *repository) GetTrendStats(ctx context.Context, activityID int64, userID int64, from time.Time, to time.Time) (domain.TrendStats, error) {
 	query := `
 		SELECT COUNT(*) as count,
@@ -22,6 +22,7 @@ type DB interface {
 
 	// Nutrition stats methods
 	GetLastConsumptionTime(ctx context.Context, userID int64) (*time.Time, error)
+	ListConsumptionLogs(ctx context.Context, userID int64, from time.Time, to time.Time) ([]*domain.ConsumptionLog, error)
 	GetNutritionStats(ctx context.Context, filter domain.NutritionStatsFilter) ([]domain.NutritionStats, error)
 
 	// Top products methods
@@ -42,6 +43,7 @@ type DB interface {
 	CreateWorkout(ctx context.Context, workout *domain.Workout) (int64, error)
 	CloseWorkout(ctx context.Context, workoutID int64, completedAt time.Time) error
 	ListWorkouts(ctx context.Context, userID int64) ([]domain.Workout, error)
+	ListWorkoutsPage(ctx context.Context, userID int64, after *domain.Cursor, limit int) ([]domain.Workout, error)
 	GetWorkoutByDate(ctx context.Context, userID int64, date time.Time) (*domain.Workout, error)
 
 	// Set methods
@@ -52,7 +54,8 @@ type DB interface {
 	ListSets(ctx context.Context, userID int64, from time.Time, to time.Time) ([]domain.Set, error)
 	GetExercisesByIDs(ctx context.Context, userID int64, exerciseIDs []int64) ([]domain.Exercise, error)
 	GetWorkoutsByIDs(ctx context.Context, userID int64, workoutIDs []int64) ([]domain.Workout, error)
-	GetExerciseHistory(ctx context.Context, userID int64, exerciseID int64, limit int, offset int) ([]domain.Workout, error)
+	GetExerciseHistory(ctx context.Context, userID int64, exerciseID int64, after *domain.Cursor, limit int, offset int) ([]domain.Workout, error)
+	ListSetsByWorkouts(ctx context.Context, userID int64, workoutIDs []int64) ([]domain.Set, error)
 	ListSetsByExerciseAndWorkouts(ctx context.Context, userID int64, exerciseID int64, workoutIDs []int64) ([]domain.Set, error)
 
 	// Money tracking methods
@@ -61,22 +64,98 @@ type DB interface {
 	DeleteTransaction(ctx context.Context, id int64, userID int64) error
 	SetBudget(ctx context.Context, b *domain.Budget) (int64, error)
 	GetTransactions(ctx context.Context, filter domain.TransactionFilter) ([]*domain.Transaction, int, error)
+	StreamTransactions(ctx context.Context, filter domain.TransactionFilter, batchSize int, fn func([]*domain.Transaction) error) error
+	GetTransaction(ctx context.Context, userID, id int64) (*domain.Transaction, error)
+	ReplaceTransactionSplits(ctx context.Context, userID, transactionID int64, splits []domain.TransactionSplit) error
+	ListTransactionSplits(ctx context.Context, userID int64, transactionIDs []int64) ([]domain.TransactionSplit, error)
+	AddTransactionTags(ctx context.Context, userID int64, transactionIDs []int64, names []string) (int, error)
+	RemoveTransactionTags(ctx context.Context, userID int64, transactionIDs []int64, names []string) (int, error)
+	ListTransactionTags(ctx context.Context, userID int64, transactionIDs []int64) ([]domain.TransactionTag, error)
+	ListTags(ctx context.Context, userID int64) ([]domain.TagSummary, error)
+	RenameTag(ctx context.Context, userID int64, name, newName string) (bool, error)
+	DeleteTag(ctx context.Context, userID int64, name string) (bool, error)
+	SaveSavingsGoal(ctx context.Context, g *domain.SavingsGoal) (int64, error)
+	ListSavingsGoals(ctx context.Context, userID int64) ([]domain.SavingsGoal, error)
+	GetTagSavings(ctx context.Context, userID int64, tag string, at time.Time) (float64, error)
 	GetSpendingByCategory(ctx context.Context, userID int64, from, to time.Time, depth int) ([]domain.SpendingByCategory, error)
 	GetTopMerchants(ctx context.Context, userID int64, from, to time.Time, limit int) ([]domain.MerchantSummary, error)
 	GetSpendingForPeriod(ctx context.Context, userID int64, from, to time.Time) ([]domain.SpendingByCategory, error)
 	GetBudgetProgress(ctx context.Context, userID int64, at time.Time) ([]domain.BudgetProgress, error)
 	GetBalance(ctx context.Context, userID int64, from, to time.Time) (domain.BalanceResult, error)
+	GetMonthlyCashflow(ctx context.Context, userID int64, from, to time.Time) ([]domain.MonthlyCashflow, error)
+	GetMonthlyCategorySpending(ctx context.Context, userID int64, from, to time.Time, depth int) ([]domain.MonthlyCategorySpending, error)
+	GetMonthlyAccountNets(ctx context.Context, userID int64, from, to time.Time) ([]domain.AccountMonthNet, error)
+	ListCategoryCorrections(ctx context.Context, userID int64) ([]domain.CategoryCorrection, error)
+	ListExternalIDs(ctx context.Context, userID int64, account string, ids []string) ([]string, error)
+	SaveImportProfile(ctx context.Context, p *domain.ImportProfile) (int64, error)
+	ListImportProfiles(ctx context.Context, userID int64) ([]domain.ImportProfile, error)
+	SaveAccount(ctx context.Context, a *domain.Account) (int64, error)
+	GetAccount(ctx context.Context, userID int64, name string) (*domain.Account, error)
+	GetAccountBalances(ctx context.Context, userID int64, at time.Time) ([]domain.AccountBalance, error)
+	ListUnregisteredAccounts(ctx context.Context, userID int64) ([]string, error)
+	ListAccountTransactions(ctx context.Context, userID int64, account string, from, to time.Time) ([]domain.Transaction, error)
+	ListUnpairedTransactions(ctx context.Context, userID int64, from, to time.Time) ([]domain.Transaction, error)
+	PairTransfers(ctx context.Context, userID int64, pairs []domain.TransferPair) (int, error)
+	UnpairTransfer(ctx context.Context, userID int64, id int64) error
+	ReplaceRecurringItems(ctx context.Context, userID int64, items []domain.RecurringItem) error
+	ListRecurringItems(ctx context.Context, userID int64) ([]domain.RecurringItem, error)
+	SaveRecurringBudget(ctx context.Context, rb *domain.RecurringBudget) (int64, error)
+	ListRecurringBudgets(ctx context.Context, userID int64) ([]domain.RecurringBudget, error)
+	AddBudgetPeriods(ctx context.Context, periods []domain.Budget) error
+	ListBudgetPeriods(ctx context.Context, userID, recurringBudgetID int64, to time.Time) ([]domain.BudgetProgress, error)
+	GetCategorySpending(ctx context.Context, userID int64, category string, from, to time.Time) (float64, error)
+	GetBudgetAlertSettings(ctx context.Context, userID int64) (*domain.BudgetAlertSettings, error)
+	SaveBudgetAlertSettings(ctx context.Context, s *domain.BudgetAlertSettings) error
+	GetReminderSettings(ctx context.Context, userID int64) (*domain.ReminderSettings, error)
+	SaveReminderSettings(ctx context.Context, s *domain.ReminderSettings) error
+	ListEnabledReminderSettings(ctx context.Context) ([]domain.ReminderSettings, error)
+	AddNotification(ctx context.Context, n *domain.Notification) (bool, error)
+	ListNotifications(ctx context.Context, userID int64, unreadOnly bool, limit int) ([]domain.Notification, error)
+	MarkNotificationsRead(ctx context.Context, userID int64, ids []int64) (int, error)
+	SetNotificationDelivery(ctx context.Context, id int64, deliveredAt *time.Time, deliveryError *string) error
+	ListUndeliveredNotifications(ctx context.Context, userID int64, kind string, maxAttempts int) ([]domain.Notification, error)
 
 	// Progress tracking methods
+	SaveProgressScale(ctx context.Context, scale *domain.ProgressScale) error
+	ListProgressScales(ctx context.Context, userID int64) ([]domain.ProgressScale, error)
+	DeleteProgressScale(ctx context.Context, userID, id int64) (bool, error)
+	SaveLifePart(ctx context.Context, part *domain.LifePart) (bool, error)
+	ListLifeParts(ctx context.Context, userID int64) ([]domain.LifePart, error)
+	DeleteLifePart(ctx context.Context, userID, id int64) (bool, error)
+	SaveMilestone(ctx context.Context, m *domain.Milestone) (bool, error)
+	GetMilestone(ctx context.Context, userID, id int64) (*domain.Milestone, error)
+	ListMilestones(ctx context.Context, userID int64, activityIDs []int64) ([]domain.Milestone, error)
+	DeleteMilestone(ctx context.Context, userID, id int64) (bool, error)
 	CreateActivity(ctx context.Context, activity *domain.Activity) (int64, error)
 	ListActivities(ctx context.Context, filter domain.ActivityFilter) ([]domain.Activity, error)
 	GetActivity(ctx context.Context, activityID int64, userID int64) (*domain.Activity, error)
 	UpdateActivity(ctx context.Context, activity *domain.Activity) error
 	FinishActivity(ctx context.Context, activityID int64, userID int64, endedAt time.Time) error
 	CreateProgress(ctx context.Context, progress *domain.ActivityPoint) (int64, error)
+	GetProgress(ctx context.Context, id int64, userID int64) (*domain.ActivityPoint, error)
+	UpdateProgress(ctx context.Context, progress *domain.ActivityPoint) error
+	DeleteProgress(ctx context.Context, id int64, userID int64) (bool, error)
+	CountProgressOutsideRange(ctx context.Context, userID, activityID int64, min, max int) (int, error)
 	ListProgress(ctx context.Context, filter domain.ProgressFilter) ([]domain.ActivityPoint, error)
+	ListActivitySubtrees(ctx context.Context, userID int64, rootIDs []int64) ([]domain.Activity, []domain.ActivityPoint, error)
 	GetTrendStats(ctx context.Context, activityID int64, userID int64, from time.Time, to time.Time) (domain.TrendStats, error)
 	SearchProgressNotes(ctx context.Context, filter domain.ProgressNoteSearchFilter) ([]domain.ActivityPointWithActivity, error)
+	NoteEmbeddingsAvailable(ctx context.Context) (bool, error)
+	ListNotesToEmbed(ctx context.Context, userID int64, model string, limit int) ([]domain.ActivityPoint, error)
+	SaveNoteEmbedding(ctx context.Context, e domain.NoteEmbedding) error
+}
+
+// NotificationSink delivers a notification outside the inbox.
+type NotificationSink interface {
+	Send(ctx context.Context, n domain.Notification) error
+}
+
+// Embedder turns texts into vectors for semantic note search, one vector per
+// text. Vectors of different models are not comparable, so Model names the
+// one in use.
+type Embedder interface {
+	Model() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
 }
 
 type DBMaintainer interface {
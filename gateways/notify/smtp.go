@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+
+	"personal/domain"
+)
+
+// SMTP mails notifications through a relay that accepts unauthenticated
+// mail, such as a local postfix or mailpit.
+type SMTP struct {
+	Addr string // host:port of the relay
+	From string
+	To   string
+}
+
+func (s SMTP) Send(ctx context.Context, n domain.Notification) error {
+	if s.Addr == "" {
+		return fmt.Errorf("smtp relay is not configured")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", s.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerValue(n.Title)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(n.Body)
+	msg.WriteString("\r\n")
+
+	return smtp.SendMail(s.Addr, nil, s.From, []string{s.To}, []byte(msg.String()))
+}
+
+// headerValue keeps a header on one line.
+func headerValue(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
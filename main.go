@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -14,10 +15,14 @@ import (
 	sloggin "github.com/samber/slog-gin"
 
 	"personal/action/auth"
+	export_transactions "personal/action/export_transactions"
+	money_dashboard "personal/action/money_dashboard"
 	money_import "personal/action/money_import"
 	"personal/action/progress"
+	"personal/action/reminders"
 	"personal/gateways"
 	"personal/gateways/db"
+	"personal/gateways/embed"
 	mcp2 "personal/transport/mcp"
 )
 
@@ -57,7 +62,30 @@ func main() {
 		log.Printf("Warning: Failed to apply migrations: %v", err)
 	}
 
-	server := mcp2.Server(repo)
+	// Daily check-in reminders
+	if os.Getenv("REMINDERS_DISABLED") == "" {
+		go reminders.Run(context.Background(), repo, time.Minute)
+	}
+
+	// Semantic note search with a local embedding model, when configured
+	var embedder gateways.Embedder
+	if url := os.Getenv("EMBEDDINGS_URL"); url != "" {
+		available, err := repo.NoteEmbeddingsAvailable(context.Background())
+		switch {
+		case err != nil:
+			log.Printf("Warning: semantic note search is off: %v", err)
+		case !available:
+			log.Println("Warning: EMBEDDINGS_URL is set but pgvector is not installed, semantic note search is off")
+		default:
+			model := os.Getenv("EMBEDDINGS_MODEL")
+			if model == "" {
+				model = "bge-m3"
+			}
+			embedder = embed.Ollama{URL: url, ModelName: model}
+		}
+	}
+
+	server := mcp2.Server(repo, embedder)
 
 	// Create the streamable HTTP handler.
 	handler := mcp.NewStreamableHTTPHandler(
@@ -129,6 +157,12 @@ func main() {
 	moneyImport.GET("/import", money_import.ImportGETHandler)
 	moneyImport.POST("/import", money_import.ImportPOSTHandler)
 
+	// Money dashboard — same Basic Auth as the import page
+	moneyWeb := router.Group("/web/money", money_import.BasicAuthMiddleware(importUser, importPass), dbMiddleware(repo))
+	moneyWeb.GET("", money_dashboard.DashboardGETHandler)
+	moneyWeb.POST("/recategorize", money_dashboard.RecategorizePOSTHandler)
+	moneyWeb.GET("/export", export_transactions.ExportGETHandler)
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8081"
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/progress"
+)
+
+func (s *IntegrationTestSuite) TestGetAdherenceReport_DailyActivity() {
+	ctx := s.Context()
+	startedAt := time.Now().UTC().AddDate(0, 0, -10)
+	day0 := startedAt.Truncate(24 * time.Hour)
+
+	_, act, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Meditation", ProgressType: "habit_progress", FrequencyDays: 1,
+		StartedAt: startedAt.Format(time.RFC3339),
+	})
+	require.NoError(s.T(), err)
+
+	// Days 3 and 4 are missed, today has no check-in yet.
+	for _, d := range []int{0, 1, 2, 5, 6, 7, 8, 9} {
+		_, _, err := progress.CreateProgressPoint(ctx, nil, progress.CreateProgressPointInput{
+			ActivityID: act.Activity.ID, Value: 1,
+			ProgressAt: day0.AddDate(0, 0, d).Add(12 * time.Hour).Format(time.RFC3339),
+		})
+		s.Require().NoError(err)
+	}
+
+	_, out, err := progress.GetAdherenceReport(ctx, nil, progress.GetAdherenceReportInput{ActivityID: act.Activity.ID})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Activities, 1)
+	r := out.Activities[0]
+
+	assert.Equal(s.T(), 10, r.Windows)
+	assert.Equal(s.T(), 8, r.OnTime)
+	require.NotNil(s.T(), r.Pct)
+	assert.Equal(s.T(), 80.0, *r.Pct)
+	assert.Equal(s.T(), 5, r.CurrentStreak)
+	assert.Equal(s.T(), 5, r.LongestStreak)
+
+	assert.Equal(s.T(), 2, r.MissedTotal)
+	require.Len(s.T(), r.Missed, 2)
+	assert.Equal(s.T(), day0.AddDate(0, 0, 4).Format(time.RFC3339), r.Missed[0].From)
+
+	windows := 0
+	for _, m := range r.Months {
+		windows += m.Windows
+	}
+	assert.Equal(s.T(), 10, windows)
+
+	assert.Equal(s.T(), 7, r.Gaps.Count)
+	assert.Equal(s.T(), 2.0, r.Gaps.AvgBreak)
+	assert.Equal(s.T(), 2, r.Gaps.Max)
+	assert.Equal(s.T(), 6, r.Gaps.OnTime)
+	assert.Equal(s.T(), 1, r.Gaps.VeryLate)
+
+	// Without activity_id every active activity is reported.
+	_, all, err := progress.GetAdherenceReport(ctx, nil, progress.GetAdherenceReportInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), all.Activities, 1)
+	assert.Equal(s.T(), act.Activity.ID, all.Activities[0].ActivityID)
+
+	_, _, err = progress.GetAdherenceReport(ctx, nil, progress.GetAdherenceReportInput{Days: 1000})
+	assert.ErrorContains(s.T(), err, "days must be at most 730")
+}
@@ -0,0 +1,213 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/notifications"
+	"personal/action/progress"
+	"personal/action/reminders"
+	"personal/domain"
+	"personal/util"
+)
+
+func (s *IntegrationTestSuite) TestCheckInReminders_WebhookOncePerDay() {
+	ctx := s.Context()
+	now := time.Now().UTC()
+	startedAt := now.AddDate(0, 0, -3).Format(time.RFC3339)
+
+	_, due, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Journal", ProgressType: "habit_progress", FrequencyDays: 1, StartedAt: startedAt,
+	})
+	require.NoError(s.T(), err)
+	_, weekly, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Weekly review", ProgressType: "habit_progress", FrequencyDays: 7, StartedAt: startedAt,
+	})
+	require.NoError(s.T(), err)
+	_, _, err = progress.CreateProgressPoint(ctx, nil, progress.CreateProgressPointInput{
+		ActivityID: weekly.Activity.ID, Value: 1, ProgressAt: now.Add(-time.Minute).Format(time.RFC3339),
+	})
+	require.NoError(s.T(), err)
+
+	var mu sync.Mutex
+	var received []domain.Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n domain.Notification
+		_ = json.NewDecoder(r.Body).Decode(&n)
+		mu.Lock()
+		received = append(received, n)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	_, settings, err := reminders.SetCheckInReminders(ctx, nil, reminders.SetCheckInRemindersInput{
+		Enabled:  util.Ptr(true),
+		RemindAt: util.Ptr("00:00"),
+		Channel:  util.Ptr(domain.ReminderWebhook),
+		Target:   util.Ptr(server.URL),
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), settings.Error)
+	assert.Equal(s.T(), "UTC", settings.Timezone)
+
+	_, out, err := reminders.CheckCheckInReminders(ctx, nil, reminders.CheckCheckInRemindersInput{At: &now})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Due, 1)
+	assert.Equal(s.T(), due.Activity.ID, out.Due[0].ID)
+	require.NotNil(s.T(), out.Notification)
+	assert.Equal(s.T(), domain.NotificationCheckInDue, out.Notification.Kind)
+	assert.Equal(s.T(), "Journal is due for check-in", out.Notification.Title)
+	assert.NotNil(s.T(), out.Notification.DeliveredAt)
+	mu.Lock()
+	assert.Len(s.T(), received, 1)
+	mu.Unlock()
+
+	// Already sent today.
+	_, again, err := reminders.CheckCheckInReminders(ctx, nil, reminders.CheckCheckInRemindersInput{At: &now})
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), again.Due, 1)
+	assert.Nil(s.T(), again.Notification)
+
+	_, inbox, err := notifications.ListNotifications(ctx, nil, notifications.ListNotificationsInput{UnreadOnly: true})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), inbox.Notifications, 1)
+	assert.Equal(s.T(), domain.NotificationCheckInDue, inbox.Notifications[0].Kind)
+}
+
+func (s *IntegrationTestSuite) TestCheckInReminders_RetriesFailedDelivery() {
+	ctx := s.Context()
+	now := time.Now().UTC()
+
+	_, _, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Journal", ProgressType: "habit_progress", FrequencyDays: 1,
+		StartedAt: now.AddDate(0, 0, -3).Format(time.RFC3339),
+	})
+	require.NoError(s.T(), err)
+
+	var mu sync.Mutex
+	attempts, failing := 0, true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if failing {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	_, settings, err := reminders.SetCheckInReminders(ctx, nil, reminders.SetCheckInRemindersInput{
+		Enabled:  util.Ptr(true),
+		RemindAt: util.Ptr("00:00"),
+		Channel:  util.Ptr(domain.ReminderWebhook),
+		Target:   util.Ptr(server.URL),
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), settings.Error)
+
+	_, out, err := reminders.CheckCheckInReminders(ctx, nil, reminders.CheckCheckInRemindersInput{At: &now})
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), out.Notification)
+	assert.Nil(s.T(), out.Notification.DeliveredAt)
+	require.NotNil(s.T(), out.Notification.DeliveryError)
+
+	// The next check re-sends the stored reminder instead of raising a new one.
+	mu.Lock()
+	failing = false
+	mu.Unlock()
+	_, again, err := reminders.CheckCheckInReminders(ctx, nil, reminders.CheckCheckInRemindersInput{At: &now})
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), again.Notification)
+
+	_, inbox, err := notifications.ListNotifications(ctx, nil, notifications.ListNotificationsInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), inbox.Notifications, 1)
+	assert.NotNil(s.T(), inbox.Notifications[0].DeliveredAt)
+	assert.Nil(s.T(), inbox.Notifications[0].DeliveryError)
+
+	// Delivered reminders are not sent again.
+	_, _, err = reminders.CheckCheckInReminders(ctx, nil, reminders.CheckCheckInRemindersInput{At: &now})
+	require.NoError(s.T(), err)
+	mu.Lock()
+	assert.Equal(s.T(), 2, attempts)
+	mu.Unlock()
+}
+
+func (s *IntegrationTestSuite) TestCheckInReminders_RetryCap() {
+	ctx := s.Context()
+	now := time.Now().UTC()
+
+	_, _, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Journal", ProgressType: "habit_progress", FrequencyDays: 1,
+		StartedAt: now.AddDate(0, 0, -3).Format(time.RFC3339),
+	})
+	require.NoError(s.T(), err)
+
+	var mu sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, _, err = reminders.SetCheckInReminders(ctx, nil, reminders.SetCheckInRemindersInput{
+		Enabled:  util.Ptr(true),
+		RemindAt: util.Ptr("00:00"),
+		Channel:  util.Ptr(domain.ReminderWebhook),
+		Target:   util.Ptr(server.URL),
+	})
+	require.NoError(s.T(), err)
+
+	for range 8 {
+		_, _, err = reminders.CheckCheckInReminders(ctx, nil, reminders.CheckCheckInRemindersInput{At: &now})
+		require.NoError(s.T(), err)
+	}
+	mu.Lock()
+	assert.Equal(s.T(), 5, attempts)
+	mu.Unlock()
+
+	_, inbox, err := notifications.ListNotifications(ctx, nil, notifications.ListNotificationsInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), inbox.Notifications, 1)
+	assert.Nil(s.T(), inbox.Notifications[0].DeliveredAt)
+	assert.NotNil(s.T(), inbox.Notifications[0].DeliveryError)
+}
+
+func (s *IntegrationTestSuite) TestCheckInReminders_Validation() {
+	ctx := s.Context()
+
+	_, out, err := reminders.SetCheckInReminders(ctx, nil, reminders.SetCheckInRemindersInput{
+		Timezone: util.Ptr("Mars/Olympus"),
+	})
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), out.Error, "unknown timezone")
+
+	_, out, err = reminders.SetCheckInReminders(ctx, nil, reminders.SetCheckInRemindersInput{
+		RemindAt: util.Ptr("9am"),
+	})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "remind_at must be HH:MM", out.Error)
+
+	_, out, err = reminders.SetCheckInReminders(ctx, nil, reminders.SetCheckInRemindersInput{
+		Channel: util.Ptr(domain.ReminderEmail),
+	})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "email channel needs an email address as target", out.Error)
+
+	// Nothing was saved: still the defaults.
+	_, out, err = reminders.SetCheckInReminders(ctx, nil, reminders.SetCheckInRemindersInput{})
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), out.Error)
+	assert.False(s.T(), out.Enabled)
+	assert.Equal(s.T(), "09:00", out.RemindAt)
+	assert.Equal(s.T(), domain.ReminderInbox, out.Channel)
+}
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/find_correlations"
+	"personal/action/progress"
+	"personal/action/split_transaction"
+	"personal/domain"
+	"personal/util"
+)
+
+func (s *IntegrationTestSuite) TestFindCorrelations_AlcoholLowersNextDayMood() {
+	ctx := s.Context()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	_, mood, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Mood", ProgressType: "mood", FrequencyDays: 1, StartedAt: today.AddDate(0, 0, -40).Format(time.RFC3339),
+	})
+	require.NoError(s.T(), err)
+
+	// Drinks every third day; the day after is a bad one.
+	drank := func(daysAgo int) bool { return daysAgo%3 == 0 }
+	for daysAgo := 21; daysAgo >= 1; daysAgo-- {
+		day := today.AddDate(0, 0, -daysAgo)
+		alcohol := 0.0
+		if drank(daysAgo) {
+			alcohol = 40
+		}
+		require.NoError(s.T(), s.Repo().AddConsumptionLog(ctx, &domain.ConsumptionLog{
+			UserID:     s.UserID(),
+			ConsumedAt: day.Add(20 * time.Hour),
+			FoodName:   "Dinner",
+			AmountG:    500,
+			Nutrients:  &domain.Nutrients{Calories: util.Ptr(1800 + alcohol*7), EthylAlcoholG: util.Ptr(alcohol)},
+		}))
+
+		value := 1
+		if drank(daysAgo + 1) {
+			value = -1
+		}
+		_, _, err := progress.CreateProgressPoint(ctx, nil, progress.CreateProgressPointInput{
+			ActivityID: mood.Activity.ID, Value: value, ProgressAt: day.Add(9 * time.Hour).Format(time.RFC3339),
+		})
+		require.NoError(s.T(), err)
+	}
+
+	_, out, err := find_correlations.FindCorrelations(ctx, nil, find_correlations.FindCorrelationsInput{
+		Days: 30, Metrics: []string{"alcohol", "caffeine"},
+	})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "UTC", out.Timezone)
+	require.NotEmpty(s.T(), out.Correlations)
+
+	top := out.Correlations[0]
+	assert.Equal(s.T(), "alcohol", top.Driver)
+	assert.Equal(s.T(), "Mood", top.OutcomeLabel)
+	assert.Equal(s.T(), 1, top.LagDays)
+	assert.Equal(s.T(), -1.0, top.R)
+	assert.Equal(s.T(), "negative", top.Direction)
+	assert.Equal(s.T(), "strong", top.Strength)
+	assert.Equal(s.T(), 20, top.N) // the first logged day has no day before it
+	for _, c := range out.Correlations {
+		assert.NotEqual(s.T(), "caffeine", c.Driver, "a constant series has no correlation")
+	}
+	assert.Equal(s.T(), len(out.Correlations), out.TestsRun)
+	assert.NotEmpty(s.T(), out.Caveats)
+
+	_, _, err = find_correlations.FindCorrelations(ctx, nil, find_correlations.FindCorrelationsInput{Metrics: []string{"sleep"}})
+	assert.Error(s.T(), err)
+	_, _, err = find_correlations.FindCorrelations(ctx, nil, find_correlations.FindCorrelationsInput{MaxLag: util.Ptr(8)})
+	assert.Error(s.T(), err)
+}
+
+func (s *IntegrationTestSuite) TestFindCorrelations_DiscretionarySpendUsesSplitLines() {
+	ctx := s.Context()
+	id := s.addReceipt(ctx)
+
+	_, splitOut, err := split_transaction.SplitTransaction(ctx, nil, split_transaction.SplitTransactionInput{
+		ID: id,
+		Splits: []split_transaction.SplitInput{
+			{Category: "food/groceries", Amount: 60},
+			{Category: "home/cleaning", Amount: 25},
+			{Category: "entertainment/games", Amount: 15},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), splitOut.Error)
+
+	days := int(time.Since(receiptDay).Hours()/24) + 2
+	_, out, err := find_correlations.FindCorrelations(ctx, nil, find_correlations.FindCorrelationsInput{
+		Days: days, Metrics: []string{"discretionary_spend"}, DiscretionaryCategories: []string{"entertainment", "home"},
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Series, 1)
+
+	// The parent's food/groceries category would count nothing.
+	spend := out.Series[0]
+	assert.Equal(s.T(), "discretionary_spend", spend.Key)
+	assert.Equal(s.T(), 1, spend.NonZeroDays)
+	assert.InDelta(s.T(), 40, spend.Mean*float64(spend.DaysObserved), 0.01)
+}
@@ -104,3 +104,37 @@ func (s *IntegrationTestSuite) TestGetExerciseHistory_NoHistory() {
 	require.NoError(s.T(), err)
 	assert.Empty(s.T(), output.Sessions)
 }
+
+func (s *IntegrationTestSuite) TestGetExerciseHistory_CursorPaging() {
+	ctx := s.Context()
+
+	_, ex, err := create_exercise.CreateExercise(ctx, nil, create_exercise.CreateExerciseInput{
+		Name: "Row", EquipmentType: "barbell",
+	})
+	require.NoError(s.T(), err)
+
+	for i := 0; i < 3; i++ {
+		at := time.Now().Add(-time.Duration(3-i) * 24 * time.Hour)
+		wID, err := s.Repo().CreateWorkout(ctx, &domain.Workout{UserID: s.UserID(), StartedAt: at})
+		require.NoError(s.T(), err)
+		_, err = s.Repo().CreateSet(ctx, &domain.Set{
+			UserID: s.UserID(), WorkoutID: wID, ExerciseID: ex.ID, Reps: 5, WeightKg: float64(60 + i), CreatedAt: at,
+		})
+		require.NoError(s.T(), err)
+	}
+
+	_, page1, err := get_exercise_history.GetExerciseHistory(ctx, nil, get_exercise_history.GetExerciseHistoryInput{
+		ExerciseID: ex.ID, Limit: 2,
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page1.Sessions, 2)
+	require.NotEmpty(s.T(), page1.NextCursor)
+
+	_, page2, err := get_exercise_history.GetExerciseHistory(ctx, nil, get_exercise_history.GetExerciseHistoryInput{
+		ExerciseID: ex.ID, Limit: 2, Cursor: page1.NextCursor,
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page2.Sessions, 1)
+	assert.Empty(s.T(), page2.NextCursor)
+	assert.NotEqual(s.T(), page1.Sessions[1].WorkoutID, page2.Sessions[0].WorkoutID)
+}
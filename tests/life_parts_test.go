@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/progress"
+	"personal/domain"
+	"personal/util"
+)
+
+func (s *IntegrationTestSuite) createLifePart(name string) int64 {
+	_, out, err := progress.CreateLifePart(s.Context(), nil, progress.CreateLifePartInput{Name: name})
+	s.Require().NoError(err)
+	return out.LifePart.ID
+}
+
+func (s *IntegrationTestSuite) TestLifeParts_CRUD() {
+	ctx := s.Context()
+
+	_, created, err := progress.CreateLifePart(ctx, nil, progress.CreateLifePartInput{Name: " Health ", Description: "body and sleep"})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Health", created.LifePart.Name)
+	financeID := s.createLifePart("Finances")
+
+	_, _, err = progress.CreateLifePart(ctx, nil, progress.CreateLifePartInput{Name: "health"})
+	assert.ErrorContains(s.T(), err, "already exists")
+
+	_, edited, err := progress.EditLifePart(ctx, nil, progress.EditLifePartInput{LifePartID: financeID, Name: util.Ptr("Money")})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Money", edited.LifePart.Name)
+
+	_, _, err = progress.EditLifePart(ctx, nil, progress.EditLifePartInput{LifePartID: financeID, Name: util.Ptr("HEALTH")})
+	assert.ErrorContains(s.T(), err, "already exists")
+
+	_, list, err := progress.ListLifeParts(ctx, nil, progress.ListLifePartsInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), list.LifeParts, 2)
+	assert.Equal(s.T(), "Health", list.LifeParts[0].Name)
+	assert.Equal(s.T(), "body and sleep", list.LifeParts[0].Description)
+	assert.Equal(s.T(), "Money", list.LifeParts[1].Name)
+
+	// Deleting a part unlinks it from activities.
+	_, act, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Budget review", ProgressType: "habit_progress", FrequencyDays: 7,
+		LifePartIDs: []int64{created.LifePart.ID, financeID},
+	})
+	require.NoError(s.T(), err)
+
+	_, _, err = progress.DeleteLifePart(ctx, nil, progress.DeleteLifePartInput{LifePartID: financeID})
+	require.NoError(s.T(), err)
+	_, _, err = progress.DeleteLifePart(ctx, nil, progress.DeleteLifePartInput{LifePartID: financeID})
+	assert.ErrorContains(s.T(), err, "not found")
+
+	activity, err := s.Repo().GetActivity(ctx, act.Activity.ID, s.UserID())
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []int64{created.LifePart.ID}, activity.LifePartIDs)
+
+	_, _, err = progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Orphan", ProgressType: "habit_progress", FrequencyDays: 1, LifePartIDs: []int64{financeID},
+	})
+	assert.ErrorContains(s.T(), err, "not found")
+}
+
+func (s *IntegrationTestSuite) TestGetActivityList_GroupedByArea() {
+	ctx := s.Context()
+	healthID := s.createLifePart("Health")
+	workID := s.createLifePart("Work")
+	s.createLifePart("Hobbies")
+
+	started := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	ids := map[string]int64{}
+	for name, parts := range map[string][]int64{
+		"Gym":     {healthID},
+		"Walk":    {healthID, workID},
+		"Journal": nil,
+	} {
+		_, out, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+			Name: name, ProgressType: "habit_progress", FrequencyDays: 1, LifePartIDs: parts, StartedAt: started,
+		})
+		require.NoError(s.T(), err)
+		ids[name] = out.Activity.ID
+	}
+
+	_, out, err := progress.GetActivityList(ctx, nil, progress.GetActivityListInput{ActiveOnly: true})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Areas, 3)
+
+	assert.Equal(s.T(), "Health", out.Areas[0].Name)
+	assert.ElementsMatch(s.T(), []int64{ids["Gym"], ids["Walk"]}, out.Areas[0].ActivityIDs)
+	assert.Equal(s.T(), progress.ActivityArea{LifePartID: workID, Name: "Work", ActivityIDs: []int64{ids["Walk"]}}, out.Areas[1])
+	assert.Equal(s.T(), progress.ActivityArea{Name: "unassigned", ActivityIDs: []int64{ids["Journal"]}}, out.Areas[2])
+}
+
+func (s *IntegrationTestSuite) TestGetLifeBalance() {
+	ctx := s.Context()
+	db := s.Repo()
+	healthID := s.createLifePart("Health")
+	financeID := s.createLifePart("Finances")
+	s.createLifePart("Hobbies")
+
+	now := time.Now()
+	gymID, err := db.CreateActivity(ctx, &domain.Activity{
+		UserID: s.UserID(), Name: "Gym", ProgressType: domain.ProgressTypeHabitProgress,
+		FrequencyDays: 7, LifePartIDs: []int64{healthID}, StartedAt: now.AddDate(0, 0, -28),
+	})
+	require.NoError(s.T(), err)
+	budgetID, err := db.CreateActivity(ctx, &domain.Activity{
+		UserID: s.UserID(), Name: "Budget", ProgressType: domain.ProgressTypeHabitProgress,
+		FrequencyDays: 1, LifePartIDs: []int64{financeID}, StartedAt: now.AddDate(0, 0, -28),
+	})
+	require.NoError(s.T(), err)
+
+	for i, v := range []int{2, 1, 2, 1} {
+		_, err = db.CreateProgress(ctx, &domain.ActivityPoint{ActivityID: gymID, UserID: s.UserID(), Value: v, ProgressAt: now.AddDate(0, 0, -7*i-1)})
+		require.NoError(s.T(), err)
+	}
+	_, err = db.CreateProgress(ctx, &domain.ActivityPoint{ActivityID: budgetID, UserID: s.UserID(), Value: 1, ProgressAt: now.AddDate(0, 0, -2)})
+	require.NoError(s.T(), err)
+
+	_, out, err := progress.GetLifeBalance(ctx, nil, progress.GetLifeBalanceInput{Days: 28})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Areas, 3)
+
+	finances, health, hobbies := out.Areas[0], out.Areas[1], out.Areas[2]
+	assert.Equal(s.T(), 4, health.Points)
+	assert.InDelta(s.T(), 1.5, *health.Average, 0.001)
+	assert.Equal(s.T(), domain.LifeThriving, health.Status)
+	assert.Equal(s.T(), domain.LifeNeglected, finances.Status)
+	assert.Equal(s.T(), 1, finances.Points)
+	assert.Equal(s.T(), domain.LifeNoActivities, hobbies.Status)
+
+	assert.Equal(s.T(), []string{"Health"}, out.Thriving)
+	assert.Equal(s.T(), []string{"Finances"}, out.Neglected)
+	assert.Empty(s.T(), out.Struggling)
+}
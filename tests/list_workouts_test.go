@@ -112,3 +112,34 @@ func (s *IntegrationTestSuite) TestListWorkouts_WithSets() {
 	assert.Equal(s.T(), "barbell", output.Workouts[1].Exercises[0].EquipmentType)
 	require.Len(s.T(), output.Workouts[1].Exercises[0].Sets, 3)
 }
+
+func (s *IntegrationTestSuite) TestListWorkouts_CursorPaging() {
+	ctx := s.Context()
+
+	start := time.Now().Add(-10 * 24 * time.Hour)
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		id, err := s.Repo().CreateWorkout(ctx, &domain.Workout{UserID: s.UserID(), StartedAt: start.Add(time.Duration(i) * 24 * time.Hour)})
+		require.NoError(s.T(), err)
+		ids = append(ids, id)
+	}
+
+	_, page1, err := list_workouts.ListWorkouts(ctx, nil, list_workouts.ListWorkoutsInput{Limit: 2})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page1.Workouts, 2)
+	assert.Equal(s.T(), ids[2], page1.Workouts[0].ID)
+	require.NotEmpty(s.T(), page1.NextCursor)
+
+	// A workout started meanwhile lands before the cursor and does not shift the next page.
+	_, err = s.Repo().CreateWorkout(ctx, &domain.Workout{UserID: s.UserID(), StartedAt: time.Now()})
+	require.NoError(s.T(), err)
+
+	_, page2, err := list_workouts.ListWorkouts(ctx, nil, list_workouts.ListWorkoutsInput{Limit: 2, Cursor: page1.NextCursor})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page2.Workouts, 1)
+	assert.Equal(s.T(), ids[0], page2.Workouts[0].ID)
+	assert.Empty(s.T(), page2.NextCursor)
+
+	_, _, err = list_workouts.ListWorkouts(ctx, nil, list_workouts.ListWorkoutsInput{Cursor: "garbage"})
+	assert.Error(s.T(), err)
+}
@@ -0,0 +1,219 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/account"
+	"personal/action/add_transactions"
+	"personal/action/delete_transaction"
+	"personal/action/edit_transactions"
+	"personal/action/get_transactions"
+)
+
+// revolutBalanceCSV is consistent with an opening balance of 100.00.
+const revolutBalanceCSV = `Type,Product,Started Date,Completed Date,Description,Amount,Fee,Currency,State,Balance
+TOPUP,Current,2026-04-01 08:00:00,2026-04-01 08:00:00,Salary from Employer,1000.00,0.00,EUR,COMPLETED,1100.00
+CARD_PAYMENT,Current,2026-04-02 12:00:00,2026-04-02 12:01:00,LIDL CYPRUS 0042 NICOSIA,-50.00,0.00,EUR,COMPLETED,1050.00
+CARD_PAYMENT,Current,2026-04-03 09:00:00,2026-04-03 09:05:00,Starbucks Coffee,-20.00,0.00,EUR,COMPLETED,1030.00
+`
+
+func (s *IntegrationTestSuite) saveAccount(ctx context.Context, name string, opening float64) {
+	_, out, err := account.SaveAccount(ctx, nil, account.SaveAccountInput{
+		Name:           name,
+		Type:           "checking",
+		OpeningBalance: opening,
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(out.Error)
+}
+
+func (s *IntegrationTestSuite) TestAccounts_BalanceUsesDirectionAndOpening() {
+	ctx := s.Context()
+	at := time.Date(2026, 4, 4, 9, 0, 0, 0, time.UTC)
+
+	_, addOut, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 30, Currency: "EUR", AmountEUR: 30, Account: "revolut", TransactedAt: at},
+			{Type: "income", AmountOriginal: 200, Currency: "EUR", AmountEUR: 200, Account: "REVOLUT", TransactedAt: at},
+			{Type: "transfer", Direction: "in", AmountOriginal: 50, Currency: "EUR", AmountEUR: 50, Account: "Revolut", TransactedAt: at},
+			{Type: "transfer", AmountOriginal: 20, Currency: "EUR", AmountEUR: 20, Account: "Revolut", TransactedAt: at},
+			{Type: "expense", AmountOriginal: 5, Currency: "EUR", AmountEUR: 5, Account: "Cash", TransactedAt: at},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), addOut.Error)
+	assert.Equal(s.T(), "out", addOut.Transactions[3].Direction)
+
+	s.saveAccount(ctx, "Revolut", 100)
+
+	_, out, err := account.GetAccountBalances(ctx, nil, account.GetAccountBalancesInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Accounts, 1)
+	assert.Equal(s.T(), "Revolut", out.Accounts[0].Name)
+	assert.InDelta(s.T(), 300.0, out.Accounts[0].Balance, 0.001) // 100 - 30 + 200 + 50 - 20
+	assert.Equal(s.T(), 4, out.Accounts[0].TransactionCount)
+	assert.Equal(s.T(), []string{"Cash"}, out.UnregisteredAccounts)
+
+	// Case variants were renamed to the registered spelling.
+	name := "Revolut"
+	_, listOut, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Account: &name, Limit: 10})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 4, listOut.Total)
+	for _, tx := range listOut.Transactions {
+		assert.Equal(s.T(), "Revolut", tx.Account)
+	}
+}
+
+func (s *IntegrationTestSuite) TestAccounts_BalanceSeparatesCurrencies() {
+	ctx := s.Context()
+	at := time.Date(2026, 4, 4, 9, 0, 0, 0, time.UTC)
+
+	_, addOut, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "income", AmountOriginal: 200, Currency: "EUR", AmountEUR: 200, Account: "Revolut", TransactedAt: at},
+			{Type: "income", AmountOriginal: 500, Currency: "USD", AmountEUR: 460, Account: "Revolut", TransactedAt: at},
+			{Type: "expense", AmountOriginal: 120, Currency: "USD", AmountEUR: 110, Account: "Revolut", TransactedAt: at},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), addOut.Error)
+
+	s.saveAccount(ctx, "Revolut", 100)
+
+	_, out, err := account.GetAccountBalances(ctx, nil, account.GetAccountBalancesInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Accounts, 1)
+	assert.InDelta(s.T(), 300.0, out.Accounts[0].Balance, 0.001)
+	assert.Equal(s.T(), 3, out.Accounts[0].TransactionCount)
+	require.Len(s.T(), out.Accounts[0].OtherCurrencies, 1)
+	assert.Equal(s.T(), "USD", out.Accounts[0].OtherCurrencies[0].Currency)
+	assert.InDelta(s.T(), 380.0, out.Accounts[0].OtherCurrencies[0].Balance, 0.001)
+}
+
+func (s *IntegrationTestSuite) TestAccounts_NewTransactionsUseRegisteredName() {
+	ctx := s.Context()
+	s.saveAccount(ctx, "Bank of Cyprus", 0)
+
+	_, out, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 10, Currency: "EUR", AmountEUR: 10, Account: "bank of cyprus", TransactedAt: time.Now()},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Transactions, 1)
+	assert.Equal(s.T(), "Bank of Cyprus", out.Transactions[0].Account)
+}
+
+func (s *IntegrationTestSuite) TestAccounts_EditUsesRegisteredName() {
+	ctx := s.Context()
+	s.saveAccount(ctx, "Bank of Cyprus", 0)
+
+	_, out, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 10, Currency: "EUR", AmountEUR: 10, Account: "Cash", TransactedAt: time.Now()},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Transactions, 1)
+
+	moved := "BANK OF CYPRUS"
+	_, editOut, err := edit_transactions.EditTransactions(ctx, nil, edit_transactions.EditTransactionsInput{
+		Updates: []edit_transactions.TransactionUpdate{{ID: out.Transactions[0].ID, Account: &moved}},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), editOut.Error)
+
+	name := "Bank of Cyprus"
+	_, listOut, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Account: &name, Limit: 10})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), listOut.Transactions, 1)
+	assert.Equal(s.T(), "Bank of Cyprus", listOut.Transactions[0].Account)
+}
+
+func (s *IntegrationTestSuite) TestAccounts_Reconcile() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	w := postStatement(r, "Revolut", "", "export.csv", revolutBalanceCSV)
+	require.Contains(s.T(), w.Body.String(), "imported 3")
+
+	s.saveAccount(ctx, "Revolut", 100)
+
+	_, out, err := account.ReconcileAccount(ctx, nil, account.ReconcileAccountInput{Account: "revolut"})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), out.Error)
+	assert.Equal(s.T(), "ok", out.Status)
+	assert.Equal(s.T(), 3, out.CheckpointsChecked)
+	assert.InDelta(s.T(), 1030.0, out.ComputedBalance, 0.001)
+	require.NotNil(s.T(), out.StatementBalance)
+	assert.InDelta(s.T(), 1030.0, *out.StatementBalance, 0.001)
+
+	// A lost transaction shows up as drift at the next checkpoint.
+	name := "Revolut"
+	lidl := "Lidl"
+	_, listOut, err := get_transactions.GetTransactions(ctx, nil,
+		get_transactions.GetTransactionsInput{Account: &name, Merchant: &lidl, Limit: 10})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), listOut.Transactions, 1)
+	_, delOut, err := delete_transaction.DeleteTransaction(ctx, nil,
+		delete_transaction.DeleteTransactionInput{ID: listOut.Transactions[0].ID})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), delOut.Error)
+
+	_, out, err = account.ReconcileAccount(ctx, nil, account.ReconcileAccountInput{Account: "Revolut"})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "gaps_found", out.Status)
+	require.Len(s.T(), out.Gaps, 1)
+	assert.Equal(s.T(), time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC), out.Gaps[0].Date.UTC())
+	assert.InDelta(s.T(), -50.0, out.Gaps[0].DriftSincePrev, 0.001)
+}
+
+func (s *IntegrationTestSuite) TestAccounts_ReconcileWrongOpeningBalance() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	postStatement(r, "Revolut", "", "export.csv", revolutBalanceCSV)
+	s.saveAccount(ctx, "Revolut", 0)
+
+	_, out, err := account.ReconcileAccount(ctx, nil, account.ReconcileAccountInput{Account: "Revolut"})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "gaps_found", out.Status)
+	require.Len(s.T(), out.Gaps, 1)
+	assert.Equal(s.T(), time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), out.Gaps[0].Date.UTC())
+	assert.InDelta(s.T(), 100.0, out.Gaps[0].Difference, 0.001)
+}
+
+func (s *IntegrationTestSuite) TestAccounts_Validation() {
+	ctx := s.Context()
+
+	_, out, err := account.SaveAccount(ctx, nil, account.SaveAccountInput{Name: "Wallet", Type: "crypto"})
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), out.Error, "type must be one of")
+
+	_, recOut, err := account.ReconcileAccount(ctx, nil, account.ReconcileAccountInput{Account: "Nope"})
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), recOut.Error, "not registered")
+}
+
+func (s *IntegrationTestSuite) TestAccounts_ReconcileCountsRevolutFees() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	const statement = `Type,Product,Started Date,Completed Date,Description,Amount,Fee,Currency,State,Balance
+TOPUP,Current,2026-04-01 08:00:00,2026-04-01 08:00:00,Salary from Employer,1000.00,0.00,EUR,COMPLETED,1100.00
+ATM,Current,2026-04-02 12:00:00,2026-04-02 12:01:00,Cash at ATM Nicosia,-100.00,2.00,EUR,COMPLETED,998.00
+`
+	w := postStatement(r, "Revolut", "", "export.csv", statement)
+	require.Contains(s.T(), w.Body.String(), "imported 2")
+
+	s.saveAccount(ctx, "Revolut", 100)
+
+	_, out, err := account.ReconcileAccount(ctx, nil, account.ReconcileAccountInput{Account: "Revolut"})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "ok", out.Status)
+	assert.InDelta(s.T(), 998.0, out.ComputedBalance, 0.001)
+}
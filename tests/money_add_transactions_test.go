@@ -205,3 +205,53 @@ func (s *IntegrationTestSuite) TestAddTransactions_MultiCurrency() {
 	assert.Equal(s.T(), 10.00, out.Transactions[0].AmountOriginal)
 	assert.Equal(s.T(), 9.20, out.Transactions[0].AmountEUR)
 }
+
+func (s *IntegrationTestSuite) TestGetTransactions_CursorPaging() {
+	ctx := s.Context()
+
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	var txs []add_transactions.TransactionInput
+	for i := 0; i < 5; i++ {
+		// Two rows share a timestamp, so the id breaks the tie.
+		txs = append(txs, add_transactions.TransactionInput{Type: "expense", AmountOriginal: float64(10 + i), Currency: "EUR",
+			AmountEUR: float64(10 + i), Account: "Revolut", Category: "food", TransactedAt: at.Add(time.Duration(i/2) * time.Hour)})
+	}
+	_, addOut, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{Transactions: txs})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), addOut.Error)
+
+	_, page1, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Limit: 2})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page1.Transactions, 2)
+	assert.Equal(s.T(), 5, page1.Total)
+	require.NotEmpty(s.T(), page1.NextCursor)
+
+	// A newer transaction added between pages must not repeat or skip rows.
+	_, _, err = add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{{Type: "expense", AmountOriginal: 1, Currency: "EUR", AmountEUR: 1,
+			Account: "Revolut", Category: "food", TransactedAt: at.AddDate(0, 0, 1)}},
+	})
+	require.NoError(s.T(), err)
+
+	seen := map[int64]bool{}
+	for _, t := range page1.Transactions {
+		seen[t.ID] = true
+	}
+	cursor := page1.NextCursor
+	for cursor != "" {
+		_, page, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Limit: 2, Cursor: cursor, SkipTotal: true})
+		require.NoError(s.T(), err)
+		require.Empty(s.T(), page.Error)
+		assert.Zero(s.T(), page.Total)
+		for _, t := range page.Transactions {
+			assert.False(s.T(), seen[t.ID], "transaction %d listed twice", t.ID)
+			seen[t.ID] = true
+		}
+		cursor = page.NextCursor
+	}
+	assert.Len(s.T(), seen, 5)
+
+	_, badOut, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Cursor: "not-a-cursor"})
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), badOut.Error, "cursor")
+}
@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/add_transactions"
+	"personal/action/budget_alerts"
+	"personal/action/get_budget_progress"
+	"personal/action/notifications"
+	"personal/action/set_budget"
+	"personal/domain"
+	"personal/util"
+)
+
+// addFastSpendingBudget sets a 300 EUR April food budget half spent in the
+// first ten days.
+func (s *IntegrationTestSuite) addFastSpendingBudget(ctx context.Context) {
+	_, out, err := set_budget.SetBudget(ctx, nil, set_budget.SetBudgetInput{
+		Name: "Food - April 2026", Category: "food", AmountEUR: 300,
+		StartsAt: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2026, 4, 30, 23, 59, 59, 0, time.UTC),
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(out.Error)
+
+	_, _, err = add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 90, Currency: "EUR", AmountEUR: 90, Account: "Revolut",
+				Category: "food", Merchant: "Lidl", TransactedAt: time.Date(2026, 4, 2, 10, 0, 0, 0, time.UTC)},
+			{Type: "expense", AmountOriginal: 60, Currency: "EUR", AmountEUR: 60, Account: "Revolut",
+				Category: "food/restaurant", Merchant: "Zuma", TransactedAt: time.Date(2026, 4, 9, 20, 0, 0, 0, time.UTC)},
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *IntegrationTestSuite) TestBudgetForecast_Linear() {
+	ctx := s.Context()
+	s.addFastSpendingBudget(ctx)
+
+	_, out, err := get_budget_progress.GetBudgetProgress(ctx, nil, get_budget_progress.GetBudgetProgressInput{
+		At: time.Date(2026, 4, 11, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Budgets, 1)
+
+	f := out.Budgets[0].Forecast
+	assert.Equal(s.T(), "linear", f.Curve)
+	assert.InDelta(s.T(), 15, f.DailyBurnEUR, 0.01)
+	assert.InDelta(s.T(), 100, f.ExpectedSpentEUR, 0.1)
+	assert.InDelta(s.T(), 450, f.ProjectedSpendEUR, 0.1)
+	assert.InDelta(s.T(), -150, f.ProjectedRemainingEUR, 0.1)
+	assert.False(s.T(), f.OnPace)
+	require.NotNil(s.T(), f.DaysUntilExhausted)
+	assert.InDelta(s.T(), 10, *f.DaysUntilExhausted, 0.01)
+}
+
+func (s *IntegrationTestSuite) TestBudgetForecast_SeasonalCurve() {
+	ctx := s.Context()
+
+	_, setOut, err := set_budget.SetBudget(ctx, nil, set_budget.SetBudgetInput{
+		Name: "Housing", Category: "housing", AmountEUR: 1000, Period: "monthly",
+		StartsAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), setOut.Error)
+
+	// Rent is paid on the 1st, so the month is mostly spent on day one.
+	var txs []add_transactions.TransactionInput
+	for month := time.January; month <= time.March; month++ {
+		txs = append(txs, add_transactions.TransactionInput{Type: "expense", AmountOriginal: 900, Currency: "EUR", AmountEUR: 900,
+			Account: "Bank of Cyprus", Category: "housing/rent", Merchant: "Landlord", TransactedAt: time.Date(2026, month, 1, 9, 0, 0, 0, time.UTC)})
+	}
+	txs = append(txs, add_transactions.TransactionInput{Type: "expense", AmountOriginal: 60, Currency: "EUR", AmountEUR: 60,
+		Account: "Bank of Cyprus", Category: "housing/utilities", Merchant: "EAC", TransactedAt: time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)})
+	_, _, err = add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{Transactions: txs})
+	require.NoError(s.T(), err)
+
+	_, out, err := get_budget_progress.GetBudgetProgress(ctx, nil, get_budget_progress.GetBudgetProgressInput{
+		At: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Budgets, 1)
+
+	f := out.Budgets[0].Forecast
+	assert.Equal(s.T(), "seasonal", f.Curve)
+	assert.True(s.T(), f.OnPace)
+	// January: 900 of 960 by the 5th; February: 900 of 900.
+	assert.InDelta(s.T(), (900.0/960+1)/2*1000, f.ExpectedSpentEUR, 0.1)
+	assert.InDelta(s.T(), 900/((900.0/960+1)/2), f.ProjectedSpendEUR, 0.1)
+}
+
+func (s *IntegrationTestSuite) TestBudgetAlerts_WebhookAndInbox() {
+	ctx := s.Context()
+	s.addFastSpendingBudget(ctx)
+
+	var mu sync.Mutex
+	var received []domain.Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n domain.Notification
+		_ = json.NewDecoder(r.Body).Decode(&n)
+		mu.Lock()
+		received = append(received, n)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	_, settings, err := budget_alerts.SetBudgetAlerts(ctx, nil, budget_alerts.SetBudgetAlertsInput{
+		Thresholds: []int{80, 50, 50},
+		WebhookURL: util.Ptr(server.URL),
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), settings.Error)
+	assert.Equal(s.T(), []int{50, 80}, settings.Thresholds)
+	assert.True(s.T(), settings.ForecastAlerts)
+
+	at := time.Date(2026, 4, 11, 0, 0, 0, 0, time.UTC)
+	_, out, err := budget_alerts.CheckBudgetAlerts(ctx, nil, budget_alerts.CheckBudgetAlertsInput{At: &at})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Notifications, 2)
+	kinds := []string{out.Notifications[0].Kind, out.Notifications[1].Kind}
+	assert.ElementsMatch(s.T(), []string{domain.NotificationBudgetThreshold, domain.NotificationBudgetForecast}, kinds)
+	for _, n := range out.Notifications {
+		assert.NotNil(s.T(), n.DeliveredAt)
+	}
+	mu.Lock()
+	assert.Len(s.T(), received, 2)
+	mu.Unlock()
+
+	// Already raised for this period.
+	_, again, err := budget_alerts.CheckBudgetAlerts(ctx, nil, budget_alerts.CheckBudgetAlertsInput{At: &at})
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), again.Notifications)
+
+	_, inbox, err := notifications.ListNotifications(ctx, nil, notifications.ListNotificationsInput{UnreadOnly: true})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), inbox.Notifications, 2)
+
+	_, marked, err := notifications.MarkNotificationsRead(ctx, nil, notifications.MarkNotificationsReadInput{
+		IDs: []int64{inbox.Notifications[0].ID},
+	})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, marked.MarkedCount)
+
+	_, inbox, err = notifications.ListNotifications(ctx, nil, notifications.ListNotificationsInput{UnreadOnly: true})
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), inbox.Notifications, 1)
+}
+
+func (s *IntegrationTestSuite) TestBudgetAlerts_FailedWebhookKeepsInbox() {
+	ctx := s.Context()
+	s.addFastSpendingBudget(ctx)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, settings, err := budget_alerts.SetBudgetAlerts(ctx, nil, budget_alerts.SetBudgetAlertsInput{
+		ForecastAlerts: util.Ptr(false),
+		WebhookURL:     util.Ptr(server.URL),
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), settings.Error)
+
+	at := time.Date(2026, 4, 11, 0, 0, 0, 0, time.UTC)
+	_, out, err := budget_alerts.CheckBudgetAlerts(ctx, nil, budget_alerts.CheckBudgetAlertsInput{At: &at})
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), out.Notifications, "50% used is below the default 80% threshold")
+
+	_, _, err = add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 100, Currency: "EUR", AmountEUR: 100, Account: "Revolut",
+				Category: "food", Merchant: "Lidl", TransactedAt: time.Date(2026, 4, 10, 10, 0, 0, 0, time.UTC)},
+		},
+	})
+	require.NoError(s.T(), err)
+
+	_, out, err = budget_alerts.CheckBudgetAlerts(ctx, nil, budget_alerts.CheckBudgetAlertsInput{At: &at})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Notifications, 1)
+	assert.Nil(s.T(), out.Notifications[0].DeliveredAt)
+	require.NotNil(s.T(), out.Notifications[0].DeliveryError)
+	assert.Contains(s.T(), *out.Notifications[0].DeliveryError, "502")
+
+	_, inbox, err := notifications.ListNotifications(ctx, nil, notifications.ListNotificationsInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), inbox.Notifications, 1)
+	assert.NotNil(s.T(), inbox.Notifications[0].DeliveryError)
+
+	_, bad, err := budget_alerts.SetBudgetAlerts(ctx, nil, budget_alerts.SetBudgetAlertsInput{WebhookURL: util.Ptr("ftp://example.com")})
+	require.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), bad.Error)
+}
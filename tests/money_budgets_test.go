@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/add_transactions"
+	"personal/action/get_budget_progress"
+	"personal/action/set_budget"
+)
+
+func (s *IntegrationTestSuite) TestRecurringBudget_Rollover() {
+	ctx := s.Context()
+
+	for _, in := range []set_budget.SetBudgetInput{
+		{Name: "Food", Category: "food", AmountEUR: 300, Period: "monthly", Rollover: true,
+			StartsAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "Transport", Category: "transport", AmountEUR: 100, Period: "monthly",
+			StartsAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		_, out, err := set_budget.SetBudget(ctx, nil, in)
+		require.NoError(s.T(), err)
+		require.Empty(s.T(), out.Error)
+		assert.Equal(s.T(), "monthly", out.Budget.Period)
+	}
+
+	expense := func(category string, amount float64, at time.Time) add_transactions.TransactionInput {
+		return add_transactions.TransactionInput{Type: "expense", AmountOriginal: amount, Currency: "EUR", AmountEUR: amount,
+			Account: "Revolut", Category: category, Merchant: "Shop", TransactedAt: at}
+	}
+	_, _, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			expense("food", 200, time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)),
+			expense("food/restaurant", 450, time.Date(2026, 2, 28, 23, 59, 59, 0, time.UTC)),
+			expense("food", 100, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)),
+			expense("transport", 150, time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)),
+		},
+	})
+	require.NoError(s.T(), err)
+
+	_, out, err := get_budget_progress.GetBudgetProgress(ctx, nil, get_budget_progress.GetBudgetProgressInput{
+		At: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Budgets, 2)
+
+	budgets := map[string]get_budget_progress.BudgetProgressRow{}
+	for _, b := range out.Budgets {
+		budgets[b.Name] = b
+	}
+
+	food := budgets["Food"]
+	assert.Equal(s.T(), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), food.StartsAt.UTC())
+	assert.InDelta(s.T(), 100, food.SpentEUR, 0.01)
+	assert.InDelta(s.T(), -50, food.CarriedOverEUR, 0.01)
+	assert.InDelta(s.T(), 150, food.RemainingEUR, 0.01)
+	require.Len(s.T(), food.History, 2)
+	assert.Equal(s.T(), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), food.History[0].StartsAt.UTC())
+	assert.InDelta(s.T(), 100, food.History[0].CarriedOverEUR, 0.01)
+	assert.InDelta(s.T(), 450, food.History[0].SpentEUR, 0.01)
+	assert.InDelta(s.T(), -50, food.History[0].RemainingEUR, 0.01)
+	assert.InDelta(s.T(), 100, food.History[1].RemainingEUR, 0.01)
+
+	transport := budgets["Transport"]
+	assert.Zero(s.T(), transport.CarriedOverEUR)
+	assert.InDelta(s.T(), 100, transport.RemainingEUR, 0.01)
+	require.Len(s.T(), transport.History, 2)
+	assert.InDelta(s.T(), -50, transport.History[0].RemainingEUR, 0.01)
+
+	_, limited, err := get_budget_progress.GetBudgetProgress(ctx, nil, get_budget_progress.GetBudgetProgressInput{
+		At: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), History: 1,
+	})
+	require.NoError(s.T(), err)
+	for _, b := range limited.Budgets {
+		assert.Len(s.T(), b.History, 1)
+	}
+}
+
+func (s *IntegrationTestSuite) TestRecurringBudget_NewStartRebuildsHistory() {
+	ctx := s.Context()
+
+	setFood := func(startsAt time.Time) {
+		_, out, err := set_budget.SetBudget(ctx, nil, set_budget.SetBudgetInput{
+			Name: "Food", Category: "food", AmountEUR: 300, Period: "monthly", Rollover: true, StartsAt: startsAt,
+		})
+		require.NoError(s.T(), err)
+		require.Empty(s.T(), out.Error)
+	}
+
+	setFood(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	_, _, err := get_budget_progress.GetBudgetProgress(ctx, nil, get_budget_progress.GetBudgetProgressInput{
+		At: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(s.T(), err)
+
+	// Periods starting on the 1st are finished; moving the start to the
+	// 15th must not leave them overlapping the new ones.
+	setFood(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	_, out, err := get_budget_progress.GetBudgetProgress(ctx, nil, get_budget_progress.GetBudgetProgressInput{
+		At: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Budgets, 1)
+
+	food := out.Budgets[0]
+	assert.Equal(s.T(), time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), food.StartsAt.UTC())
+	require.Len(s.T(), food.History, 2)
+	assert.Equal(s.T(), time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC), food.History[0].StartsAt.UTC())
+	assert.Equal(s.T(), time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), food.History[1].StartsAt.UTC())
+	assert.InDelta(s.T(), 600, food.CarriedOverEUR, 0.01)
+}
+
+func (s *IntegrationTestSuite) TestRecurringBudget_PeriodNameTaken() {
+	ctx := s.Context()
+
+	// A one-off budget already uses the name of the February period.
+	_, out, err := set_budget.SetBudget(ctx, nil, set_budget.SetBudgetInput{
+		Name: "Food 2026-02-01", Category: "food", AmountEUR: 50,
+		StartsAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), out.Error)
+	_, recurring, err := set_budget.SetBudget(ctx, nil, set_budget.SetBudgetInput{
+		Name: "Food", Category: "food", AmountEUR: 300, Period: "monthly",
+		StartsAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), recurring.Error)
+
+	at := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	_, _, err = get_budget_progress.GetBudgetProgress(ctx, nil, get_budget_progress.GetBudgetProgressInput{At: at})
+	assert.ErrorContains(s.T(), err, `budget "Food 2026-02-01" already exists`)
+
+	// Nothing of the batch was stored, not even the January period.
+	periods, err := s.Repo().ListBudgetPeriods(ctx, s.UserID(), recurring.ID, at)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), periods)
+}
+
+func (s *IntegrationTestSuite) TestRecurringBudget_Validation() {
+	ctx := s.Context()
+
+	tests := []struct {
+		name  string
+		input set_budget.SetBudgetInput
+		error string
+	}{
+		{"unknown period", set_budget.SetBudgetInput{Name: "Food", Category: "food", AmountEUR: 100, Period: "daily"}, "period must be"},
+		{"rollover without period", set_budget.SetBudgetInput{Name: "Food", Category: "food", AmountEUR: 100, Rollover: true,
+			StartsAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}, "rollover requires period"},
+		{"month end start", set_budget.SetBudgetInput{Name: "Food", Category: "food", AmountEUR: 100, Period: "monthly",
+			StartsAt: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)}, "day 1-28"},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			_, out, err := set_budget.SetBudget(ctx, nil, tt.input)
+			require.NoError(t, err)
+			assert.Contains(t, out.Error, tt.error)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/add_transactions"
+	"personal/action/get_cashflow_report"
+	"personal/util"
+)
+
+func (s *IntegrationTestSuite) TestCashflowReport() {
+	ctx := s.Context()
+	s.saveAccount(ctx, "Revolut", 1000)
+	s.saveAccount(ctx, "Bank of Cyprus", 500)
+
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 12, 0, 0, 0, time.UTC) }
+	_, _, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 20, Currency: "EUR", AmountEUR: 20, Account: "Revolut",
+				Category: "food", TransactedAt: day(time.January, 28)},
+			{Type: "income", AmountOriginal: 2000, Currency: "EUR", AmountEUR: 2000, Account: "Bank of Cyprus",
+				Category: "salary", TransactedAt: day(time.February, 1)},
+			{Type: "expense", AmountOriginal: 300, Currency: "EUR", AmountEUR: 300, Account: "Revolut",
+				Category: "food/groceries", TransactedAt: day(time.February, 3)},
+			// A USD pocket top-up is not part of the EUR account balance.
+			{Type: "transfer", Direction: "in", AmountOriginal: 500, Currency: "USD", AmountEUR: 460, Account: "Revolut",
+				TransactedAt: day(time.February, 4)},
+			{Type: "expense", AmountOriginal: 200, Currency: "EUR", AmountEUR: 200, Account: "Bank of Cyprus",
+				Category: "housing/utilities", TransactedAt: day(time.February, 20)},
+			{Type: "expense", AmountOriginal: 100, Currency: "EUR", AmountEUR: 100, Account: "Revolut",
+				Category: "food/cafe", TransactedAt: day(time.March, 2)},
+			{Type: "expense", AmountOriginal: 50, Currency: "EUR", AmountEUR: 50, Account: "Revolut",
+				Category: "transport", TransactedAt: day(time.March, 30)},
+			{Type: "transfer", AmountOriginal: 400, Currency: "EUR", AmountEUR: 400, Account: "Bank of Cyprus",
+				TransactedAt: day(time.March, 10)},
+			{Type: "transfer", Direction: "in", AmountOriginal: 400, Currency: "EUR", AmountEUR: 400, Account: "Revolut",
+				TransactedAt: day(time.March, 10)},
+		},
+	})
+	require.NoError(s.T(), err)
+
+	_, out, err := get_cashflow_report.GetCashflowReport(ctx, nil, get_cashflow_report.GetCashflowReportInput{
+		From: util.Ptr(day(time.February, 10)),
+		To:   util.Ptr(day(time.March, 5)),
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), out.Error)
+
+	assert.Equal(s.T(), []string{"2026-02", "2026-03"}, out.Months)
+	require.Len(s.T(), out.Cashflow, 2)
+	assert.Equal(s.T(), get_cashflow_report.MonthOutput{
+		Month: "2026-02", IncomeEUR: 2000, ExpenseEUR: 500, NetEUR: 1500, SavingsRatePct: util.Ptr(75.0),
+	}, out.Cashflow[0])
+	assert.Equal(s.T(), get_cashflow_report.MonthOutput{Month: "2026-03", ExpenseEUR: 150, NetEUR: -150}, out.Cashflow[1],
+		"the whole last month is covered and transfers are not income")
+	assert.Equal(s.T(), get_cashflow_report.TotalsOutput{
+		IncomeEUR: 2000, ExpenseEUR: 650, NetEUR: 1350, SavingsRatePct: util.Ptr(67.5),
+	}, out.Totals)
+
+	balances := map[string][]float64{}
+	for _, nw := range out.NetWorth {
+		balances[nw.Account] = nw.Balances
+	}
+	assert.Equal(s.T(), map[string][]float64{
+		"Revolut":        {680, 930},
+		"Bank of Cyprus": {2300, 1900},
+	}, balances)
+	require.Len(s.T(), out.NetWorthTotals, 1)
+	assert.Equal(s.T(), []float64{2980, 2830}, out.NetWorthTotals[0].Balances)
+
+	assert.Equal(s.T(), []get_cashflow_report.CategoryRowOutput{
+		{Category: "food", MonthsEUR: []float64{300, 100}, TotalEUR: 400},
+		{Category: "housing", MonthsEUR: []float64{200, 0}, TotalEUR: 200},
+		{Category: "transport", MonthsEUR: []float64{0, 50}, TotalEUR: 50},
+	}, out.CategoryMatrix)
+
+	_, bad, err := get_cashflow_report.GetCashflowReport(ctx, nil, get_cashflow_report.GetCashflowReportInput{
+		From: util.Ptr(day(time.January, 1).AddDate(-6, 0, 0)),
+		To:   util.Ptr(day(time.March, 1)),
+	})
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), bad.Error, "max 60")
+}
@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/add_transactions"
+	"personal/action/edit_transactions"
+	"personal/action/get_transactions"
+	"personal/action/suggest_categories"
+)
+
+const unknownMerchantCSV = `Type,Product,Started Date,Completed Date,Description,Amount,Fee,Currency,State,Balance
+CARD_PAYMENT,Current,2026-04-01 09:00:00,2026-04-01 09:05:00,ACME BISTRO LIMASSOL 0042,-25.00,0.00,EUR,COMPLETED,975.00
+`
+
+const unknownMerchantRepeatCSV = `Type,Product,Started Date,Completed Date,Description,Amount,Fee,Currency,State,Balance
+CARD_PAYMENT,Current,2026-05-01 09:00:00,2026-05-01 09:05:00,ACME BISTRO LIMASSOL 0077,-31.00,0.00,EUR,COMPLETED,944.00
+`
+
+// insertTestTransactionWithDescription inserts an expense that carries a raw
+// bank description, like imported rows do.
+func (s *IntegrationTestSuite) insertTestTransactionWithDescription(
+	ctx context.Context,
+	category, merchant, description string,
+	at time.Time,
+) int64 {
+	_, out, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{
+				Type:                "expense",
+				AmountOriginal:      40,
+				Currency:            "EUR",
+				AmountEUR:           40,
+				Account:             "Revolut",
+				Category:            category,
+				Merchant:            merchant,
+				OriginalDescription: &description,
+				TransactedAt:        at,
+			},
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(out.Transactions, 1)
+	return out.Transactions[0].ID
+}
+
+func (s *IntegrationTestSuite) TestCategoryLearning_CorrectionAppliedOnNextImport() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	importCSV := func(csv string) string {
+		body, ct := multipartCSV("Revolut", csv)
+		req := httptest.NewRequest(http.MethodPost, "/money/import", body)
+		req.Header.Set("Content-Type", ct)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(s.T(), http.StatusOK, w.Code)
+		return w.Body.String()
+	}
+
+	importCSV(unknownMerchantCSV)
+
+	uncategorized := "uncategorized"
+	_, listOut, err := get_transactions.GetTransactions(ctx, nil,
+		get_transactions.GetTransactionsInput{Category: &uncategorized, Limit: 10})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), listOut.Transactions, 1)
+
+	newCategory := "food/restaurant"
+	_, editOut, err := edit_transactions.EditTransactions(ctx, nil, edit_transactions.EditTransactionsInput{
+		Updates: []edit_transactions.TransactionUpdate{
+			{ID: listOut.Transactions[0].ID, Category: &newCategory},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), editOut.Error)
+
+	body := importCSV(unknownMerchantRepeatCSV)
+	assert.Contains(s.T(), body, "categorized from corrections 1")
+
+	_, restaurantOut, err := get_transactions.GetTransactions(ctx, nil,
+		get_transactions.GetTransactionsInput{Category: &newCategory, Limit: 10})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, restaurantOut.Total)
+}
+
+func (s *IntegrationTestSuite) TestSuggestCategories() {
+	ctx := s.Context()
+
+	at := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+	corrected := s.insertTestTransactionWithDescription(ctx, "uncategorized", "Petrolina", "PETROLINA STATION 12 LARNACA", at)
+	pending := s.insertTestTransactionWithDescription(ctx, "uncategorized", "Petrolina Nicosia", "PETROLINA STATION 31 NICOSIA", at.AddDate(0, 0, 1))
+	unknown := s.insertTestTransactionWithDescription(ctx, "uncategorized", "Foo", "FOO BAR QUX", at.AddDate(0, 0, 2))
+	sameCity := s.insertTestTransactionWithDescription(ctx, "uncategorized", "Periptero", "KIOSK PERIPTERO LARNACA", at.AddDate(0, 0, 3))
+
+	fuel := "transport/fuel"
+	_, editOut, err := edit_transactions.EditTransactions(ctx, nil, edit_transactions.EditTransactionsInput{
+		Updates: []edit_transactions.TransactionUpdate{{ID: corrected, Category: &fuel}},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), editOut.Error)
+
+	tests := []struct {
+		name           string
+		txID           int64
+		wantSuggestion bool
+		wantSource     string
+	}{
+		{name: "description tokens match a corrected transaction", txID: pending, wantSuggestion: true, wantSource: "description"},
+		{name: "no signal for unseen description", txID: unknown, wantSuggestion: false},
+		{name: "single learned category does not capture a merchant sharing only the city", txID: sameCity, wantSuggestion: false},
+	}
+
+	_, out, err := suggest_categories.SuggestCategories(ctx, nil, suggest_categories.SuggestCategoriesInput{})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 3, out.Inspected)
+
+	byID := map[int64]suggest_categories.SuggestionRow{}
+	for _, row := range out.Suggestions {
+		byID[row.TransactionID] = row
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			row, ok := byID[tt.txID]
+			assert.Equal(t, tt.wantSuggestion, ok)
+			if !tt.wantSuggestion {
+				return
+			}
+			assert.Equal(t, fuel, row.SuggestedCategory)
+			assert.Equal(t, tt.wantSource, row.Source)
+			assert.Greater(t, row.Confidence, 0.5)
+			assert.LessOrEqual(t, row.Confidence, 1.0)
+		})
+	}
+}
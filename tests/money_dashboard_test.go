@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/add_transactions"
+	"personal/action/export_transactions"
+	"personal/action/get_transactions"
+	"personal/action/money_dashboard"
+	"personal/gateways"
+)
+
+func (s *IntegrationTestSuite) moneyDashboardRouter(ctx context.Context) *gin.Engine {
+	userID := gateways.UserIDFromContext(ctx)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		reqCtx := gateways.WithDB(c.Request.Context(), s.Repo())
+		reqCtx = gateways.WithUserID(reqCtx, userID)
+		c.Request = c.Request.WithContext(reqCtx)
+		c.Next()
+	})
+	r.GET("/web/money", money_dashboard.DashboardGETHandler)
+	r.POST("/web/money/recategorize", money_dashboard.RecategorizePOSTHandler)
+	r.GET("/web/money/export", export_transactions.ExportGETHandler)
+	return r
+}
+
+func (s *IntegrationTestSuite) TestMoneyDashboard_Rendering() {
+	ctx := s.Context()
+	s.addFastSpendingBudget(ctx)
+	_, _, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 100, Currency: "EUR", AmountEUR: 100, Account: "Revolut",
+				Category: "food", Merchant: "Lidl", TransactedAt: time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)},
+			// Outside the compared days of last month.
+			{Type: "expense", AmountOriginal: 500, Currency: "EUR", AmountEUR: 500, Account: "Revolut",
+				Category: "travel", Merchant: "Vueling", TransactedAt: time.Date(2026, 3, 25, 10, 0, 0, 0, time.UTC)},
+		},
+	})
+	require.NoError(s.T(), err)
+
+	r := s.moneyDashboardRouter(ctx)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/web/money?date=2026-04-10", nil))
+
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.Contains(s.T(), body, "<title>Money Dashboard</title>")
+	assert.Contains(s.T(), body, "Spent · April 2026 to date")
+	assert.Contains(s.T(), body, "€150.00")
+	assert.Contains(s.T(), body, "last month same days: <strong>€100.00</strong>")
+	assert.Contains(s.T(), body, "+50.00 (+50%)")
+	assert.Contains(s.T(), body, "Food - April 2026")
+	assert.Contains(s.T(), body, "€150.00 / €300.00")
+	assert.Contains(s.T(), body, "Zuma")
+	assert.Contains(s.T(), body, `name="category" value="food/restaurant"`)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/web/money?date=April", nil))
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *IntegrationTestSuite) TestMoneyDashboard_Recategorize() {
+	ctx := s.Context()
+	s.addFastSpendingBudget(ctx)
+
+	merchant := "Zuma"
+	_, list, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Merchant: &merchant})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), list.Transactions, 1)
+	id := list.Transactions[0].ID
+
+	post := func(form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/web/money/recategorize", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		s.moneyDashboardRouter(ctx).ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(url.Values{"id": {strconv.FormatInt(id, 10)}, "category": {" dining/restaurant "}})
+	require.Equal(s.T(), http.StatusSeeOther, w.Code)
+	assert.True(s.T(), strings.HasPrefix(w.Header().Get("Location"), "/web/money?message="))
+
+	_, list, err = get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Merchant: &merchant})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "dining/restaurant", list.Transactions[0].Category)
+
+	w = post(url.Values{"id": {strconv.FormatInt(id, 10)}})
+	require.Equal(s.T(), http.StatusSeeOther, w.Code)
+	assert.Contains(s.T(), w.Header().Get("Location"), "required")
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/account"
+	"personal/action/add_transactions"
+	"personal/action/export_transactions"
+	"personal/action/split_transaction"
+	"personal/action/tags"
+	"personal/action/transfer_pairs"
+	"personal/util"
+)
+
+func (s *IntegrationTestSuite) TestExportTransactions_LedgerAndBeancount() {
+	ctx := s.Context()
+	id := s.addReceipt(ctx)
+
+	_, splitOut, err := split_transaction.SplitTransaction(ctx, nil, split_transaction.SplitTransactionInput{
+		ID: id,
+		Splits: []split_transaction.SplitInput{
+			{Category: "food/groceries", Amount: 60},
+			{Category: "home/cleaning", Amount: 40},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), splitOut.Error)
+	_, tagOut, err := tags.TagTransactions(ctx, nil, tags.TagTransactionsInput{IDs: []int64{id}, Add: []string{"weekly shop"}})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), tagOut.Error)
+
+	_, accOut, err := account.SaveAccount(ctx, nil, account.SaveAccountInput{Name: "Amex", Currency: "USD", Type: "credit"})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), accOut.Error)
+	_, addOut, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 50, Currency: "USD", AmountEUR: 40, Account: "Amex",
+				Category: "shopping", Merchant: `Target "US"`, Note: util.Ptr("socks"), TransactedAt: receiptDay.AddDate(0, 0, 1)},
+			{Type: "income", AmountOriginal: 2000, Currency: "EUR", AmountEUR: 2000, Account: "Revolut",
+				Category: "salary", Merchant: "ACME", TransactedAt: receiptDay.AddDate(0, 0, 2)},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), addOut.Error)
+
+	_, ledgerOut, err := export_transactions.ExportTransactions(ctx, nil, export_transactions.ExportTransactionsInput{Format: "ledger"})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), ledgerOut.Error)
+	assert.Equal(s.T(), 3, ledgerOut.Count)
+	journal := strings.Join(strings.Fields(ledgerOut.Content), " ")
+	assert.Contains(s.T(), journal, "2026-04-12 * Lidl ; id:")
+	assert.Contains(s.T(), journal, "; :weekly-shop: Assets:Revolut -100.00 EUR Expenses:Food:Groceries 60.00 EUR Expenses:Home:Cleaning 40.00 EUR")
+	assert.Contains(s.T(), journal, "; socks Liabilities:Amex -50.00 USD @@ 40.00 EUR Expenses:Shopping 40.00 EUR")
+	assert.Contains(s.T(), journal, "Assets:Revolut 2000.00 EUR Income:Salary -2000.00 EUR")
+	// Oldest first.
+	assert.Less(s.T(), strings.Index(journal, "Lidl"), strings.Index(journal, "ACME"))
+
+	_, beanOut, err := export_transactions.ExportTransactions(ctx, nil, export_transactions.ExportTransactionsInput{
+		Format: "beancount", Account: util.Ptr("amex"),
+	})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, beanOut.Count)
+	assert.Contains(s.T(), beanOut.Content, `plugin "beancount.plugins.auto_accounts"`)
+	assert.Contains(s.T(), beanOut.Content, `2026-04-13 * "Target \"US\"" "socks"`)
+	assert.Contains(s.T(), beanOut.Content, `category: "shopping"`)
+
+	_, badOut, err := export_transactions.ExportTransactions(ctx, nil, export_transactions.ExportTransactionsInput{Format: "qif"})
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), badOut.Error, "format must be one of")
+}
+
+func (s *IntegrationTestSuite) TestExportTransactions_PairedTransferIsNotPnL() {
+	ctx := s.Context()
+	s.addTopUpScenario(ctx)
+
+	from := transferDay.AddDate(0, 0, -1)
+	to := transferDay.AddDate(0, 0, 5)
+	_, pairOut, err := transfer_pairs.PairTransfers(ctx, nil, transfer_pairs.PairTransfersInput{From: &from, To: &to})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, pairOut.PairedCount)
+
+	_, ledgerOut, err := export_transactions.ExportTransactions(ctx, nil, export_transactions.ExportTransactionsInput{Format: "ledger"})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), ledgerOut.Error)
+	journal := strings.Join(strings.Fields(ledgerOut.Content), " ")
+	assert.Contains(s.T(), journal, "Assets:Transfers 200.00 EUR")
+	assert.Contains(s.T(), journal, "Assets:Transfers -200.00 EUR")
+	assert.NotContains(s.T(), journal, "Expenses:Shopping")
+	assert.NotContains(s.T(), journal, "Income:Income ")
+	assert.Contains(s.T(), journal, "Expenses:Food:Restaurant 49.99 EUR")
+}
+
+func (s *IntegrationTestSuite) TestExportTransactions_CSVDownloadWithoutLimit() {
+	ctx := s.Context()
+	day := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+
+	var txs []add_transactions.TransactionInput
+	for i := 0; i < 250; i++ {
+		txs = append(txs, add_transactions.TransactionInput{
+			Type: "expense", AmountOriginal: 3.5, Currency: "EUR", AmountEUR: 3.5, Account: "Revolut",
+			Category: "food/cafe", Merchant: "Costa", TransactedAt: day.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	txs = append(txs, add_transactions.TransactionInput{
+		Type: "expense", AmountOriginal: 20, Currency: "EUR", AmountEUR: 20, Account: "Revolut",
+		Category: "food/cafe", Merchant: "Costa", TransactedAt: day.AddDate(0, 1, 0),
+	})
+	_, addOut, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{Transactions: txs})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), addOut.Error)
+
+	r := s.moneyDashboardRouter(ctx)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/web/money/export?format=csv&from=2026-02-01&to=2026-02-28", nil))
+
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(s.T(), w.Header().Get("Content-Disposition"), "transactions.csv")
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), rows, 251)
+	assert.Equal(s.T(), []string{"id", "date", "type", "direction", "account", "amount", "currency", "amount_eur"}, rows[0][:8])
+	assert.Equal(s.T(), "-3.50", rows[1][5])
+	assert.Equal(s.T(), "food/cafe", rows[1][8])
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/web/money/export?format=csv&from=yesterday", nil))
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
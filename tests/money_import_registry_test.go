@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/get_transactions"
+	"personal/action/import_profile"
+	"personal/gateways"
+)
+
+const wiseCSV = `"TransferWise ID";"Date";"Amount";"Currency";"Description";"Running Balance"
+"TRANSFER-1";"05.04.2026";"-1.234,50";"EUR";"Card transaction IKEA NICOSIA";"100,00"
+"TRANSFER-2";"06.04.2026";"2.000,00";"EUR";"Salary from Employer";"2.100,00"
+"TRANSFER-3";"07.04.2026";"-12,00";"USD";"Card transaction NETFLIX";"2.088,00"
+`
+
+// saveWiseProfile stores a semicolon-separated, comma-decimal column map.
+func (s *IntegrationTestSuite) saveWiseProfile(ctx context.Context) {
+	_, out, err := import_profile.SaveImportProfile(ctx, nil, import_profile.ImportProfileInput{
+		Name:              "Wise",
+		Aliases:           []string{"transferwise"},
+		Delimiter:         ";",
+		DateColumn:        "Date",
+		DateFormat:        "DD.MM.YYYY",
+		DescriptionColumn: "Description",
+		AmountColumn:      "Amount",
+		CurrencyColumn:    "Currency",
+		DecimalStyle:      "comma",
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(out.Error)
+}
+
+func (s *IntegrationTestSuite) TestImportRegistry_Accounts() {
+	tests := []struct {
+		name        string
+		account     string
+		csv         string
+		wantAccount string
+		wantCount   int
+	}{
+		{name: "saved profile by name", account: "Wise", csv: wiseCSV, wantAccount: "Wise", wantCount: 2},
+		{name: "saved profile by alias", account: "TransferWise", csv: wiseCSV, wantAccount: "wise", wantCount: 2},
+		{name: "saved profile auto-detected", account: "auto", csv: wiseCSV, wantAccount: "Wise", wantCount: 2},
+		{name: "built-in auto-detected", account: "auto", csv: revolutCSV, wantAccount: "Revolut", wantCount: 3},
+		{name: "built-in by alias", account: "BOC", csv: bocCSV, wantAccount: "bank of cyprus", wantCount: 3},
+	}
+
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			require.NoError(t, s.dbMaintainer.TruncateUserData(ctx, gateways.UserIDFromContext(ctx)))
+			s.saveWiseProfile(ctx)
+
+			body, ct := multipartCSV(tt.account, tt.csv)
+			req := httptest.NewRequest(http.MethodPost, "/money/import", body)
+			req.Header.Set("Content-Type", ct)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			_, out, err := get_transactions.GetTransactions(ctx, nil,
+				get_transactions.GetTransactionsInput{Account: &tt.wantAccount, Limit: 50})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCount, out.Total, w.Body.String())
+		})
+	}
+}
+
+func (s *IntegrationTestSuite) TestImportRegistry_WiseAmountsParsed() {
+	ctx := s.Context()
+	s.saveWiseProfile(ctx)
+	r := s.importRouter(ctx)
+
+	body, ct := multipartCSV("Wise", wiseCSV)
+	req := httptest.NewRequest(http.MethodPost, "/money/import", body)
+	req.Header.Set("Content-Type", ct)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+
+	expense := "expense"
+	_, out, err := get_transactions.GetTransactions(ctx, nil,
+		get_transactions.GetTransactionsInput{Type: &expense, Limit: 50})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Transactions, 1)
+	assert.Equal(s.T(), 1234.50, out.Transactions[0].AmountEUR)
+	assert.Equal(s.T(), 5, out.Transactions[0].TransactedAt.Day())
+}
+
+func (s *IntegrationTestSuite) TestImportRegistry_FormListsProfiles() {
+	ctx := s.Context()
+	s.saveWiseProfile(ctx)
+	r := s.importRouter(ctx)
+
+	req := httptest.NewRequest(http.MethodGet, "/money/import", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), `<option value="Revolut">`)
+	assert.Contains(s.T(), w.Body.String(), `<option value="Wise">`)
+	assert.Contains(s.T(), w.Body.String(), `<option value="auto">`)
+}
+
+func (s *IntegrationTestSuite) TestImportRegistry_InvalidProfileRejected() {
+	ctx := s.Context()
+
+	_, out, err := import_profile.SaveImportProfile(ctx, nil, import_profile.ImportProfileInput{
+		Name:              "Broken",
+		DateColumn:        "Date",
+		DescriptionColumn: "Description",
+	})
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), out.Error, "amount_column")
+
+	_, listOut, err := import_profile.ListImportProfiles(ctx, nil, import_profile.ListImportProfilesInput{})
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), listOut.Profiles)
+	assert.Len(s.T(), listOut.Builtin, 5)
+}
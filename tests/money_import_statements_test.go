@@ -0,0 +1,308 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/account"
+	"personal/action/get_transactions"
+	"personal/gateways"
+)
+
+const ofxStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>EUR
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20260405120000[+3:EEST]
+<DTAVAIL>20260407
+<TRNAMT>-42.10
+<FITID>OFX-0001
+<NAME>LIDL CYPRUS 0042
+<MEMO>Card purchase
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20260406
+<TRNAMT>2500.00
+<FITID>OFX-0002
+<NAME>Salary from Employer
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const qifStatement = `!Type:Bank
+D15/04/2026
+T-9.99
+PNETFLIX.COM
+N1001
+^
+D16/04/2026
+T1,200.00
+PSalary from Employer
+N1002
+^
+`
+
+const camtStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+<BkToCstmrStmt>
+<Stmt>
+<Acct><Ccy>EUR</Ccy></Acct>
+<Ntry>
+<Amt Ccy="EUR">15.40</Amt>
+<CdtDbtInd>DBIT</CdtDbtInd>
+<Sts>BOOK</Sts>
+<BookgDt><Dt>2026-04-10</Dt></BookgDt>
+<ValDt><Dt>2026-04-11</Dt></ValDt>
+<AcctSvcrRef>CAMT-REF-1</AcctSvcrRef>
+<NtryDtls><TxDtls>
+<RltdPties><Cdtr><Nm>Wolt Cyprus</Nm></Cdtr></RltdPties>
+<RmtInf><Ustrd>Order 123</Ustrd></RmtInf>
+</TxDtls></NtryDtls>
+</Ntry>
+<Ntry>
+<Amt Ccy="EUR">100.00</Amt>
+<CdtDbtInd>CRDT</CdtDbtInd>
+<Sts>BOOK</Sts>
+<BookgDt><Dt>2026-04-12</Dt></BookgDt>
+<AcctSvcrRef>CAMT-REF-2</AcctSvcrRef>
+<AddtlNtryInf>Refund</AddtlNtryInf>
+</Ntry>
+<Ntry>
+<Amt Ccy="EUR">7.00</Amt>
+<CdtDbtInd>DBIT</CdtDbtInd>
+<Sts>PDNG</Sts>
+<BookgDt><Dt>2026-04-13</Dt></BookgDt>
+<AcctSvcrRef>CAMT-REF-3</AcctSvcrRef>
+</Ntry>
+</Stmt>
+</BkToCstmrStmt>
+</Document>
+`
+
+// multipartStatement builds an import form with an optional account_name
+// override and a statement file of any format.
+func multipartStatement(account, accountName, filename, content string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("account", account)
+	if accountName != "" {
+		_ = writer.WriteField("account_name", accountName)
+	}
+	part, _ := writer.CreateFormFile("file", filename)
+	_, _ = fmt.Fprint(part, content)
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+func postStatement(r *gin.Engine, account, accountName, filename, content string) *httptest.ResponseRecorder {
+	body, ct := multipartStatement(account, accountName, filename, content)
+	req := httptest.NewRequest(http.MethodPost, "/money/import", body)
+	req.Header.Set("Content-Type", ct)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func (s *IntegrationTestSuite) statementTransactions(ctx context.Context, t *testing.T, account string) get_transactions.GetTransactionsOutput {
+	_, out, err := get_transactions.GetTransactions(ctx, nil,
+		get_transactions.GetTransactionsInput{Account: &account, Limit: 50})
+	require.NoError(t, err)
+	return out
+}
+
+func (s *IntegrationTestSuite) TestImportStatements_Formats() {
+	tests := []struct {
+		name        string
+		account     string
+		filename    string
+		content     string
+		wantAccount string
+		wantCount   int
+	}{
+		{name: "ofx by name", account: "OFX", filename: "statement.ofx", content: ofxStatement, wantAccount: "OFX", wantCount: 2},
+		{name: "qfx alias", account: "QFX", filename: "statement.qfx", content: ofxStatement, wantAccount: "ofx", wantCount: 2},
+		{name: "ofx auto-detected", account: "auto", filename: "statement.ofx", content: ofxStatement, wantAccount: "OFX", wantCount: 2},
+		{name: "qif auto-detected", account: "auto", filename: "statement.qif", content: qifStatement, wantAccount: "QIF", wantCount: 2},
+		{name: "camt auto-detected, pending skipped", account: "auto", filename: "statement.xml", content: camtStatement, wantAccount: "CAMT.053", wantCount: 2},
+	}
+
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			require.NoError(t, s.dbMaintainer.TruncateUserData(ctx, gateways.UserIDFromContext(ctx)))
+
+			w := postStatement(r, tt.account, "", tt.filename, tt.content)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			out := s.statementTransactions(ctx, t, tt.wantAccount)
+			assert.Equal(t, tt.wantCount, out.Total, w.Body.String())
+		})
+	}
+}
+
+func (s *IntegrationTestSuite) TestImportStatements_OFXFields() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	w := postStatement(r, "OFX", "", "statement.ofx", ofxStatement)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+
+	out := s.statementTransactions(ctx, s.T(), "OFX")
+	require.Len(s.T(), out.Transactions, 2)
+
+	// Newest first: salary, then the Lidl purchase.
+	lidl := out.Transactions[1]
+	assert.Equal(s.T(), "expense", lidl.Type)
+	assert.InDelta(s.T(), 42.10, lidl.AmountOriginal, 0.001)
+	assert.Equal(s.T(), "EUR", lidl.Currency)
+	assert.Equal(s.T(), "Lidl", lidl.Merchant)
+	assert.Equal(s.T(), time.Date(2026, 4, 5, 9, 0, 0, 0, time.UTC), lidl.TransactedAt.UTC())
+	require.NotNil(s.T(), lidl.ExternalID)
+	assert.Equal(s.T(), "OFX-0001", *lidl.ExternalID)
+	require.NotNil(s.T(), lidl.ValueDate)
+	assert.Equal(s.T(), time.Date(2026, 4, 7, 0, 0, 0, 0, time.UTC), lidl.ValueDate.UTC())
+}
+
+func (s *IntegrationTestSuite) TestImportStatements_CAMTFields() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	w := postStatement(r, "CAMT", "Hellenic", "statement.xml", camtStatement)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+
+	out := s.statementTransactions(ctx, s.T(), "Hellenic")
+	require.Len(s.T(), out.Transactions, 2, w.Body.String())
+
+	wolt := out.Transactions[1]
+	assert.Equal(s.T(), "expense", wolt.Type)
+	assert.InDelta(s.T(), 15.40, wolt.AmountOriginal, 0.001)
+	assert.Equal(s.T(), "Wolt", wolt.Merchant)
+	assert.Equal(s.T(), time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC), wolt.TransactedAt.UTC())
+	require.NotNil(s.T(), wolt.ExternalID)
+	assert.Equal(s.T(), "CAMT-REF-1", *wolt.ExternalID)
+	require.NotNil(s.T(), wolt.ValueDate)
+	assert.Equal(s.T(), time.Date(2026, 4, 11, 0, 0, 0, 0, time.UTC), wolt.ValueDate.UTC())
+
+	refund := out.Transactions[0]
+	assert.Equal(s.T(), "income", refund.Type)
+	assert.Nil(s.T(), refund.ValueDate)
+}
+
+func (s *IntegrationTestSuite) TestImportStatements_ReimportSkipsDuplicates() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	w := postStatement(r, "QIF", "", "statement.qif", qifStatement)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "imported 2")
+
+	w = postStatement(r, "QIF", "", "statement.qif", qifStatement)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "duplicates 2")
+
+	out := s.statementTransactions(ctx, s.T(), "QIF")
+	assert.Equal(s.T(), 2, out.Total)
+
+	// The same bank IDs in another account are not duplicates.
+	w = postStatement(r, "QIF", "Savings", "statement.qif", qifStatement)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), 2, s.statementTransactions(ctx, s.T(), "Savings").Total)
+}
+
+func (s *IntegrationTestSuite) TestImportStatements_QIFSharedNumberIsNotDuplicate() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	// Banks reuse N for cash withdrawals; the rows are still distinct.
+	const statement = `!Type:Bank
+D15/04/2026
+T-50.00
+PATM LIMASSOL
+NATM
+^
+D18/04/2026
+T-20.00
+PATM NICOSIA
+NATM
+^
+D18/04/2026
+T-20.00
+PATM NICOSIA
+NATM
+^
+`
+	w := postStatement(r, "QIF", "", "statement.qif", statement)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "imported 3")
+	assert.Equal(s.T(), 3, s.statementTransactions(ctx, s.T(), "QIF").Total)
+
+	w = postStatement(r, "QIF", "", "statement.qif", statement)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "duplicates 3")
+	assert.Equal(s.T(), 3, s.statementTransactions(ctx, s.T(), "QIF").Total)
+}
+
+func (s *IntegrationTestSuite) TestImportStatements_QIFDecimalComma() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	const statement = `!Type:Bank
+D15/04/2026
+T-12,50
+PWOLT
+^
+D16/04/2026
+T1.234,56
+PSalary from Employer
+^
+D17/04/2026
+T-1,050.75
+PIKEA
+^
+`
+	w := postStatement(r, "QIF", "", "statement.qif", statement)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+
+	out := s.statementTransactions(ctx, s.T(), "QIF")
+	require.Len(s.T(), out.Transactions, 3, w.Body.String())
+	assert.InDelta(s.T(), 1050.75, out.Transactions[0].AmountOriginal, 0.001)
+	assert.InDelta(s.T(), 1234.56, out.Transactions[1].AmountOriginal, 0.001)
+	assert.InDelta(s.T(), 12.50, out.Transactions[2].AmountOriginal, 0.001)
+	assert.Equal(s.T(), "EUR", out.Transactions[2].Currency)
+}
+
+func (s *IntegrationTestSuite) TestImportStatements_QIFTakesAccountCurrency() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	_, _, err := account.SaveAccount(ctx, nil, account.SaveAccountInput{Name: "Chase", Currency: "USD"})
+	require.NoError(s.T(), err)
+
+	// Non-EUR rows are not converted on import, so a USD account's QIF
+	// rows are skipped rather than stored as EUR.
+	w := postStatement(r, "QIF", "Chase", "statement.qif", qifStatement)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "skipped 2")
+	assert.Equal(s.T(), 0, s.statementTransactions(ctx, s.T(), "Chase").Total)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"personal/action/account"
 	"personal/action/get_transactions"
 	money_import "personal/action/money_import"
 	"personal/gateways"
@@ -94,6 +95,34 @@ func (s *IntegrationTestSuite) TestImport_POST_Revolut_Success() {
 	assert.Equal(s.T(), 3, listOut.Total)
 }
 
+func (s *IntegrationTestSuite) TestImport_POST_KeepsStoredAccountName() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	accounts := func() []string {
+		_, out, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Limit: 50})
+		require.NoError(s.T(), err)
+		var names []string
+		for _, t := range out.Transactions {
+			names = append(names, t.Account)
+		}
+		return names
+	}
+
+	// Unregistered accounts keep the lowercase spelling earlier imports used.
+	w := postStatement(r, "Revolut", "", "statement.csv", revolutCSV)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), []string{"revolut", "revolut", "revolut"}, accounts())
+
+	// A registered account's name wins, whatever the form sends.
+	require.NoError(s.T(), s.dbMaintainer.TruncateUserData(ctx, s.UserID()))
+	_, _, err := account.SaveAccount(ctx, nil, account.SaveAccountInput{Name: "Revolut"})
+	require.NoError(s.T(), err)
+	w = postStatement(r, "auto", "REVOLUT", "statement.csv", revolutCSV)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Equal(s.T(), []string{"Revolut", "Revolut", "Revolut"}, accounts())
+}
+
 func (s *IntegrationTestSuite) TestImport_POST_Revolut_ExpenseIncomeSplit() {
 	ctx := s.Context()
 	r := s.importRouter(ctx)
@@ -258,8 +287,28 @@ func (s *IntegrationTestSuite) TestImport_POST_UnknownAccount() {
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 
-	assert.Equal(s.T(), http.StatusOK, w.Code)
-	assert.Contains(s.T(), strings.ToLower(w.Body.String()), "unknown account")
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "unknown format")
+	assert.Contains(s.T(), w.Body.String(), "MyBank")
+}
+
+func (s *IntegrationTestSuite) TestImport_POST_UnknownFormatIsNotDetected() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	// The file is a valid Revolut export, but the chosen format does not exist.
+	body, ct := multipartCSV("Revolutt", revolutCSV)
+	req := httptest.NewRequest(http.MethodPost, "/money/import", body)
+	req.Header.Set("Content-Type", ct)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "unknown format")
+
+	_, listOut, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Limit: 50})
+	require.NoError(s.T(), err)
+	assert.Zero(s.T(), listOut.Total)
 }
 
 func (s *IntegrationTestSuite) TestImport_POST_EmptyCSV() {
@@ -292,6 +341,7 @@ func (s *IntegrationTestSuite) TestImport_POST_MissingAccountField() {
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 
+	// Without a format the parser is detected from the file.
 	assert.Equal(s.T(), http.StatusOK, w.Code)
-	assert.Contains(s.T(), strings.ToLower(w.Body.String()), "account name is required")
+	assert.Contains(s.T(), w.Body.String(), "imported 3")
 }
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/add_transactions"
+	"personal/action/recurring"
+)
+
+// addRecurringScenario records a monthly Netflix series with a price bump,
+// a weekly yoga class, a gym membership that stopped four months ago and
+// irregular groceries.
+func (s *IntegrationTestSuite) addRecurringScenario(ctx context.Context) {
+	now := time.Now().UTC().Truncate(24 * time.Hour)
+	var txs []add_transactions.TransactionInput
+	expense := func(merchant string, amount float64, at time.Time) {
+		txs = append(txs, add_transactions.TransactionInput{
+			Type: "expense", AmountOriginal: amount, Currency: "EUR", AmountEUR: amount,
+			Account: "Revolut", Category: "subscriptions", Merchant: merchant, TransactedAt: at,
+		})
+	}
+
+	for i := 5; i >= 1; i-- {
+		amount := 12.99
+		if i == 1 {
+			amount = 15.99
+		}
+		expense("Netflix", amount, now.AddDate(0, -i, 10))
+	}
+	for i := 4; i >= 1; i-- {
+		expense("Yoga Club", 10, now.AddDate(0, 0, -7*i+2))
+	}
+	for i := 7; i >= 4; i-- {
+		expense("Old Gym", 40, now.AddDate(0, -i, 0))
+	}
+	for _, daysAgo := range []int{60, 51, 49, 30, 12, 3} {
+		expense("Lidl", 20+float64(daysAgo), now.AddDate(0, 0, -daysAgo))
+	}
+
+	_, out, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{Transactions: txs})
+	s.Require().NoError(err)
+	s.Require().Empty(out.Error)
+}
+
+func (s *IntegrationTestSuite) TestRecurring_DetectAndList() {
+	ctx := s.Context()
+	s.addRecurringScenario(ctx)
+
+	_, detectOut, err := recurring.DetectRecurring(ctx, nil, recurring.DetectRecurringInput{})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), detectOut.Error)
+	assert.Equal(s.T(), 3, detectOut.DetectedCount)
+
+	_, out, err := recurring.ListRecurring(ctx, nil, recurring.ListRecurringInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Items, 3)
+
+	items := map[string]recurring.RecurringItemOutput{}
+	for _, it := range out.Items {
+		items[it.Merchant] = it
+	}
+	assert.NotContains(s.T(), items, "Lidl")
+
+	netflix := items["Netflix"]
+	assert.Equal(s.T(), "monthly", netflix.Cadence)
+	assert.Equal(s.T(), "active", netflix.Status)
+	assert.True(s.T(), netflix.PriceIncreased)
+	assert.InDelta(s.T(), 15.99, netflix.LastAmount, 0.001)
+	assert.InDelta(s.T(), 12.99, netflix.PreviousAmount, 0.001)
+	assert.Equal(s.T(), 5, netflix.Occurrences)
+	assert.True(s.T(), netflix.NextChargeAt.Equal(netflix.LastChargedAt.AddDate(0, 1, 0)))
+
+	yoga := items["Yoga Club"]
+	assert.Equal(s.T(), "weekly", yoga.Cadence)
+	assert.Equal(s.T(), "active", yoga.Status)
+	assert.False(s.T(), yoga.PriceIncreased)
+
+	assert.Equal(s.T(), "cancelled", items["Old Gym"].Status)
+
+	// Cancelled items do not count towards fixed costs.
+	assert.InDelta(s.T(), 15.99+10*52.0/12, out.MonthlyTotals["EUR"], 0.01)
+
+	_, filtered, err := recurring.ListRecurring(ctx, nil, recurring.ListRecurringInput{PriceIncreasedOnly: true})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), filtered.Items, 1)
+	assert.Equal(s.T(), "Netflix", filtered.Items[0].Merchant)
+
+	_, bad, err := recurring.ListRecurring(ctx, nil, recurring.ListRecurringInput{Status: "paused"})
+	require.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), bad.Error)
+}
+
+func (s *IntegrationTestSuite) TestRecurring_RefreshedOnImport() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	const spotify = `Type,Product,Started Date,Completed Date,Description,Amount,Fee,Currency,State,Balance
+CARD_PAYMENT,Current,2026-01-03 08:00:00,2026-01-03 08:00:00,Spotify,-10.99,0.00,EUR,COMPLETED,89.01
+CARD_PAYMENT,Current,2026-02-03 08:00:00,2026-02-03 08:00:00,Spotify,-10.99,0.00,EUR,COMPLETED,78.02
+CARD_PAYMENT,Current,2026-03-03 08:00:00,2026-03-03 08:00:00,Spotify,-10.99,0.00,EUR,COMPLETED,67.03
+`
+	w := postStatement(r, "Revolut", "", "revolut.csv", spotify)
+	require.Contains(s.T(), w.Body.String(), "recurring items 1")
+
+	_, out, err := recurring.ListRecurring(ctx, nil, recurring.ListRecurringInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), out.Items, 1)
+	assert.Equal(s.T(), "monthly", out.Items[0].Cadence)
+	assert.Equal(s.T(), time.Date(2026, 4, 3, 8, 0, 0, 0, time.UTC), out.Items[0].NextChargeAt.UTC())
+}
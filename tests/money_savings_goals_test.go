@@ -0,0 +1,222 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/add_transactions"
+	"personal/action/progress"
+	"personal/action/savings_goals"
+	"personal/action/tags"
+	"personal/action/transfer_pairs"
+	"personal/domain"
+	"personal/util"
+)
+
+func (s *IntegrationTestSuite) TestSavingsGoals_AccountFundingAndReflection() {
+	ctx := s.Context()
+	now := time.Now().UTC()
+
+	s.saveAccount(ctx, "Savings", 1000)
+	_, addOut, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "income", AmountOriginal: 500, Currency: "EUR", AmountEUR: 500, Account: "Savings",
+				Category: "savings", TransactedAt: now.AddDate(0, 0, -60)},
+			{Type: "income", AmountOriginal: 500, Currency: "EUR", AmountEUR: 500, Account: "Savings",
+				Category: "savings", TransactedAt: now.AddDate(0, 0, -30)},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), addOut.Error)
+
+	activityID, err := s.Repo().CreateActivity(ctx, &domain.Activity{
+		UserID:        s.UserID(),
+		Name:          "Financial cushion",
+		ProgressType:  domain.ProgressTypeHabitProgress,
+		FrequencyDays: 7,
+		StartedAt:     now.AddDate(0, -3, 0),
+	})
+	require.NoError(s.T(), err)
+
+	deadline := now.AddDate(0, 6, 0)
+	_, saveOut, err := savings_goals.SaveSavingsGoal(ctx, nil, savings_goals.SaveSavingsGoalInput{
+		Name:           "Emergency fund",
+		TargetAmount:   5000,
+		Deadline:       &deadline,
+		FundingAccount: util.Ptr("savings"),
+		ActivityID:     &activityID,
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), saveOut.Error)
+	assert.Equal(s.T(), "Savings", *saveOut.Goal.FundingAccount)
+	assert.Equal(s.T(), "EUR", saveOut.Goal.Currency)
+
+	_, checkOut, err := savings_goals.CheckSavingsGoals(ctx, nil, savings_goals.CheckSavingsGoalsInput{At: &now})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), checkOut.Error)
+	require.Len(s.T(), checkOut.Goals, 1)
+	g := checkOut.Goals[0]
+	assert.InDelta(s.T(), 2000, g.Saved, 0.01)
+	assert.InDelta(s.T(), 3000, g.Remaining, 0.01)
+	assert.InDelta(s.T(), 40, g.ProgressPct, 0.01)
+	// 1000 saved over the 90-day window is about 338 a month.
+	assert.InDelta(s.T(), 338.19, g.MonthlyPace, 0.01)
+	require.NotNil(s.T(), g.RequiredMonthly)
+	assert.InDelta(s.T(), 500, *g.RequiredMonthly, 5)
+	assert.Equal(s.T(), domain.SavingsBehind, g.Status)
+	assert.Equal(s.T(), "Financial cushion", g.ActivityName)
+
+	_, statsOut, err := progress.GetActivityStats(ctx, nil, progress.GetActivityStatsInput{ActivityID: activityID})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), statsOut.SavingsGoals, 1)
+	assert.Equal(s.T(), "Emergency fund", statsOut.SavingsGoals[0].Name)
+	assert.Equal(s.T(), domain.SavingsBehind, statsOut.SavingsGoals[0].Status)
+
+	// A smaller target is within reach at the current pace.
+	_, saveOut, err = savings_goals.SaveSavingsGoal(ctx, nil, savings_goals.SaveSavingsGoalInput{
+		Name:           "Emergency fund",
+		TargetAmount:   3500,
+		Deadline:       &deadline,
+		FundingAccount: util.Ptr("Savings"),
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), saveOut.Error)
+	assert.Equal(s.T(), domain.SavingsOnTrack, saveOut.Goal.Status)
+	assert.Nil(s.T(), saveOut.Goal.ActivityID)
+}
+
+func (s *IntegrationTestSuite) TestSavingsGoals_TagFunding() {
+	ctx := s.Context()
+	at := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	_, addOut, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "transfer", AmountOriginal: 800, Currency: "EUR", AmountEUR: 800, Account: "Revolut",
+				Category: "transfer", TransactedAt: at.AddDate(0, 0, -20)},
+			{Type: "income", AmountOriginal: 50, Currency: "EUR", AmountEUR: 50, Account: "Revolut",
+				Category: "interest", TransactedAt: at.AddDate(0, 0, -10)},
+			{Type: "expense", AmountOriginal: 100, Currency: "EUR", AmountEUR: 100, Account: "Revolut",
+				Category: "home", TransactedAt: at.AddDate(0, 0, -5)},
+			{Type: "transfer", AmountOriginal: 300, Currency: "EUR", AmountEUR: 300, Account: "Revolut",
+				Category: "transfer", TransactedAt: at.AddDate(0, 0, 5)},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), addOut.Error)
+	ids := make([]int64, len(addOut.Transactions))
+	for i, tx := range addOut.Transactions {
+		ids[i] = tx.ID
+	}
+	_, tagOut, err := tags.TagTransactions(ctx, nil, tags.TagTransactionsInput{IDs: ids, Add: []string{"House Deposit"}})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), tagOut.Error)
+
+	_, saveOut, err := savings_goals.SaveSavingsGoal(ctx, nil, savings_goals.SaveSavingsGoalInput{
+		Name:           "House",
+		TargetAmount:   10000,
+		FundingTag:     util.Ptr("house  deposit"),
+		StartingAmount: 1000,
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), saveOut.Error)
+	assert.Equal(s.T(), "house deposit", *saveOut.Goal.FundingTag)
+
+	_, checkOut, err := savings_goals.CheckSavingsGoals(ctx, nil, savings_goals.CheckSavingsGoalsInput{At: &at, Name: util.Ptr("house")})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), checkOut.Goals, 1)
+	g := checkOut.Goals[0]
+	assert.InDelta(s.T(), 1750, g.Saved, 0.01)
+	assert.Equal(s.T(), domain.SavingsNoDeadline, g.Status)
+	assert.Nil(s.T(), g.RequiredMonthly)
+
+	// Renaming the tag keeps the goal funded.
+	_, renameOut, err := tags.RenameTag(ctx, nil, tags.RenameTagInput{Name: "house deposit", NewName: "home deposit"})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), renameOut.Error)
+	_, checkOut, err = savings_goals.CheckSavingsGoals(ctx, nil, savings_goals.CheckSavingsGoalsInput{At: &at})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), checkOut.Goals, 1)
+	assert.Equal(s.T(), "home deposit", *checkOut.Goals[0].FundingTag)
+	assert.InDelta(s.T(), 1750, checkOut.Goals[0].Saved, 0.01)
+}
+
+func (s *IntegrationTestSuite) TestSavingsGoals_TagFundingPairedTransfer() {
+	ctx := s.Context()
+	added := s.addTopUpScenario(ctx)
+
+	from := transferDay.AddDate(0, 0, -1)
+	to := transferDay.AddDate(0, 0, 5)
+	_, pairOut, err := transfer_pairs.PairTransfers(ctx, nil, transfer_pairs.PairTransfersInput{From: &from, To: &to})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, pairOut.PairedCount)
+
+	// The BOC → Revolut top-up keeps its expense/income types once paired.
+	outID, inID := added.Transactions[0].ID, added.Transactions[1].ID
+
+	tests := []struct {
+		name      string
+		tagged    []int64
+		wantSaved float64
+	}{
+		{name: "outgoing leg is a deposit", tagged: []int64{outID}, wantSaved: 1200},
+		{name: "incoming leg is a withdrawal", tagged: []int64{inID}, wantSaved: 800},
+		{name: "both legs count once", tagged: []int64{outID, inID}, wantSaved: 1200},
+	}
+	for i, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tag := fmt.Sprintf("deposit %d", i)
+			_, tagOut, err := tags.TagTransactions(ctx, nil, tags.TagTransactionsInput{IDs: tt.tagged, Add: []string{tag}})
+			require.NoError(t, err)
+			require.Empty(t, tagOut.Error)
+
+			goal := fmt.Sprintf("Goal %d", i)
+			_, saveOut, err := savings_goals.SaveSavingsGoal(ctx, nil, savings_goals.SaveSavingsGoalInput{
+				Name:           goal,
+				TargetAmount:   10000,
+				FundingTag:     &tag,
+				StartingAmount: 1000,
+			})
+			require.NoError(t, err)
+			require.Empty(t, saveOut.Error)
+
+			_, checkOut, err := savings_goals.CheckSavingsGoals(ctx, nil, savings_goals.CheckSavingsGoalsInput{At: &to, Name: &goal})
+			require.NoError(t, err)
+			require.Len(t, checkOut.Goals, 1)
+			assert.InDelta(t, tt.wantSaved, checkOut.Goals[0].Saved, 0.01)
+		})
+	}
+}
+
+func (s *IntegrationTestSuite) TestSavingsGoals_Validation() {
+	ctx := s.Context()
+	s.saveAccount(ctx, "Savings", 0)
+
+	tests := []struct {
+		name   string
+		input  savings_goals.SaveSavingsGoalInput
+		errMsg string
+	}{
+		{"no name", savings_goals.SaveSavingsGoalInput{TargetAmount: 100, FundingTag: util.Ptr("x")}, "name is required"},
+		{"no target", savings_goals.SaveSavingsGoalInput{Name: "Car", FundingTag: util.Ptr("x")}, "target_amount"},
+		{"no funding", savings_goals.SaveSavingsGoalInput{Name: "Car", TargetAmount: 100}, "exactly one"},
+		{"both fundings", savings_goals.SaveSavingsGoalInput{Name: "Car", TargetAmount: 100,
+			FundingTag: util.Ptr("x"), FundingAccount: util.Ptr("Savings")}, "exactly one"},
+		{"unknown account", savings_goals.SaveSavingsGoalInput{Name: "Car", TargetAmount: 100,
+			FundingAccount: util.Ptr("Brokerage")}, "not found"},
+		{"currency mismatch", savings_goals.SaveSavingsGoalInput{Name: "Car", TargetAmount: 100,
+			FundingAccount: util.Ptr("Savings"), Currency: "USD"}, "currency must match"},
+		{"tag in USD", savings_goals.SaveSavingsGoalInput{Name: "Car", TargetAmount: 100,
+			FundingTag: util.Ptr("car"), Currency: "USD"}, "EUR"},
+		{"unknown activity", savings_goals.SaveSavingsGoalInput{Name: "Car", TargetAmount: 100,
+			FundingTag: util.Ptr("car"), ActivityID: util.Ptr(int64(999999))}, "activity 999999 not found"},
+	}
+	for _, tt := range tests {
+		_, out, err := savings_goals.SaveSavingsGoal(ctx, nil, tt.input)
+		require.NoError(s.T(), err, tt.name)
+		assert.Contains(s.T(), out.Error, tt.errMsg, tt.name)
+	}
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/add_transactions"
+	"personal/action/compare_periods"
+	"personal/action/edit_transactions"
+	"personal/action/get_budget_progress"
+	"personal/action/get_spending_by_category"
+	"personal/action/get_transactions"
+	"personal/action/set_budget"
+	"personal/action/split_transaction"
+	"personal/util"
+)
+
+var receiptDay = time.Date(2026, 4, 12, 18, 0, 0, 0, time.UTC)
+
+// addReceipt records a 100 EUR supermarket receipt categorized as groceries.
+func (s *IntegrationTestSuite) addReceipt(ctx context.Context) int64 {
+	_, out, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 100, Currency: "EUR", AmountEUR: 100, Account: "Revolut",
+				Category: "food/groceries", Merchant: "Lidl", TransactedAt: receiptDay},
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(out.Error)
+	return out.Transactions[0].ID
+}
+
+func (s *IntegrationTestSuite) TestSplitTransaction_UsedByAggregations() {
+	ctx := s.Context()
+	id := s.addReceipt(ctx)
+
+	_, splitOut, err := split_transaction.SplitTransaction(ctx, nil, split_transaction.SplitTransactionInput{
+		ID: id,
+		Splits: []split_transaction.SplitInput{
+			{Category: "food/groceries", Amount: 60},
+			{Category: "home/cleaning", Amount: 25, Note: util.Ptr("detergent")},
+			{Category: "personal_care", Amount: 15},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), splitOut.Error)
+	require.Len(s.T(), splitOut.Splits, 3)
+
+	from := receiptDay.AddDate(0, 0, -1)
+	to := receiptDay.AddDate(0, 0, 1)
+
+	_, spendOut, err := get_spending_by_category.GetSpendingByCategory(ctx, nil,
+		get_spending_by_category.GetSpendingByCategoryInput{From: from, To: to, Depth: 1})
+	require.NoError(s.T(), err)
+	totals := map[string]float64{}
+	for _, c := range spendOut.Categories {
+		totals[c.Category] = c.TotalEUR
+	}
+	assert.Equal(s.T(), map[string]float64{"food": 60, "home": 25, "personal_care": 15}, totals)
+
+	_, _, err = set_budget.SetBudget(ctx, nil, set_budget.SetBudgetInput{
+		Name: "Home - April 2026", Category: "home", AmountEUR: 50,
+		StartsAt: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2026, 4, 30, 23, 59, 59, 0, time.UTC),
+	})
+	require.NoError(s.T(), err)
+	_, budgetOut, err := get_budget_progress.GetBudgetProgress(ctx, nil, get_budget_progress.GetBudgetProgressInput{At: receiptDay})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), budgetOut.Budgets, 1)
+	assert.InDelta(s.T(), 25, budgetOut.Budgets[0].SpentEUR, 0.01)
+
+	_, cmpOut, err := compare_periods.ComparePeriods(ctx, nil, compare_periods.ComparePeriodsInput{
+		PeriodAFrom: from.AddDate(0, -1, 0), PeriodATo: from,
+		PeriodBFrom: from, PeriodBTo: to,
+	})
+	require.NoError(s.T(), err)
+	assert.InDelta(s.T(), 100, cmpOut.PeriodB.TotalEUR, 0.01)
+	assert.Len(s.T(), cmpOut.PeriodB.Categories, 3)
+
+	// A split line matches the category filter and is listed with its parent.
+	category := "home"
+	_, listOut, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Category: &category})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), listOut.Transactions, 1)
+	require.Len(s.T(), listOut.Transactions[0].Splits, 3)
+	assert.Equal(s.T(), "home/cleaning", listOut.Transactions[0].Splits[1].Category)
+	assert.Equal(s.T(), "detergent", *listOut.Transactions[0].Splits[1].Note)
+
+	// Changing the amount drops the split, which no longer adds up.
+	_, editOut, err := edit_transactions.EditTransactions(ctx, nil, edit_transactions.EditTransactionsInput{
+		Updates: []edit_transactions.TransactionUpdate{{ID: id, AmountOriginal: util.Ptr(120.0), AmountEUR: util.Ptr(120.0)}},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), editOut.Error)
+
+	_, spendOut, err = get_spending_by_category.GetSpendingByCategory(ctx, nil,
+		get_spending_by_category.GetSpendingByCategoryInput{From: from, To: to, Depth: 1})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), spendOut.Categories, 1)
+	assert.InDelta(s.T(), 120, spendOut.Categories[0].TotalEUR, 0.01)
+}
+
+func (s *IntegrationTestSuite) TestSplitTransaction_ForeignCurrency() {
+	ctx := s.Context()
+
+	_, out, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 50, Currency: "USD", AmountEUR: 40, Account: "Revolut",
+				Category: "shopping", Merchant: "Target", TransactedAt: receiptDay},
+		},
+	})
+	require.NoError(s.T(), err)
+
+	_, splitOut, err := split_transaction.SplitTransaction(ctx, nil, split_transaction.SplitTransactionInput{
+		ID: out.Transactions[0].ID,
+		Splits: []split_transaction.SplitInput{
+			{Category: "shopping/clothes", Amount: 30},
+			{Category: "home", Amount: 20},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), splitOut.Error)
+	assert.InDelta(s.T(), 24, splitOut.Splits[0].AmountEUR, 0.001)
+	assert.InDelta(s.T(), 16, splitOut.Splits[1].AmountEUR, 0.001)
+}
+
+func (s *IntegrationTestSuite) TestSplitTransaction_Validation() {
+	ctx := s.Context()
+	id := s.addReceipt(ctx)
+
+	tests := []struct {
+		name   string
+		input  split_transaction.SplitTransactionInput
+		errMsg string
+	}{
+		{"sum mismatch", split_transaction.SplitTransactionInput{ID: id, Splits: []split_transaction.SplitInput{
+			{Category: "food", Amount: 60}, {Category: "home", Amount: 30}}}, "sum to 90.00"},
+		{"single line", split_transaction.SplitTransactionInput{ID: id, Splits: []split_transaction.SplitInput{
+			{Category: "food", Amount: 100}}}, "at least 2 lines"},
+		{"missing category", split_transaction.SplitTransactionInput{ID: id, Splits: []split_transaction.SplitInput{
+			{Category: "food", Amount: 60}, {Category: " ", Amount: 40}}}, "category is required"},
+		{"unknown transaction", split_transaction.SplitTransactionInput{ID: id + 1000, Splits: nil}, "not found"},
+	}
+	for _, tt := range tests {
+		_, out, err := split_transaction.SplitTransaction(ctx, nil, tt.input)
+		require.NoError(s.T(), err, tt.name)
+		assert.Contains(s.T(), out.Error, tt.errMsg, tt.name)
+	}
+}
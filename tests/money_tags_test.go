@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/add_transactions"
+	"personal/action/delete_transaction"
+	"personal/action/get_transactions"
+	"personal/action/tags"
+	"personal/util"
+)
+
+// addTripTransactions records a weekend in Barcelona next to ordinary
+// spending and returns the IDs in input order.
+func (s *IntegrationTestSuite) addTripTransactions(ctx context.Context) []int64 {
+	day := time.Date(2026, 5, 8, 12, 0, 0, 0, time.UTC)
+	_, out, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 180, Currency: "EUR", AmountEUR: 180, Account: "Revolut",
+				Category: "travel/flights", Merchant: "Vueling", TransactedAt: day},
+			{Type: "expense", AmountOriginal: 240, Currency: "EUR", AmountEUR: 240, Account: "Revolut",
+				Category: "travel/hotel", Merchant: "Hotel Arts", TransactedAt: day.AddDate(0, 0, 1)},
+			{Type: "expense", AmountOriginal: 45, Currency: "EUR", AmountEUR: 45, Account: "Revolut",
+				Category: "food/restaurant", Merchant: "Cervecería Catalana", Note: util.Ptr("birthday gift dinner for Ana"),
+				TransactedAt: day.AddDate(0, 0, 1)},
+			{Type: "expense", AmountOriginal: 30, Currency: "EUR", AmountEUR: 30, Account: "Revolut",
+				Category: "shopping", Merchant: "Amazon", OriginalDescription: util.Ptr("AMZN Mktp giftcards"),
+				TransactedAt: day.AddDate(0, 0, 5)},
+			{Type: "expense", AmountOriginal: 12, Currency: "EUR", AmountEUR: 12, Account: "Revolut",
+				Category: "food/groceries", Merchant: "Lidl", TransactedAt: day.AddDate(0, 0, 6)},
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(out.Error)
+
+	ids := make([]int64, len(out.Transactions))
+	for i, tx := range out.Transactions {
+		ids[i] = tx.ID
+	}
+	return ids
+}
+
+func (s *IntegrationTestSuite) TestTags_FilterAndManage() {
+	ctx := s.Context()
+	ids := s.addTripTransactions(ctx)
+
+	_, tagOut, err := tags.TagTransactions(ctx, nil, tags.TagTransactionsInput{
+		IDs: ids[:3],
+		Add: []string{"Barcelona  Trip", "barcelona trip", "2026"},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), tagOut.Error)
+	assert.Equal(s.T(), 6, tagOut.AddedCount)
+
+	_, listOut, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{
+		Tags: []string{"BARCELONA TRIP"},
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 3, listOut.Total)
+	assert.Equal(s.T(), []string{"2026", "barcelona trip"}, listOut.Transactions[0].Tags)
+
+	_, tagOut, err = tags.TagTransactions(ctx, nil, tags.TagTransactionsInput{IDs: ids[2:3], Remove: []string{"2026"}})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, tagOut.RemovedCount)
+
+	_, listOut, err = get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{
+		Tags: []string{"barcelona trip", "2026"},
+	})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, listOut.Total)
+
+	_, tagList, err := tags.ListTags(ctx, nil, tags.ListTagsInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), tagList.Tags, 2)
+	assert.Equal(s.T(), tags.TagOutput{Name: "barcelona trip", TransactionCount: 3, SpentEUR: 465}, tagList.Tags[0])
+
+	// Renaming onto an existing tag merges them.
+	_, renameOut, err := tags.RenameTag(ctx, nil, tags.RenameTagInput{Name: "2026", NewName: "Barcelona trip"})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), renameOut.Error)
+	_, tagList, err = tags.ListTags(ctx, nil, tags.ListTagsInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), tagList.Tags, 1)
+	assert.Equal(s.T(), 3, tagList.Tags[0].TransactionCount)
+
+	_, _, err = delete_transaction.DeleteTransaction(ctx, nil, delete_transaction.DeleteTransactionInput{ID: ids[0]})
+	require.NoError(s.T(), err)
+	_, tagList, err = tags.ListTags(ctx, nil, tags.ListTagsInput{})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, tagList.Tags[0].TransactionCount)
+
+	_, deleteOut, err := tags.DeleteTag(ctx, nil, tags.DeleteTagInput{Name: "barcelona trip"})
+	require.NoError(s.T(), err)
+	assert.True(s.T(), deleteOut.Deleted)
+	_, listOut, err = get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 4, listOut.Total, "deleting a tag keeps its transactions")
+
+	_, deleteOut, err = tags.DeleteTag(ctx, nil, tags.DeleteTagInput{Name: "barcelona trip"})
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), deleteOut.Error, "not found")
+}
+
+func (s *IntegrationTestSuite) TestGetTransactions_TextSearchAndAmountRange() {
+	ctx := s.Context()
+	s.addTripTransactions(ctx)
+
+	tests := []struct {
+		name      string
+		input     get_transactions.GetTransactionsInput
+		merchants []string
+	}{
+		{"prefix in note and description", get_transactions.GetTransactionsInput{Query: util.Ptr("gift")},
+			[]string{"Amazon", "Cervecería Catalana"}},
+		{"merchant words", get_transactions.GetTransactionsInput{Query: util.Ptr("hotel arts")},
+			[]string{"Hotel Arts"}},
+		{"non-ascii", get_transactions.GetTransactionsInput{Query: util.Ptr("cervecería")},
+			[]string{"Cervecería Catalana"}},
+		{"punctuation is ignored", get_transactions.GetTransactionsInput{Query: util.Ptr("gift & (")},
+			[]string{"Amazon", "Cervecería Catalana"}},
+		{"amount range", get_transactions.GetTransactionsInput{MinEUR: util.Ptr(30.0), MaxEUR: util.Ptr(180.0)},
+			[]string{"Amazon", "Cervecería Catalana", "Vueling"}},
+		{"search and range", get_transactions.GetTransactionsInput{Query: util.Ptr("gift"), MinEUR: util.Ptr(40.0)},
+			[]string{"Cervecería Catalana"}},
+	}
+	for _, tt := range tests {
+		_, out, err := get_transactions.GetTransactions(ctx, nil, tt.input)
+		require.NoError(s.T(), err, tt.name)
+		require.Empty(s.T(), out.Error, tt.name)
+		var merchants []string
+		for _, tx := range out.Transactions {
+			merchants = append(merchants, tx.Merchant)
+		}
+		assert.ElementsMatch(s.T(), tt.merchants, merchants, tt.name)
+	}
+
+	_, out, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{MinEUR: util.Ptr(50.0), MaxEUR: util.Ptr(10.0)})
+	require.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), out.Error)
+
+	_, out, err = get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Query: util.Ptr("&&")})
+	require.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), out.Error)
+}
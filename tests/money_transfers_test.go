@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/add_transactions"
+	"personal/action/compare_periods"
+	"personal/action/get_balance"
+	"personal/action/get_spending_by_category"
+	"personal/action/get_transactions"
+	"personal/action/transfer_pairs"
+)
+
+var transferDay = time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC)
+
+// addTopUpScenario records a BOC → Revolut top-up as expense + income,
+// plus a real lunch and salary that must stay in the reports.
+func (s *IntegrationTestSuite) addTopUpScenario(ctx context.Context) add_transactions.AddTransactionsOutput {
+	_, out, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 200, Currency: "EUR", AmountEUR: 200, Account: "Bank of Cyprus",
+				Category: "shopping", Merchant: "Revolut", TransactedAt: transferDay},
+			{Type: "income", AmountOriginal: 200, Currency: "EUR", AmountEUR: 200, Account: "Revolut",
+				Category: "income", Merchant: "Top-Up", TransactedAt: transferDay.Add(26 * time.Hour)},
+			{Type: "expense", AmountOriginal: 49.99, Currency: "EUR", AmountEUR: 49.99, Account: "Bank of Cyprus",
+				Category: "food/restaurant", Merchant: "Bistro", TransactedAt: transferDay},
+			{Type: "income", AmountOriginal: 50, Currency: "EUR", AmountEUR: 50, Account: "Revolut",
+				Category: "income/refund", Merchant: "Shop", TransactedAt: transferDay},
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(out.Error)
+	return out
+}
+
+func (s *IntegrationTestSuite) TestTransfers_PairedExcludedFromReports() {
+	ctx := s.Context()
+	added := s.addTopUpScenario(ctx)
+
+	from := transferDay.AddDate(0, 0, -1)
+	to := transferDay.AddDate(0, 0, 5)
+
+	_, out, err := transfer_pairs.PairTransfers(ctx, nil, transfer_pairs.PairTransfersInput{From: &from, To: &to})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), out.Error)
+	assert.Equal(s.T(), 1, out.PairedCount)
+	require.Len(s.T(), out.Pairs, 1)
+	assert.Equal(s.T(), added.Transactions[0].ID, out.Pairs[0].Out.ID)
+	assert.Equal(s.T(), added.Transactions[1].ID, out.Pairs[0].In.ID)
+
+	_, balOut, err := get_balance.GetBalance(ctx, nil, get_balance.GetBalanceInput{From: from, To: to})
+	require.NoError(s.T(), err)
+	assert.InDelta(s.T(), 50.0, balOut.IncomeEUR, 0.001)
+	assert.InDelta(s.T(), 49.99, balOut.ExpenseEUR, 0.001)
+
+	_, spendOut, err := get_spending_by_category.GetSpendingByCategory(ctx, nil,
+		get_spending_by_category.GetSpendingByCategoryInput{From: from, To: to, Depth: 1})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), spendOut.Categories, 1)
+	assert.Equal(s.T(), "food", spendOut.Categories[0].Category)
+
+	_, cmpOut, err := compare_periods.ComparePeriods(ctx, nil, compare_periods.ComparePeriodsInput{
+		PeriodAFrom: from.AddDate(0, -1, 0), PeriodATo: from,
+		PeriodBFrom: from, PeriodBTo: to,
+	})
+	require.NoError(s.T(), err)
+	assert.InDelta(s.T(), 49.99, cmpOut.PeriodB.TotalEUR, 0.001)
+
+	// Both sides point at each other.
+	_, listOut, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{From: &from, Limit: 10})
+	require.NoError(s.T(), err)
+	peers := map[int64]*int64{}
+	for _, tx := range listOut.Transactions {
+		peers[tx.ID] = tx.TransferPeerID
+	}
+	require.NotNil(s.T(), peers[added.Transactions[0].ID])
+	assert.Equal(s.T(), added.Transactions[1].ID, *peers[added.Transactions[0].ID])
+	assert.Nil(s.T(), peers[added.Transactions[2].ID])
+}
+
+func (s *IntegrationTestSuite) TestTransfers_SameAmountOnlySuggested() {
+	ctx := s.Context()
+
+	// A dinner paid by card and an unrelated refund of the same amount on
+	// another account: neither side looks like a transfer.
+	_, added, err := add_transactions.AddTransactions(ctx, nil, add_transactions.AddTransactionsInput{
+		Transactions: []add_transactions.TransactionInput{
+			{Type: "expense", AmountOriginal: 42, Currency: "EUR", AmountEUR: 42, Account: "Bank of Cyprus",
+				Category: "food/restaurant", Merchant: "Bistro", TransactedAt: transferDay},
+			{Type: "income", AmountOriginal: 42, Currency: "EUR", AmountEUR: 42, Account: "Revolut",
+				Category: "income/refund", Merchant: "Shop", TransactedAt: transferDay.Add(time.Hour)},
+		},
+	})
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), added.Error)
+
+	from := transferDay.AddDate(0, 0, -1)
+	to := transferDay.AddDate(0, 0, 5)
+	_, out, err := transfer_pairs.PairTransfers(ctx, nil, transfer_pairs.PairTransfersInput{From: &from, To: &to})
+	require.NoError(s.T(), err)
+	assert.Zero(s.T(), out.PairedCount)
+	assert.Empty(s.T(), out.Pairs)
+	require.Len(s.T(), out.Suggestions, 1)
+	assert.Equal(s.T(), added.Transactions[0].ID, out.Suggestions[0].Out.ID)
+	assert.Equal(s.T(), added.Transactions[1].ID, out.Suggestions[0].In.ID)
+
+	_, balOut, err := get_balance.GetBalance(ctx, nil, get_balance.GetBalanceInput{From: from, To: to})
+	require.NoError(s.T(), err)
+	assert.InDelta(s.T(), 42.0, balOut.ExpenseEUR, 0.001)
+}
+
+func (s *IntegrationTestSuite) TestTransfers_DryRunAndUnpair() {
+	ctx := s.Context()
+	added := s.addTopUpScenario(ctx)
+
+	from := transferDay.AddDate(0, 0, -1)
+	to := transferDay.AddDate(0, 0, 5)
+
+	_, dry, err := transfer_pairs.PairTransfers(ctx, nil, transfer_pairs.PairTransfersInput{From: &from, To: &to, DryRun: true})
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), dry.Pairs, 1)
+	assert.Zero(s.T(), dry.PairedCount)
+
+	_, balOut, err := get_balance.GetBalance(ctx, nil, get_balance.GetBalanceInput{From: from, To: to})
+	require.NoError(s.T(), err)
+	assert.InDelta(s.T(), 250.0, balOut.IncomeEUR, 0.001)
+
+	_, out, err := transfer_pairs.PairTransfers(ctx, nil, transfer_pairs.PairTransfersInput{From: &from, To: &to})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, out.PairedCount)
+
+	_, unpairOut, err := transfer_pairs.UnpairTransfer(ctx, nil, transfer_pairs.UnpairTransferInput{ID: added.Transactions[1].ID})
+	require.NoError(s.T(), err)
+	require.True(s.T(), unpairOut.Success, unpairOut.Error)
+
+	_, balOut, err = get_balance.GetBalance(ctx, nil, get_balance.GetBalanceInput{From: from, To: to})
+	require.NoError(s.T(), err)
+	assert.InDelta(s.T(), 250.0, balOut.IncomeEUR, 0.001)
+	assert.InDelta(s.T(), 249.99, balOut.ExpenseEUR, 0.001)
+
+	_, unpairOut, err = transfer_pairs.UnpairTransfer(ctx, nil, transfer_pairs.UnpairTransferInput{ID: added.Transactions[2].ID})
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), unpairOut.Error, "not paired")
+}
+
+func (s *IntegrationTestSuite) TestTransfers_PairedOnImport() {
+	ctx := s.Context()
+	r := s.importRouter(ctx)
+
+	const bocTopUp = `Date,Description,Debit,Credit,Currency,Balance
+05/04/2026,REVOLUT**1234*,100.00,,EUR,900.00
+`
+	const revolutTopUp = `Type,Product,Started Date,Completed Date,Description,Amount,Fee,Currency,State,Balance
+TOPUP,Current,2026-04-05 10:00:00,2026-04-05 10:00:00,Top-Up by *1234,100.00,0.00,EUR,COMPLETED,100.00
+`
+
+	w := postStatement(r, "Bank of Cyprus", "", "boc.csv", bocTopUp)
+	require.Contains(s.T(), w.Body.String(), "paired transfers 0")
+
+	w = postStatement(r, "Revolut", "", "revolut.csv", revolutTopUp)
+	require.Contains(s.T(), w.Body.String(), "paired transfers 1")
+
+	account := "Revolut"
+	_, listOut, err := get_transactions.GetTransactions(ctx, nil, get_transactions.GetTransactionsInput{Account: &account, Limit: 10})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), listOut.Transactions, 1)
+	assert.NotNil(s.T(), listOut.Transactions[0].TransferPeerID)
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/progress"
+	"personal/domain"
+	"personal/util"
+)
+
+func (s *IntegrationTestSuite) TestActivityHierarchy_MilestonesAndBurndown() {
+	ctx := s.Context()
+	now := time.Now().UTC()
+	startedAt := now.AddDate(0, 0, -30).Format(time.RFC3339)
+
+	_, thesis, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Thesis", ProgressType: "project_progress", FrequencyDays: 7, StartedAt: startedAt,
+	})
+	require.NoError(s.T(), err)
+	thesisID := thesis.Activity.ID
+
+	_, review, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Literature review", ProgressType: "project_progress", FrequencyDays: 7, StartedAt: startedAt,
+		ParentID: util.Ptr(thesisID),
+	})
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), review.Activity.ParentID)
+	assert.Equal(s.T(), thesisID, *review.Activity.ParentID)
+
+	// A parent cannot become its own descendant.
+	_, _, err = progress.EditActivity(ctx, nil, progress.EditActivityInput{ActivityID: thesisID, ParentID: util.Ptr(review.Activity.ID)})
+	assert.Error(s.T(), err)
+	_, _, err = progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Orphan", ProgressType: "project_progress", FrequencyDays: 7, ParentID: util.Ptr(int64(999999)),
+	})
+	assert.Error(s.T(), err)
+
+	estimate := func(activityID int64, hours float64, at time.Time) {
+		_, _, err := progress.CreateProgressPoint(ctx, nil, progress.CreateProgressPointInput{
+			ActivityID: activityID, Value: 1, HoursLeft: &hours, ProgressAt: at.Format(time.RFC3339),
+		})
+		s.Require().NoError(err)
+	}
+	estimate(thesisID, 100, now.AddDate(0, 0, -20))
+	estimate(thesisID, 80, now.AddDate(0, 0, -10))
+	estimate(review.Activity.ID, 20, now.AddDate(0, 0, -10))
+	estimate(thesisID, 60, now.AddDate(0, 0, -1))
+	estimate(review.Activity.ID, 10, now.AddDate(0, 0, -1))
+
+	_, draft, err := progress.SaveMilestone(ctx, nil, progress.SaveMilestoneInput{
+		ActivityID: thesisID, Name: util.Ptr("First draft"), DueAt: util.Ptr(now.AddDate(0, 0, -2).Format(time.RFC3339)),
+	})
+	require.NoError(s.T(), err)
+	assert.True(s.T(), draft.Milestone.Overdue)
+	_, _, err = progress.SaveMilestone(ctx, nil, progress.SaveMilestoneInput{
+		ActivityID: thesisID, Name: util.Ptr("Defense"), DueAt: util.Ptr(now.AddDate(0, 2, 0).Format(time.RFC3339)),
+	})
+	require.NoError(s.T(), err)
+	_, proposal, err := progress.SaveMilestone(ctx, nil, progress.SaveMilestoneInput{ActivityID: thesisID, Name: util.Ptr("Proposal")})
+	require.NoError(s.T(), err)
+	_, done, err := progress.SaveMilestone(ctx, nil, progress.SaveMilestoneInput{MilestoneID: proposal.Milestone.ID, Done: util.Ptr(true)})
+	require.NoError(s.T(), err)
+	assert.True(s.T(), done.Milestone.Done)
+	assert.NotEmpty(s.T(), done.Milestone.DoneAt)
+	assert.Equal(s.T(), "Proposal", done.Milestone.Name)
+
+	_, stats, err := progress.GetActivityStats(ctx, nil, progress.GetActivityStatsInput{ActivityID: thesisID})
+	require.NoError(s.T(), err)
+
+	require.Len(s.T(), stats.SubActivities, 1)
+	assert.Equal(s.T(), review.Activity.ID, stats.SubActivities[0].ID)
+	require.NotNil(s.T(), stats.SubActivities[0].HoursLeft)
+	assert.Equal(s.T(), 10.0, *stats.SubActivities[0].HoursLeft)
+
+	require.NotNil(s.T(), stats.Milestones)
+	assert.Equal(s.T(), 3, stats.Milestones.Total)
+	assert.Equal(s.T(), 1, stats.Milestones.Done)
+	assert.Equal(s.T(), 1, stats.Milestones.Overdue)
+	require.Len(s.T(), stats.Milestones.Items, 3)
+	assert.Equal(s.T(), "First draft", stats.Milestones.Items[0].Name)
+	assert.Equal(s.T(), "Proposal", stats.Milestones.Items[2].Name)
+
+	// Parent and sub-activity estimates add up: 100, 80+20, 60+10.
+	require.NotNil(s.T(), stats.Burndown)
+	assert.Equal(s.T(), 70.0, stats.Burndown.HoursLeft)
+	require.Len(s.T(), stats.Burndown.Series, 3)
+	assert.Equal(s.T(), 100.0, stats.Burndown.Series[1].HoursLeft)
+	require.NotNil(s.T(), stats.Burndown.VelocityPerDay)
+	assert.Equal(s.T(), 2.14, *stats.Burndown.VelocityPerDay)
+	assert.NotEmpty(s.T(), stats.Burndown.ProjectedCompletion)
+
+	_, child, err := progress.GetActivityStats(ctx, nil, progress.GetActivityStatsInput{ActivityID: review.Activity.ID})
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), child.ParentID)
+	assert.Equal(s.T(), thesisID, *child.ParentID)
+	assert.Nil(s.T(), child.Milestones)
+
+	_, deleted, err := progress.DeleteMilestone(ctx, nil, progress.DeleteMilestoneInput{MilestoneID: draft.Milestone.ID})
+	require.NoError(s.T(), err)
+	assert.True(s.T(), deleted.Success)
+	_, _, err = progress.DeleteMilestone(ctx, nil, progress.DeleteMilestoneInput{MilestoneID: draft.Milestone.ID})
+	assert.Error(s.T(), err)
+}
+
+func (s *IntegrationTestSuite) TestActivityHierarchy_FutureSubActivityCounts() {
+	ctx := s.Context()
+	now := time.Now().UTC()
+
+	_, thesis, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Thesis", ProgressType: "project_progress", FrequencyDays: 7,
+		StartedAt: now.AddDate(0, 0, -30).Format(time.RFC3339),
+	})
+	require.NoError(s.T(), err)
+	thesisID := thesis.Activity.ID
+
+	// Scheduled to start later, but already estimated.
+	_, defense, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Defense prep", ProgressType: "project_progress", FrequencyDays: 7,
+		StartedAt: now.AddDate(0, 0, 10).Format(time.RFC3339), ParentID: util.Ptr(thesisID),
+	})
+	require.NoError(s.T(), err)
+
+	for _, p := range []domain.ActivityPoint{
+		{ActivityID: thesisID, HoursLeft: util.Ptr(40.0), ProgressAt: now.AddDate(0, 0, -1)},
+		{ActivityID: defense.Activity.ID, HoursLeft: util.Ptr(15.0), ProgressAt: now.AddDate(0, 0, -1)},
+	} {
+		p.UserID, p.Value = s.UserID(), 1
+		_, err := s.Repo().CreateProgress(ctx, &p)
+		require.NoError(s.T(), err)
+	}
+
+	_, stats, err := progress.GetActivityStats(ctx, nil, progress.GetActivityStatsInput{ActivityID: thesisID})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), stats.SubActivities, 1)
+	assert.Equal(s.T(), defense.Activity.ID, stats.SubActivities[0].ID)
+	require.NotNil(s.T(), stats.Burndown)
+	assert.Equal(s.T(), 55.0, stats.Burndown.HoursLeft)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/web/progress", nil).WithContext(ctx)
+	progress.DashboardWebHandler(c)
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "55h left")
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/progress"
+	"personal/domain"
+	"personal/util"
+)
+
+func (s *IntegrationTestSuite) createMoodActivity(name string) int64 {
+	id, err := s.Repo().CreateActivity(s.Context(), &domain.Activity{
+		UserID:        s.UserID(),
+		Name:          name,
+		ProgressType:  domain.ProgressTypeMood,
+		FrequencyDays: 1,
+		StartedAt:     time.Now().AddDate(0, 0, -10),
+	})
+	s.Require().NoError(err)
+	return id
+}
+
+func (s *IntegrationTestSuite) lastPointAt(activityID int64) *time.Time {
+	a, err := s.Repo().GetActivity(s.Context(), activityID, s.UserID())
+	s.Require().NoError(err)
+	s.Require().NotNil(a)
+	return a.LastPointAt
+}
+
+func (s *IntegrationTestSuite) TestEditProgressPoint() {
+	ctx := s.Context()
+	moodID := s.createMoodActivity("Mood")
+	gymID := s.createMoodActivity("Gym")
+
+	now := time.Now().UTC().Truncate(time.Second)
+	older, err := s.Repo().CreateProgress(ctx, &domain.ActivityPoint{ActivityID: moodID, UserID: s.UserID(), Value: 1, ProgressAt: now.AddDate(0, 0, -2)})
+	require.NoError(s.T(), err)
+	latest, err := s.Repo().CreateProgress(ctx, &domain.ActivityPoint{ActivityID: moodID, UserID: s.UserID(), Value: 2, Note: "gym", ProgressAt: now})
+	require.NoError(s.T(), err)
+
+	_, out, err := progress.EditProgressPoint(ctx, nil, progress.EditProgressPointInput{ProgressID: older, Value: util.Ptr(-1), Note: util.Ptr("rough day")})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), -1, out.Progress.Value)
+	assert.Equal(s.T(), "rough day", out.Progress.Note)
+
+	// Moving the latest point to another activity moves last_point_at with it.
+	_, out, err = progress.EditProgressPoint(ctx, nil, progress.EditProgressPointInput{ProgressID: latest, ActivityID: &gymID})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), gymID, out.ActivityID)
+	assert.WithinDuration(s.T(), now.AddDate(0, 0, -2), *s.lastPointAt(moodID), time.Second)
+	assert.WithinDuration(s.T(), now, *s.lastPointAt(gymID), time.Second)
+
+	// Backdating the only point of an activity moves last_point_at back.
+	_, _, err = progress.EditProgressPoint(ctx, nil, progress.EditProgressPointInput{
+		ProgressID: latest, ProgressAt: util.Ptr(now.AddDate(0, 0, -5).Format(time.RFC3339)),
+	})
+	require.NoError(s.T(), err)
+	assert.WithinDuration(s.T(), now.AddDate(0, 0, -5), *s.lastPointAt(gymID), time.Second)
+
+	stats, err := s.Repo().GetTrendStats(ctx, moodID, s.UserID(), now.AddDate(0, 0, -30), now.Add(time.Hour))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, stats.Count)
+	assert.InDelta(s.T(), -1, stats.Average, 0.001)
+
+	tests := []struct {
+		name   string
+		input  progress.EditProgressPointInput
+		errMsg string
+	}{
+		{"no fields", progress.EditProgressPointInput{ProgressID: older}, "at least one field"},
+		{"value out of range", progress.EditProgressPointInput{ProgressID: older, Value: util.Ptr(3)}, "between -2 and +2"},
+		{"unknown point", progress.EditProgressPointInput{ProgressID: latest + 1000, Value: util.Ptr(0)}, "not found"},
+		{"unknown activity", progress.EditProgressPointInput{ProgressID: older, ActivityID: util.Ptr(gymID + 1000)}, "activity not found"},
+	}
+	for _, tt := range tests {
+		_, _, err := progress.EditProgressPoint(ctx, nil, tt.input)
+		assert.ErrorContains(s.T(), err, tt.errMsg, tt.name)
+	}
+}
+
+func (s *IntegrationTestSuite) TestEditProgressPoint_MoveOnlyPoint() {
+	ctx := s.Context()
+	moodID := s.createMoodActivity("Mood")
+	gymID := s.createMoodActivity("Gym")
+
+	now := time.Now().UTC().Truncate(time.Second)
+	_, err := s.Repo().CreateProgress(ctx, &domain.ActivityPoint{ActivityID: gymID, UserID: s.UserID(), Value: 0, ProgressAt: now.AddDate(0, 0, -3)})
+	require.NoError(s.T(), err)
+	only, err := s.Repo().CreateProgress(ctx, &domain.ActivityPoint{ActivityID: moodID, UserID: s.UserID(), Value: 1, ProgressAt: now.AddDate(0, 0, -1)})
+	require.NoError(s.T(), err)
+	assert.WithinDuration(s.T(), now.AddDate(0, 0, -1), *s.lastPointAt(moodID), time.Second)
+
+	// The source activity is left without points, the target gets a newer one.
+	_, _, err = progress.EditProgressPoint(ctx, nil, progress.EditProgressPointInput{ProgressID: only, ActivityID: &gymID})
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), s.lastPointAt(moodID))
+	assert.WithinDuration(s.T(), now.AddDate(0, 0, -1), *s.lastPointAt(gymID), time.Second)
+
+	// Moving it back restores both.
+	_, _, err = progress.EditProgressPoint(ctx, nil, progress.EditProgressPointInput{ProgressID: only, ActivityID: &moodID})
+	require.NoError(s.T(), err)
+	assert.WithinDuration(s.T(), now.AddDate(0, 0, -1), *s.lastPointAt(moodID), time.Second)
+	assert.WithinDuration(s.T(), now.AddDate(0, 0, -3), *s.lastPointAt(gymID), time.Second)
+}
+
+func (s *IntegrationTestSuite) TestDeleteProgressPoint() {
+	ctx := s.Context()
+	moodID := s.createMoodActivity("Mood")
+
+	now := time.Now().UTC().Truncate(time.Second)
+	older, err := s.Repo().CreateProgress(ctx, &domain.ActivityPoint{ActivityID: moodID, UserID: s.UserID(), Value: 1, ProgressAt: now.AddDate(0, 0, -1)})
+	require.NoError(s.T(), err)
+	latest, err := s.Repo().CreateProgress(ctx, &domain.ActivityPoint{ActivityID: moodID, UserID: s.UserID(), Value: 2, ProgressAt: now})
+	require.NoError(s.T(), err)
+
+	_, out, err := progress.DeleteProgressPoint(ctx, nil, progress.DeleteProgressPointInput{ProgressID: latest})
+	require.NoError(s.T(), err)
+	assert.True(s.T(), out.Success)
+	assert.WithinDuration(s.T(), now.AddDate(0, 0, -1), *s.lastPointAt(moodID), time.Second)
+
+	_, _, err = progress.DeleteProgressPoint(ctx, nil, progress.DeleteProgressPointInput{ProgressID: latest})
+	assert.ErrorContains(s.T(), err, "not found")
+
+	_, _, err = progress.DeleteProgressPoint(ctx, nil, progress.DeleteProgressPointInput{ProgressID: older})
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), s.lastPointAt(moodID))
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/progress"
+	"personal/util"
+)
+
+func (s *IntegrationTestSuite) TestProgressQuantity_AttainmentAndStreaks() {
+	ctx := s.Context()
+	now := time.Now().UTC()
+	startedAt := now.AddDate(0, 0, -14)
+	start := startedAt.Truncate(24 * time.Hour)
+
+	_, act, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Reading", ProgressType: "habit_progress", FrequencyDays: 1,
+		Unit: "pages", Target: util.Ptr(140.0), TargetDays: 7,
+		StartedAt: startedAt.Format(time.RFC3339),
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "pages", act.Activity.Unit)
+
+	// Week 1: 150 pages (met), week 2: 70 + 80 (met), current week: 40.
+	log := func(at time.Time, pages float64) int64 {
+		_, out, err := progress.CreateProgressPoint(ctx, nil, progress.CreateProgressPointInput{
+			ActivityID: act.Activity.ID, Value: 1, Quantity: &pages, ProgressAt: at.Format(time.RFC3339),
+		})
+		s.Require().NoError(err)
+		return out.Progress.ID
+	}
+	log(start.AddDate(0, 0, 1), 150)
+	log(start.AddDate(0, 0, 8), 70)
+	log(start.AddDate(0, 0, 9), 80)
+	current := log(now, 40)
+
+	_, stats, err := progress.GetActivityStats(ctx, nil, progress.GetActivityStatsInput{ActivityID: act.Activity.ID})
+	require.NoError(s.T(), err)
+	q := stats.Quantity
+	require.NotNil(s.T(), q)
+	assert.Equal(s.T(), 7, q.PeriodDays)
+	assert.Equal(s.T(), 340.0, q.Total)
+	assert.Equal(s.T(), 4, q.Count)
+	assert.Equal(s.T(), 40.0, q.CurrentPeriod.Total)
+	require.NotNil(s.T(), q.CurrentPeriod.AttainmentPct)
+	assert.Equal(s.T(), 28.6, *q.CurrentPeriod.AttainmentPct)
+	assert.False(s.T(), q.CurrentPeriod.Met)
+	assert.Equal(s.T(), 2, q.PeriodsCompleted)
+	assert.Equal(s.T(), 2, q.PeriodsMet)
+	require.NotNil(s.T(), q.AvgAttainmentPct)
+	assert.Equal(s.T(), 100.0, *q.AvgAttainmentPct)
+	assert.Equal(s.T(), 2, q.CurrentStreak)
+	assert.Equal(s.T(), 2, q.LongestStreak)
+	require.NotNil(s.T(), stats.TrendOverall.QuantityTotal)
+	assert.Equal(s.T(), 340.0, *stats.TrendOverall.QuantityTotal)
+	require.NotNil(s.T(), stats.Last3Points[0].Quantity)
+	assert.Equal(s.T(), 40.0, *stats.Last3Points[0].Quantity)
+
+	// Reaching the target in the current week extends the streak.
+	_, _, err = progress.EditProgressPoint(ctx, nil, progress.EditProgressPointInput{ProgressID: current, Quantity: util.Ptr(140.0)})
+	require.NoError(s.T(), err)
+	_, stats, err = progress.GetActivityStats(ctx, nil, progress.GetActivityStatsInput{ActivityID: act.Activity.ID})
+	require.NoError(s.T(), err)
+	assert.True(s.T(), stats.Quantity.CurrentPeriod.Met)
+	assert.Equal(s.T(), 3, stats.Quantity.CurrentStreak)
+
+	// A negative quantity clears it on edit and is rejected on create.
+	_, edited, err := progress.EditProgressPoint(ctx, nil, progress.EditProgressPointInput{ProgressID: current, Quantity: util.Ptr(-1.0)})
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), edited.Progress.Quantity)
+	_, _, err = progress.CreateProgressPoint(ctx, nil, progress.CreateProgressPointInput{ActivityID: act.Activity.ID, Value: 1, Quantity: util.Ptr(-5.0)})
+	assert.ErrorContains(s.T(), err, "quantity must not be negative")
+}
+
+func (s *IntegrationTestSuite) TestProgressQuantity_ActivitySettings() {
+	ctx := s.Context()
+
+	_, act, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{Name: "Mood", ProgressType: "mood", FrequencyDays: 1})
+	require.NoError(s.T(), err)
+	_, stats, err := progress.GetActivityStats(ctx, nil, progress.GetActivityStatsInput{ActivityID: act.Activity.ID})
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), stats.Quantity)
+	assert.Nil(s.T(), stats.TrendOverall.QuantityTotal)
+
+	_, _, err = progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Water", ProgressType: "habit_progress", FrequencyDays: 1, Unit: "ml", Target: util.Ptr(-1.0),
+	})
+	assert.ErrorContains(s.T(), err, "target must be positive")
+
+	_, edited, err := progress.EditActivity(ctx, nil, progress.EditActivityInput{
+		ActivityID: act.Activity.ID, Unit: util.Ptr("ml"), Target: util.Ptr(2000.0),
+	})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "ml", edited.Activity.Unit)
+	require.NotNil(s.T(), edited.Activity.Target)
+
+	_, edited, err = progress.EditActivity(ctx, nil, progress.EditActivityInput{ActivityID: act.Activity.ID, Target: util.Ptr(0.0)})
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), edited.Activity.Target)
+
+	_, list, err := progress.GetActivityList(ctx, nil, progress.GetActivityListInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), list.Activities, 1)
+	assert.Equal(s.T(), "ml", list.Activities[0].Unit)
+	assert.Equal(s.T(), 1, list.Activities[0].TargetDays)
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/progress"
+	"personal/domain"
+	"personal/gateways"
+	"personal/util"
+)
+
+func (s *IntegrationTestSuite) saveSleepScale() progress.ScaleOutput {
+	_, out, err := progress.SaveProgressScale(s.Context(), nil, progress.SaveProgressScaleInput{
+		Name:     "sleep quality",
+		MinValue: 1,
+		MaxValue: 10,
+		Levels: []domain.ScaleLevel{
+			{Value: 10, Word: "rested", Emoji: "🛌"},
+			{Value: 5, Word: "okay", Emoji: "😐"},
+			{Value: 1, Word: "wrecked", Emoji: "🧟"},
+		},
+		Metaphors: []domain.ScaleMetaphor{
+			{Name: "sleep as battery", Levels: []domain.ScaleLevel{{Value: 10, Word: "full"}, {Value: 1, Word: "empty"}}},
+		},
+	})
+	s.Require().NoError(err)
+	return out.Scale
+}
+
+func (s *IntegrationTestSuite) TestProgressScale_CustomRangeAndLevels() {
+	ctx := s.Context()
+	scale := s.saveSleepScale()
+
+	_, act, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Sleep", ProgressType: "mood", FrequencyDays: 1, ScaleID: &scale.ID,
+		StartedAt: time.Now().AddDate(0, 0, -3).Format(time.RFC3339),
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), scale.ID, *act.Activity.ScaleID)
+
+	_, point, err := progress.CreateProgressPoint(ctx, nil, progress.CreateProgressPointInput{ActivityID: act.Activity.ID, Value: 10})
+	require.NoError(s.T(), err)
+	_, _, err = progress.CreateProgressPoint(ctx, nil, progress.CreateProgressPointInput{ActivityID: act.Activity.ID, Value: -2})
+	assert.ErrorContains(s.T(), err, "between +1 and +10")
+
+	_, stats, err := progress.GetActivityStats(ctx, nil, progress.GetActivityStatsInput{ActivityID: act.Activity.ID})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, stats.Scale.MinValue)
+	assert.Equal(s.T(), 10, stats.Scale.MaxValue)
+	require.Len(s.T(), stats.Last3Points, 1)
+	assert.Equal(s.T(), "rested", stats.Last3Points[0].Level)
+	assert.Equal(s.T(), "🛌", stats.Last3Points[0].Emoji)
+
+	_, examples, err := progress.GetProgressTypeExamples(ctx, nil, progress.ProgressTypeExamplesInput{})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), examples.CustomScales, 1)
+	assert.Equal(s.T(), scale.ID, examples.CustomScales[0].ScaleID)
+	require.Len(s.T(), examples.CustomScales[0].Mappings, 1)
+	assert.Equal(s.T(), "sleep as battery", examples.CustomScales[0].Mappings[0].MappingName)
+
+	// The dashboard shows the emoji of the custom level.
+	router := gin.New()
+	router.GET("/web/progress", func(c *gin.Context) {
+		ctx := gateways.WithUserID(gateways.WithDB(c.Request.Context(), s.Repo()), s.UserID())
+		c.Request = c.Request.WithContext(ctx)
+	}, progress.DashboardWebHandler)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/web/progress", nil))
+	require.Equal(s.T(), http.StatusOK, w.Code)
+	assert.Contains(s.T(), w.Body.String(), "🛌")
+
+	// A scale in use cannot be deleted; back on the built-in scale it can.
+	_, _, err = progress.DeleteProgressScale(ctx, nil, progress.DeleteProgressScaleInput{ScaleID: scale.ID})
+	assert.ErrorContains(s.T(), err, "used by 1 activities")
+
+	// Neither a narrower range nor another scale may strand the point at 10.
+	_, _, err = progress.SaveProgressScale(ctx, nil, progress.SaveProgressScaleInput{Name: "sleep quality", MinValue: 1, MaxValue: 5})
+	assert.ErrorContains(s.T(), err, "outside +1..+5")
+	_, _, err = progress.EditActivity(ctx, nil, progress.EditActivityInput{ActivityID: act.Activity.ID, ScaleID: util.Ptr(int64(0))})
+	assert.ErrorContains(s.T(), err, "outside -2..+2")
+
+	_, _, err = progress.EditProgressPoint(ctx, nil, progress.EditProgressPointInput{ProgressID: point.Progress.ID, Value: util.Ptr(2)})
+	require.NoError(s.T(), err)
+	_, _, err = progress.EditActivity(ctx, nil, progress.EditActivityInput{ActivityID: act.Activity.ID, ScaleID: util.Ptr(int64(0))})
+	require.NoError(s.T(), err)
+	_, _, err = progress.DeleteProgressScale(ctx, nil, progress.DeleteProgressScaleInput{ScaleID: scale.ID})
+	require.NoError(s.T(), err)
+}
+
+func (s *IntegrationTestSuite) TestProgressScale_Validation() {
+	ctx := s.Context()
+
+	tests := []struct {
+		name   string
+		input  progress.SaveProgressScaleInput
+		errMsg string
+	}{
+		{"empty range", progress.SaveProgressScaleInput{Name: "x", MinValue: 3, MaxValue: 3}, "below max_value"},
+		{"level outside", progress.SaveProgressScaleInput{Name: "x", MinValue: 0, MaxValue: 5,
+			Levels: []domain.ScaleLevel{{Value: 6, Word: "too much"}}}, "outside 0..5"},
+		{"level twice", progress.SaveProgressScaleInput{Name: "x", MinValue: 0, MaxValue: 5,
+			Levels: []domain.ScaleLevel{{Value: 1, Word: "a"}, {Value: 1, Word: "b"}}}, "named twice"},
+		{"missing name", progress.SaveProgressScaleInput{MinValue: 0, MaxValue: 5}, "name is required"},
+	}
+	for _, tt := range tests {
+		_, _, err := progress.SaveProgressScale(ctx, nil, tt.input)
+		assert.ErrorContains(s.T(), err, tt.errMsg, tt.name)
+	}
+
+	// Saving under the same name replaces the definition.
+	first := s.saveSleepScale()
+	_, second, err := progress.SaveProgressScale(ctx, nil, progress.SaveProgressScaleInput{Name: "sleep quality", MinValue: 0, MaxValue: 5})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), first.ID, second.Scale.ID)
+	assert.Equal(s.T(), 5, second.Scale.MaxValue)
+
+	_, _, err = progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Sleep", ProgressType: "mood", FrequencyDays: 1, ScaleID: util.Ptr(first.ID + 1000),
+	})
+	assert.ErrorContains(s.T(), err, "not found")
+}
@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"context"
+	"strings"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -8,6 +10,8 @@ import (
 
 	"personal/action/progress"
 	"personal/domain"
+	"personal/gateways"
+	"personal/util"
 )
 
 func (s *IntegrationTestSuite) TestSearchProgressNotes_ByVariants() {
@@ -380,3 +384,301 @@ func (s *IntegrationTestSuite) TestSearchProgressNotes_UserIsolation() {
 	require.Len(s.T(), output.Results, 1)
 	assert.Equal(s.T(), "gym from user1", output.Results[0].Note)
 }
+
+func (s *IntegrationTestSuite) TestSearchProgressNotes_CursorPaging() {
+	ctx := s.Context()
+	db := s.Repo()
+	userID := s.UserID()
+
+	activityID, err := db.CreateActivity(ctx, &domain.Activity{
+		UserID:        userID,
+		Name:          "Journal",
+		ProgressType:  domain.ProgressTypeMood,
+		FrequencyDays: 1,
+		StartedAt:     time.Now(),
+	})
+	require.NoError(s.T(), err)
+
+	notes := []string{"gym workout", "gym", "workout", "gym again"}
+	for i, note := range notes {
+		_, err = db.CreateProgress(ctx, &domain.ActivityPoint{
+			ActivityID: activityID,
+			UserID:     userID,
+			Value:      1,
+			Note:       note,
+			ProgressAt: time.Now().Add(-time.Duration(i) * time.Hour),
+		})
+		require.NoError(s.T(), err)
+	}
+
+	input := progress.SearchProgressNotesInput{QueryVariants: []string{"gym", "workout"}, Limit: 2}
+	_, page1, err := progress.SearchProgressNotes(ctx, nil, input)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page1.Results, 2)
+	assert.Equal(s.T(), "gym workout", page1.Results[0].Note)
+	assert.Equal(s.T(), 2, page1.Results[0].MatchCount)
+	assert.Equal(s.T(), "gym", page1.Results[1].Note)
+	require.NotEmpty(s.T(), page1.NextCursor)
+
+	input.Cursor = page1.NextCursor
+	_, page2, err := progress.SearchProgressNotes(ctx, nil, input)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page2.Results, 2)
+	assert.Equal(s.T(), "workout", page2.Results[0].Note)
+	assert.Equal(s.T(), "gym again", page2.Results[1].Note)
+	assert.Empty(s.T(), page2.NextCursor)
+}
+
+func (s *IntegrationTestSuite) TestSearchProgressNotes_Stemming() {
+	ctx := s.Context()
+	db := s.Repo()
+	userID := s.UserID()
+
+	activityID, err := db.CreateActivity(ctx, &domain.Activity{
+		UserID:        userID,
+		Name:          "Sport",
+		ProgressType:  domain.ProgressTypeHabitProgress,
+		FrequencyDays: 1,
+		StartedAt:     time.Now(),
+	})
+	require.NoError(s.T(), err)
+
+	for _, note := range []string{"Сегодня были тяжёлые тренировки", "I was running all morning", "stayed home"} {
+		_, err = db.CreateProgress(ctx, &domain.ActivityPoint{
+			ActivityID: activityID,
+			UserID:     userID,
+			Value:      1,
+			Note:       note,
+			ProgressAt: time.Now(),
+		})
+		require.NoError(s.T(), err)
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "тренировка", want: "Сегодня были тяжёлые тренировки"},
+		{query: "runs", want: "I was running all morning"},
+	}
+	for _, tt := range tests {
+		_, output, err := progress.SearchProgressNotes(ctx, nil, progress.SearchProgressNotesInput{QueryVariants: []string{tt.query}})
+		require.NoError(s.T(), err)
+		require.Len(s.T(), output.Results, 1, tt.query)
+		assert.Equal(s.T(), tt.want, output.Results[0].Note)
+		assert.Greater(s.T(), output.Results[0].Rank, 0.0)
+		assert.False(s.T(), output.Semantic)
+	}
+}
+
+// conceptEmbedder stands in for a local embedding model: one dimension per
+// concept, so notes about the same concept get the same vector whatever the
+// words.
+type conceptEmbedder struct{}
+
+var noteConcepts = [][]string{
+	{"burnout", "exhausted", "overwhelmed", "tired"},
+	{"gym", "workout", "training"},
+}
+
+func (conceptEmbedder) Model() string { return "test-concepts" }
+
+func (conceptEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		v := make([]float32, len(noteConcepts)+1)
+		v[len(noteConcepts)] = 1 // no concept: orthogonal to all of them
+		for c, words := range noteConcepts {
+			for _, w := range words {
+				if strings.Contains(strings.ToLower(text), w) {
+					v[c], v[len(noteConcepts)] = 1, 0
+				}
+			}
+		}
+		vectors[i] = v
+	}
+	return vectors, nil
+}
+
+func (s *IntegrationTestSuite) TestSearchProgressNotes_Semantic() {
+	ctx := s.Context()
+	db := s.Repo()
+	userID := s.UserID()
+
+	activityID, err := db.CreateActivity(ctx, &domain.Activity{
+		UserID:        userID,
+		Name:          "Daily Mood",
+		ProgressType:  domain.ProgressTypeMood,
+		FrequencyDays: 1,
+		StartedAt:     time.Now(),
+	})
+	require.NoError(s.T(), err)
+
+	tiredID, err := db.CreateProgress(ctx, &domain.ActivityPoint{
+		ActivityID: activityID,
+		UserID:     userID,
+		Value:      -2,
+		Note:       "exhausted and overwhelmed at work",
+		ProgressAt: time.Now(),
+	})
+	require.NoError(s.T(), err)
+	_, err = db.CreateProgress(ctx, &domain.ActivityPoint{
+		ActivityID: activityID,
+		UserID:     userID,
+		Value:      2,
+		Note:       "great gym session",
+		ProgressAt: time.Now(),
+	})
+	require.NoError(s.T(), err)
+
+	input := progress.SearchProgressNotesInput{QueryVariants: []string{"burnout"}}
+
+	// Full-text search alone does not know the words are related
+	_, output, err := progress.SearchProgressNotes(ctx, nil, input)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), output.Semantic)
+	assert.Empty(s.T(), output.Results)
+
+	semanticCtx := gateways.WithEmbedder(ctx, conceptEmbedder{})
+	_, output, err = progress.SearchProgressNotes(semanticCtx, nil, input)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), output.Semantic)
+	require.Len(s.T(), output.Results, 1)
+	assert.Equal(s.T(), tiredID, output.Results[0].ID)
+	assert.Equal(s.T(), 1, output.Results[0].MatchCount)
+	require.NotNil(s.T(), output.Results[0].Similarity)
+	assert.InDelta(s.T(), 1.0, *output.Results[0].Similarity, 1e-6)
+
+	// An edited note is embedded again before the next search
+	_, _, err = progress.EditProgressPoint(ctx, nil, progress.EditProgressPointInput{ProgressID: tiredID, Note: util.Ptr("calm day at home")})
+	require.NoError(s.T(), err)
+	_, output, err = progress.SearchProgressNotes(semanticCtx, nil, input)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), output.Results)
+
+	// Notes that are indexed already are not embedded again
+	pending, err := db.ListNotesToEmbed(ctx, userID, conceptEmbedder{}.Model(), 10)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), pending)
+
+	_, output, err = progress.SearchProgressNotes(semanticCtx, nil, progress.SearchProgressNotesInput{
+		QueryVariants: []string{"burnout"},
+		MinSimilarity: util.Ptr(1.5),
+	})
+	require.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), output.Error)
+}
+
+func (s *IntegrationTestSuite) TestSearchProgressNotes_CursorKeepsRanking() {
+	ctx := s.Context()
+	db := s.Repo()
+	userID := s.UserID()
+
+	activityID, err := db.CreateActivity(ctx, &domain.Activity{
+		UserID:        userID,
+		Name:          "Journal",
+		ProgressType:  domain.ProgressTypeMood,
+		FrequencyDays: 1,
+		StartedAt:     time.Now(),
+	})
+	require.NoError(s.T(), err)
+	addNote := func(note string, hoursAgo int) {
+		_, err := db.CreateProgress(ctx, &domain.ActivityPoint{
+			ActivityID: activityID,
+			UserID:     userID,
+			Value:      1,
+			Note:       note,
+			ProgressAt: time.Now().Add(-time.Duration(hoursAgo) * time.Hour),
+		})
+		require.NoError(s.T(), err)
+	}
+	addNote("gym session", 1)
+	addNote("gym again", 2)
+	addNote("training day", 3)
+
+	semanticCtx := gateways.WithEmbedder(ctx, conceptEmbedder{})
+	input := progress.SearchProgressNotesInput{QueryVariants: []string{"gym"}, Limit: 1}
+	_, page1, err := progress.SearchProgressNotes(semanticCtx, nil, input)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), page1.Semantic)
+	require.NotEmpty(s.T(), page1.NextCursor)
+
+	// Later pages do not index notes added in between
+	addNote("workout", 4)
+	input.Cursor = page1.NextCursor
+	_, page2, err := progress.SearchProgressNotes(semanticCtx, nil, input)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), page2.Error)
+	assert.True(s.T(), page2.Semantic)
+	require.Len(s.T(), page2.Results, 1)
+	pending, err := db.ListNotesToEmbed(ctx, userID, conceptEmbedder{}.Model(), 10)
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), pending, 1)
+
+	// A semantic cursor cannot continue as a full-text search
+	_, output, err := progress.SearchProgressNotes(ctx, nil, input)
+	require.NoError(s.T(), err)
+	assert.Contains(s.T(), output.Error, "test-concepts")
+	assert.Empty(s.T(), output.Results)
+
+	// A full-text cursor stays full-text when an embedder shows up
+	input.Cursor = ""
+	_, plain, err := progress.SearchProgressNotes(ctx, nil, input)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), plain.Semantic)
+	require.NotEmpty(s.T(), plain.NextCursor)
+	input.Cursor = plain.NextCursor
+	_, output, err = progress.SearchProgressNotes(semanticCtx, nil, input)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), output.Error)
+	assert.False(s.T(), output.Semantic)
+	require.Len(s.T(), output.Results, 1)
+	assert.Equal(s.T(), "gym again", output.Results[0].Note)
+}
+
+func (s *IntegrationTestSuite) TestSearchProgressNotes_SubstringMatchesCountFirst() {
+	ctx := s.Context()
+	db := s.Repo()
+	userID := s.UserID()
+
+	activityID, err := db.CreateActivity(ctx, &domain.Activity{
+		UserID:        userID,
+		Name:          "Workout",
+		ProgressType:  domain.ProgressTypeHabitProgress,
+		FrequencyDays: 1,
+		StartedAt:     time.Now(),
+	})
+	require.NoError(s.T(), err)
+
+	for i, note := range []string{"pilates and yoga", "swim session", "long swim"} {
+		_, err = db.CreateProgress(ctx, &domain.ActivityPoint{
+			ActivityID: activityID,
+			UserID:     userID,
+			Value:      1,
+			Note:       note,
+			ProgressAt: time.Now().Add(-time.Duration(i) * time.Hour),
+		})
+		require.NoError(s.T(), err)
+	}
+
+	// "ilate" and "oga" only hit the first note as substrings, so it has no
+	// full-text rank, but two variants beat the full-text hits of "swim".
+	input := progress.SearchProgressNotesInput{QueryVariants: []string{"ilate", "oga", "swim"}, Limit: 2}
+	_, page1, err := progress.SearchProgressNotes(ctx, nil, input)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page1.Results, 2)
+	assert.Equal(s.T(), "pilates and yoga", page1.Results[0].Note)
+	assert.Equal(s.T(), 2, page1.Results[0].MatchCount)
+	assert.Zero(s.T(), page1.Results[0].Rank)
+	assert.Equal(s.T(), 1, page1.Results[1].MatchCount)
+	assert.Positive(s.T(), page1.Results[1].Rank)
+	require.NotEmpty(s.T(), page1.NextCursor)
+
+	input.Cursor = page1.NextCursor
+	_, page2, err := progress.SearchProgressNotes(ctx, nil, input)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), page2.Results, 1)
+	assert.Equal(s.T(), 1, page2.Results[0].MatchCount)
+	assert.NotEqual(s.T(), page1.Results[1].ID, page2.Results[0].ID)
+	assert.Empty(s.T(), page2.NextCursor)
+}
@@ -63,7 +63,7 @@ func (s *IntegrationTestSuite) SetupSuite() {
 
 	s.pgContainer, err = postgres.Run(
 		ctx,
-		"postgres:16-alpine",
+		"pgvector/pgvector:pg16",
 		postgres.WithDatabase("test-db"),
 		postgres.WithUsername("user"),
 		postgres.WithPassword("password"),
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"personal/action/progress"
+	"personal/domain"
+)
+
+func (s *IntegrationTestSuite) TestGetWeeklyReview() {
+	ctx := s.Context()
+	// Week of Monday 2026-03-02; the previous week starts 2026-02-23.
+	week := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	startedAt := week.AddDate(0, -1, 0).Format(time.RFC3339)
+
+	_, mood, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Mood", ProgressType: "mood", FrequencyDays: 1, StartedAt: startedAt,
+	})
+	require.NoError(s.T(), err)
+	_, reading, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Reading", ProgressType: "habit_progress", FrequencyDays: 7, StartedAt: startedAt, Unit: "pages",
+	})
+	require.NoError(s.T(), err)
+	_, project, err := progress.CreateActivity(ctx, nil, progress.CreateActivityInput{
+		Name: "Thesis", ProgressType: "project_progress", FrequencyDays: 7, StartedAt: startedAt,
+	})
+	require.NoError(s.T(), err)
+
+	addPoint := func(activityID int64, value int, at time.Time, note string, quantity *float64) {
+		_, _, err := progress.CreateProgressPoint(ctx, nil, progress.CreateProgressPointInput{
+			ActivityID: activityID, Value: value, Note: note, Quantity: quantity,
+			ProgressAt: at.Format(time.RFC3339),
+		})
+		s.Require().NoError(err)
+	}
+	pages := func(v float64) *float64 { return &v }
+
+	addPoint(mood.Activity.ID, -1, week.AddDate(0, 0, -5), "", nil)
+	addPoint(mood.Activity.ID, 2, week.Add(10*time.Hour), "great start", nil)
+	addPoint(mood.Activity.ID, -2, week.AddDate(0, 0, 3), "argument at work", nil)
+	addPoint(mood.Activity.ID, 1, week.AddDate(0, 0, 6), "", nil)
+	addPoint(reading.Activity.ID, 1, week.AddDate(0, 0, 2), "", pages(40))
+	addPoint(reading.Activity.ID, 0, week.AddDate(0, 0, 5), "", pages(25))
+	// Next week is out of range.
+	addPoint(mood.Activity.ID, 2, week.AddDate(0, 0, 7), "", nil)
+
+	_, _, err = progress.FinishActivity(ctx, nil, progress.FinishActivityInput{
+		ActivityID: project.Activity.ID, EndedAt: week.AddDate(0, 0, 4).Format(time.RFC3339),
+	})
+	require.NoError(s.T(), err)
+
+	_, out, err := progress.GetWeeklyReview(ctx, nil, progress.GetWeeklyReviewInput{Week: "2026-03-04", IncludeWorkouts: true})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), week.Format(time.RFC3339), out.From)
+	assert.Equal(s.T(), week.AddDate(0, 0, 7).Format(time.RFC3339), out.To)
+
+	byID := map[int64]progress.WeeklyActivityOutput{}
+	for _, a := range out.Activities {
+		byID[a.ActivityID] = a
+	}
+	require.Len(s.T(), byID, 3)
+
+	m := byID[mood.Activity.ID]
+	assert.Equal(s.T(), 3, m.Count)
+	require.Len(s.T(), m.Points, 3)
+	assert.Equal(s.T(), "great start", m.Points[0].Note)
+	require.NotNil(s.T(), m.Average)
+	assert.Equal(s.T(), 0.33, *m.Average)
+	assert.Equal(s.T(), 1, m.PreviousCount)
+	require.NotNil(s.T(), m.Delta)
+	assert.Equal(s.T(), 1.33, *m.Delta)
+	assert.Equal(s.T(), domain.TrendUp, m.Trend)
+
+	r := byID[reading.Activity.ID]
+	require.NotNil(s.T(), r.QuantityTotal)
+	assert.Equal(s.T(), 65.0, *r.QuantityTotal)
+	assert.Empty(s.T(), r.Trend)
+
+	assert.Equal(s.T(), 0, byID[project.Activity.ID].Count)
+	require.Len(s.T(), out.Finished, 1)
+	assert.Equal(s.T(), project.Activity.ID, out.Finished[0].ActivityID)
+
+	require.Len(s.T(), out.Highs, 3)
+	assert.Equal(s.T(), "great start", out.Highs[0].Note)
+	require.Len(s.T(), out.Lows, 1)
+	assert.Equal(s.T(), "argument at work", out.Lows[0].Note)
+
+	require.NotNil(s.T(), out.Average)
+	assert.Equal(s.T(), 0.4, *out.Average)
+	assert.Equal(s.T(), domain.TrendUp, out.Trend)
+
+	assert.Nil(s.T(), out.Nutrition)
+	require.NotNil(s.T(), out.Workouts)
+	assert.Equal(s.T(), 0, out.Workouts.Workouts)
+
+	_, _, err = progress.GetWeeklyReview(ctx, nil, progress.GetWeeklyReviewInput{Week: "March"})
+	assert.Error(s.T(), err)
+}
@@ -6,32 +6,46 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"personal/action/account"
 	"personal/action/add_food"
 	"personal/action/add_transactions"
+	"personal/action/budget_alerts"
 	"personal/action/compare_periods"
 	"personal/action/create_exercise"
 	"personal/action/delete_transaction"
 	"personal/action/delete_workout_set"
 	"personal/action/edit_exercise"
 	"personal/action/edit_transactions"
+	"personal/action/export_transactions"
+	"personal/action/find_correlations"
 	"personal/action/find_food"
 	"personal/action/get_balance"
 	"personal/action/get_budget_progress"
+	"personal/action/get_cashflow_report"
 	"personal/action/get_exercise_history"
 	"personal/action/get_personal_records"
 	"personal/action/get_spending_by_category"
 	"personal/action/get_top_merchants"
 	"personal/action/get_transactions"
+	"personal/action/import_profile"
 	"personal/action/list_exercises"
 	"personal/action/list_workouts"
 	"personal/action/log_food"
 	"personal/action/log_workout_set"
 	"personal/action/merge_exercises"
+	"personal/action/notifications"
 	"personal/action/nutrition_stats"
 	"personal/action/progress"
+	"personal/action/recurring"
+	"personal/action/reminders"
+	"personal/action/savings_goals"
 	"personal/action/search_exercises"
 	"personal/action/set_budget"
+	"personal/action/split_transaction"
+	"personal/action/suggest_categories"
+	"personal/action/tags"
 	"personal/action/top_products"
+	"personal/action/transfer_pairs"
 	"personal/gateways"
 )
 
@@ -169,7 +183,9 @@ This MCP server provides tools for managing food database, nutrition tracking, w
 
 All logs include timestamps and comprehensive details for accurate tracking.`
 
-func Server(db gateways.DB) *mcp.Server {
+// Server builds the MCP server. embedder enables semantic note search and
+// may be nil.
+func Server(db gateways.DB, embedder gateways.Embedder) *mcp.Server {
 	server := mcp.NewServer(
 		&mcp.Implementation{Name: "personal", Title: "Nikita personal food and activities logging", Version: "v1.0.0"},
 		&mcp.ServerOptions{
@@ -184,6 +200,9 @@ func Server(db gateways.DB) *mcp.Server {
 		return func(ctx context.Context, method string, req mcp.Request) (result mcp.Result, err error) {
 			// Add database to context
 			ctx = gateways.WithDB(ctx, db)
+			if embedder != nil {
+				ctx = gateways.WithEmbedder(ctx, embedder)
+			}
 
 			return handler(ctx, method, req)
 		}
@@ -229,10 +248,26 @@ func Server(db gateways.DB) *mcp.Server {
 	mcp.AddTool(server, &progress.EditActivityMCPDefinition, progress.EditActivity)
 	mcp.AddTool(server, &progress.GetActivityListMCPDefinition, progress.GetActivityList)
 	mcp.AddTool(server, &progress.GetProgressTypeExamplesMCPDefinition, progress.GetProgressTypeExamples)
+	mcp.AddTool(server, &progress.SaveProgressScaleMCPDefinition, progress.SaveProgressScale)
+	mcp.AddTool(server, &progress.DeleteProgressScaleMCPDefinition, progress.DeleteProgressScale)
 	mcp.AddTool(server, &progress.GetActivityStatsMCPDefinition, progress.GetActivityStats)
+	mcp.AddTool(server, &progress.SaveMilestoneMCPDefinition, progress.SaveMilestone)
+	mcp.AddTool(server, &progress.DeleteMilestoneMCPDefinition, progress.DeleteMilestone)
 	mcp.AddTool(server, &progress.CreateProgressPointMCPDefinition, progress.CreateProgressPoint)
+	mcp.AddTool(server, &progress.EditProgressPointMCPDefinition, progress.EditProgressPoint)
+	mcp.AddTool(server, &progress.DeleteProgressPointMCPDefinition, progress.DeleteProgressPoint)
 	mcp.AddTool(server, &progress.FinishActivityMCPDefinition, progress.FinishActivity)
 	mcp.AddTool(server, &progress.SearchProgressNotesMCPDefinition, progress.SearchProgressNotes)
+	mcp.AddTool(server, &progress.CreateLifePartMCPDefinition, progress.CreateLifePart)
+	mcp.AddTool(server, &progress.ListLifePartsMCPDefinition, progress.ListLifeParts)
+	mcp.AddTool(server, &progress.EditLifePartMCPDefinition, progress.EditLifePart)
+	mcp.AddTool(server, &progress.DeleteLifePartMCPDefinition, progress.DeleteLifePart)
+	mcp.AddTool(server, &progress.GetLifeBalanceMCPDefinition, progress.GetLifeBalance)
+	mcp.AddTool(server, &progress.GetAdherenceReportMCPDefinition, progress.GetAdherenceReport)
+	mcp.AddTool(server, &progress.GetWeeklyReviewMCPDefinition, progress.GetWeeklyReview)
+	mcp.AddTool(server, &reminders.SetCheckInRemindersMCPDefinition, reminders.SetCheckInReminders)
+	mcp.AddTool(server, &reminders.CheckCheckInRemindersMCPDefinition, reminders.CheckCheckInReminders)
+	mcp.AddTool(server, &find_correlations.MCPDefinition, find_correlations.FindCorrelations)
 
 	// Money tracking tools
 	mcp.AddTool(server, &add_transactions.MCPDefinition, add_transactions.AddTransactions)
@@ -245,6 +280,29 @@ func Server(db gateways.DB) *mcp.Server {
 	mcp.AddTool(server, &compare_periods.MCPDefinition, compare_periods.ComparePeriods)
 	mcp.AddTool(server, &get_budget_progress.MCPDefinition, get_budget_progress.GetBudgetProgress)
 	mcp.AddTool(server, &get_balance.MCPDefinition, get_balance.GetBalance)
+	mcp.AddTool(server, &get_cashflow_report.MCPDefinition, get_cashflow_report.GetCashflowReport)
+	mcp.AddTool(server, &export_transactions.MCPDefinition, export_transactions.ExportTransactions)
+	mcp.AddTool(server, &suggest_categories.MCPDefinition, suggest_categories.SuggestCategories)
+	mcp.AddTool(server, &split_transaction.MCPDefinition, split_transaction.SplitTransaction)
+	mcp.AddTool(server, &tags.TagTransactionsMCPDefinition, tags.TagTransactions)
+	mcp.AddTool(server, &tags.ListTagsMCPDefinition, tags.ListTags)
+	mcp.AddTool(server, &tags.RenameTagMCPDefinition, tags.RenameTag)
+	mcp.AddTool(server, &tags.DeleteTagMCPDefinition, tags.DeleteTag)
+	mcp.AddTool(server, &import_profile.SaveImportProfileMCPDefinition, import_profile.SaveImportProfile)
+	mcp.AddTool(server, &import_profile.ListImportProfilesMCPDefinition, import_profile.ListImportProfiles)
+	mcp.AddTool(server, &account.SaveAccountMCPDefinition, account.SaveAccount)
+	mcp.AddTool(server, &account.GetAccountBalancesMCPDefinition, account.GetAccountBalances)
+	mcp.AddTool(server, &account.ReconcileAccountMCPDefinition, account.ReconcileAccount)
+	mcp.AddTool(server, &savings_goals.SaveSavingsGoalMCPDefinition, savings_goals.SaveSavingsGoal)
+	mcp.AddTool(server, &savings_goals.CheckSavingsGoalsMCPDefinition, savings_goals.CheckSavingsGoals)
+	mcp.AddTool(server, &transfer_pairs.PairTransfersMCPDefinition, transfer_pairs.PairTransfers)
+	mcp.AddTool(server, &transfer_pairs.UnpairTransferMCPDefinition, transfer_pairs.UnpairTransfer)
+	mcp.AddTool(server, &recurring.DetectRecurringMCPDefinition, recurring.DetectRecurring)
+	mcp.AddTool(server, &recurring.ListRecurringMCPDefinition, recurring.ListRecurring)
+	mcp.AddTool(server, &budget_alerts.SetBudgetAlertsMCPDefinition, budget_alerts.SetBudgetAlerts)
+	mcp.AddTool(server, &budget_alerts.CheckBudgetAlertsMCPDefinition, budget_alerts.CheckBudgetAlerts)
+	mcp.AddTool(server, &notifications.ListNotificationsMCPDefinition, notifications.ListNotifications)
+	mcp.AddTool(server, &notifications.MarkNotificationsReadMCPDefinition, notifications.MarkNotificationsRead)
 
 	return server
 }